@@ -0,0 +1,315 @@
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"session-19/model"
+)
+
+// Format identifies portfolio backup archives
+const Format = "portfolio-backup"
+
+// Version is the archive layout this build writes. Archives of a newer version are rejected.
+// Version 2 added education, certifications, testimonials, testimonial invites, slug redirects and SEO settings.
+const Version = 2
+
+// MaxArchiveSize bounds an archive accepted for restore
+const MaxArchiveSize = 1 << 30
+
+// Archive layout: manifest.json, one JSON file per entity under data/, and
+// uploaded files under files/ by storage key
+const (
+	manifestName = "manifest.json"
+	dataDir      = "data/"
+	filesDir     = "files/"
+)
+
+// maxDataSize bounds one decompressed entity file, so a crafted archive cannot exhaust memory
+const maxDataSize = 64 << 20
+
+// Archive errors
+var (
+	ErrNotBackup          = errors.New("not a portfolio backup archive")
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrCorrupt            = errors.New("backup archive is corrupt")
+)
+
+// Manifest describes an archive
+type Manifest struct {
+	Format    string         `json:"format"`
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	Counts    map[string]int `json:"counts"`
+	Files     []File         `json:"files"`
+	// Missing lists referenced uploads that were not found in storage when the archive was written
+	Missing []string `json:"missing,omitempty"`
+}
+
+// File is an uploaded file stored in an archive
+type File struct {
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// entity is one data/ file of an archive
+type entity struct {
+	name  string
+	value any
+	// since is the first archive version holding the entity; older archives restore it as empty
+	since int
+}
+
+// entities maps every part of data to its file, in restore order
+func entities(data *model.BackupData) []entity {
+	return []entity{
+		{"profile", &data.Profile, 1},
+		{"experiences", &data.Experiences, 1},
+		{"skills", &data.Skills, 1},
+		{"projects", &data.Projects, 1},
+		{"publications", &data.Publications, 1},
+		{"project_slug_redirects", &data.ProjectSlugRedirects, 2},
+		{"publication_slug_redirects", &data.PublicationSlugRedirects, 2},
+		{"education", &data.Education, 2},
+		{"certifications", &data.Certifications, 2},
+		{"testimonial_invites", &data.TestimonialInvites, 2},
+		{"testimonials", &data.Testimonials, 2},
+		{"media", &data.Media, 1},
+		{"cv_versions", &data.CVVersions, 1},
+		{"users", &data.Users, 1},
+		{"seo", &data.SEO, 2},
+	}
+}
+
+// counts returns the number of records of each entity
+func counts(data *model.BackupData) map[string]int {
+	profiles, seo := 0, 0
+	if data.Profile != nil {
+		profiles = 1
+	}
+	if data.SEO != nil {
+		seo = 1
+	}
+	return map[string]int{
+		"profile":                    profiles,
+		"experiences":                len(data.Experiences),
+		"skills":                     len(data.Skills),
+		"projects":                   len(data.Projects),
+		"publications":               len(data.Publications),
+		"project_slug_redirects":     len(data.ProjectSlugRedirects),
+		"publication_slug_redirects": len(data.PublicationSlugRedirects),
+		"education":                  len(data.Education),
+		"certifications":             len(data.Certifications),
+		"testimonial_invites":        len(data.TestimonialInvites),
+		"testimonials":               len(data.Testimonials),
+		"media":                      len(data.Media),
+		"cv_versions":                len(data.CVVersions),
+		"users":                      len(data.Users),
+		"seo":                        seo,
+	}
+}
+
+// Writer writes an archive. The manifest is written last, by Close.
+type Writer struct {
+	zw       *zip.Writer
+	manifest Manifest
+	keys     map[string]bool
+}
+
+// NewWriter creates a Writer that writes an archive created at createdAt to w
+func NewWriter(w io.Writer, createdAt time.Time) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: Manifest{
+			Format:    Format,
+			Version:   Version,
+			CreatedAt: createdAt.UTC(),
+			Counts:    map[string]int{},
+			Files:     []File{},
+		},
+		keys: map[string]bool{},
+	}
+}
+
+// WriteData writes every entity of data
+func (w *Writer) WriteData(data *model.BackupData) error {
+	for _, e := range entities(data) {
+		if err := w.writeJSON(dataDir+e.name+".json", e.value); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.name, err)
+		}
+	}
+	w.manifest.Counts = counts(data)
+	return nil
+}
+
+// AddFile stores the content of r as the uploaded file with the given storage key
+func (w *Writer) AddFile(key string, r io.Reader) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid file key %q", key)
+	}
+	if w.keys[key] {
+		return nil
+	}
+
+	f, err := w.zw.Create(filesDir + key)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), r)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	w.keys[key] = true
+	w.manifest.Files = append(w.manifest.Files, File{Key: key, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))})
+	return nil
+}
+
+// AddMissing records a referenced file that could not be found
+func (w *Writer) AddMissing(key string) {
+	w.manifest.Missing = append(w.manifest.Missing, key)
+}
+
+// Close writes the manifest and finishes the archive. It does not close the underlying writer.
+func (w *Writer) Close() (*Manifest, error) {
+	if err := w.writeJSON(manifestName, w.manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := w.zw.Close(); err != nil {
+		return nil, err
+	}
+	return &w.manifest, nil
+}
+
+func (w *Writer) writeJSON(name string, v any) error {
+	f, err := w.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// Archive is an opened backup archive
+type Archive struct {
+	Manifest Manifest
+	Data     *model.BackupData
+
+	files map[string]*zip.File
+}
+
+// Open reads the manifest and data of an archive. It rejects archives that are
+// not backups or were written by a newer version, before anything is restored.
+func Open(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotBackup, err)
+	}
+
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+
+	a := &Archive{Data: &model.BackupData{}, files: map[string]*zip.File{}}
+	mf, ok := entries[manifestName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing", ErrNotBackup, manifestName)
+	}
+	if err := readJSON(mf, &a.Manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotBackup, err)
+	}
+	if a.Manifest.Format != Format {
+		return nil, fmt.Errorf("%w: unknown format %q", ErrNotBackup, a.Manifest.Format)
+	}
+	if a.Manifest.Version < 1 || a.Manifest.Version > Version {
+		return nil, fmt.Errorf("%w: archive is version %d, this build reads versions 1 to %d", ErrUnsupportedVersion, a.Manifest.Version, Version)
+	}
+
+	for _, e := range entities(a.Data) {
+		f, ok := entries[dataDir+e.name+".json"]
+		if !ok && a.Manifest.Version < e.since {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is missing", ErrCorrupt, e.name)
+		}
+		if err := readJSON(f, e.value); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrCorrupt, e.name, err)
+		}
+	}
+
+	for _, file := range a.Manifest.Files {
+		f, ok := entries[filesDir+file.Key]
+		if !ok || !validKey(file.Key) {
+			return nil, fmt.Errorf("%w: file %s is missing", ErrCorrupt, file.Key)
+		}
+		a.files[file.Key] = f
+	}
+	sort.Slice(a.Manifest.Files, func(i, j int) bool { return a.Manifest.Files[i].Key < a.Manifest.Files[j].Key })
+
+	return a, nil
+}
+
+// Verify reads every file and checks it against the size and checksum in the manifest
+func (a *Archive) Verify() error {
+	for _, file := range a.Manifest.Files {
+		rc, err := a.files[file.Key].Open()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrCorrupt, file.Key, err)
+		}
+		hash := sha256.New()
+		size, err := io.Copy(hash, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrCorrupt, file.Key, err)
+		}
+		if size != file.Size || hex.EncodeToString(hash.Sum(nil)) != file.SHA256 {
+			return fmt.Errorf("%w: %s does not match its checksum", ErrCorrupt, file.Key)
+		}
+	}
+	return nil
+}
+
+// OpenFile opens the uploaded file stored under key
+func (a *Archive) OpenFile(key string) (io.ReadCloser, error) {
+	f, ok := a.files[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: file %s is missing", ErrCorrupt, key)
+	}
+	return f.Open()
+}
+
+func readJSON(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxDataSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxDataSize {
+		return fmt.Errorf("%s is too large", f.Name)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// validKey rejects keys that could escape the files/ directory
+func validKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, "/") && !strings.Contains(key, "\\") && path.Clean(key) == key && key != ".." && !strings.HasPrefix(key, "../")
+}
@@ -0,0 +1,190 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestArchive(t *testing.T, data *model.BackupData, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, w.WriteData(data))
+	for key, content := range files {
+		require.NoError(t, w.AddFile(key, strings.NewReader(content)))
+	}
+	_, err := w.Close()
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+// rewrite copies an archive, letting edit replace the content of any entry or drop it by returning nil
+func rewrite(t *testing.T, archive []byte, edit func(name string, content []byte) []byte) []byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+
+		content = edit(f.Name, content)
+		if content == nil {
+			continue
+		}
+		out, err := zw.Create(f.Name)
+		require.NoError(t, err)
+		out.Write(content)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	data := &model.BackupData{
+		Profile:              &model.Profile{ID: 1, Name: "Jane Doe"},
+		Projects:             []model.Project{{ID: 3, Title: "Site", ImageURL: "/public/assets/uploads/projects/3.png"}},
+		Education:            []model.Education{{ID: 4, Institution: "University", StartDate: "2018-09-01"}},
+		Certifications:       []model.Certification{{ID: 5, Name: "CKA", Issuer: "CNCF"}},
+		ProjectSlugRedirects: []model.SlugRedirect{{Slug: "old-site", TargetID: 3}},
+		SEO:                  &model.SEOSettings{Title: "Jane Doe", Robots: "Disallow: /drafts"},
+		Users:                []model.BackupUser{{Email: "admin@example.com", Name: "Admin", Role: "admin"}},
+	}
+	archive := writeTestArchive(t, data, map[string]string{"uploads/projects/3.png": "png bytes"})
+
+	a, err := Open(bytes.NewReader(archive), int64(len(archive)))
+
+	require.NoError(t, err)
+	assert.Equal(t, Version, a.Manifest.Version)
+	assert.Equal(t, 1, a.Manifest.Counts["projects"])
+	assert.Equal(t, 1, a.Manifest.Counts["education"])
+	assert.Equal(t, 1, a.Manifest.Counts["certifications"])
+	assert.Equal(t, data, a.Data)
+	require.NoError(t, a.Verify())
+	require.Len(t, a.Manifest.Files, 1)
+
+	rc, err := a.OpenFile("uploads/projects/3.png")
+	require.NoError(t, err)
+	defer rc.Close()
+	content, _ := io.ReadAll(rc)
+	assert.Equal(t, "png bytes", string(content))
+}
+
+func TestArchive_NeverContainsPasswords(t *testing.T) {
+	archive := writeTestArchive(t, &model.BackupData{Users: []model.BackupUser{{Email: "admin@example.com"}}}, nil)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		assert.NotContains(t, string(content), "password", f.Name)
+	}
+}
+
+func TestOpen_RejectsNewerVersion(t *testing.T) {
+	archive := rewrite(t, writeTestArchive(t, &model.BackupData{}, nil), func(name string, content []byte) []byte {
+		if name != manifestName {
+			return content
+		}
+		var m map[string]any
+		json.Unmarshal(content, &m)
+		m["version"] = Version + 1
+		out, _ := json.Marshal(m)
+		return out
+	})
+
+	_, err := Open(bytes.NewReader(archive), int64(len(archive)))
+
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestOpen_AcceptsVersion1WithoutLaterEntities(t *testing.T) {
+	data := &model.BackupData{Projects: []model.Project{{ID: 3, Title: "Site"}}}
+	later := map[string]bool{}
+	for _, e := range entities(&model.BackupData{}) {
+		if e.since > 1 {
+			later[dataDir+e.name+".json"] = true
+		}
+	}
+	archive := rewrite(t, writeTestArchive(t, data, nil), func(name string, content []byte) []byte {
+		switch {
+		case later[name]:
+			return nil
+		case name == manifestName:
+			var m map[string]any
+			json.Unmarshal(content, &m)
+			m["version"] = 1
+			out, _ := json.Marshal(m)
+			return out
+		}
+		return content
+	})
+
+	a, err := Open(bytes.NewReader(archive), int64(len(archive)))
+
+	require.NoError(t, err)
+	assert.Equal(t, data.Projects, a.Data.Projects)
+	assert.Empty(t, a.Data.Education)
+	assert.Empty(t, a.Data.Certifications)
+	assert.Empty(t, a.Data.ProjectSlugRedirects)
+	assert.Nil(t, a.Data.SEO)
+}
+
+func TestOpen_RejectsMissingEntity(t *testing.T) {
+	archive := rewrite(t, writeTestArchive(t, &model.BackupData{}, nil), func(name string, content []byte) []byte {
+		if name == dataDir+"education.json" {
+			return nil
+		}
+		return content
+	})
+
+	_, err := Open(bytes.NewReader(archive), int64(len(archive)))
+
+	assert.ErrorIs(t, err, ErrCorrupt)
+}
+
+func TestOpen_RejectsOtherArchives(t *testing.T) {
+	_, err := Open(strings.NewReader("not a zip"), 9)
+	assert.ErrorIs(t, err, ErrNotBackup)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("readme.txt")
+	f.Write([]byte("hello"))
+	zw.Close()
+
+	_, err = Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.ErrorIs(t, err, ErrNotBackup)
+}
+
+func TestVerify_DetectsModifiedFile(t *testing.T) {
+	archive := rewrite(t, writeTestArchive(t, &model.BackupData{}, map[string]string{"uploads/a.png": "original"}),
+		func(name string, content []byte) []byte {
+			if name == filesDir+"uploads/a.png" {
+				return []byte("tampered")
+			}
+			return content
+		})
+
+	a, err := Open(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, a.Verify(), ErrCorrupt)
+}
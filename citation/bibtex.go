@@ -0,0 +1,89 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+
+	"session-19/model"
+)
+
+// bibEscaper escapes the characters LaTeX treats specially in field values
+var bibEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// bibVerbatimEscaper percent-encodes braces in doi and url, which are read verbatim,
+// so a stray brace cannot close the field early or swallow the rest of the file
+var bibVerbatimEscaper = strings.NewReplacer(`{`, `%7B`, `}`, `%7D`)
+
+func bibtex(pubs []model.Publication) string {
+	var b strings.Builder
+	for i, key := range keys(pubs) {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		writeBibEntry(&b, pubs[i], key)
+	}
+	return b.String()
+}
+
+func writeBibEntry(b *strings.Builder, p model.Publication, key string) {
+	entryType, venueField := bibType(p)
+	b.WriteString("@" + entryType + "{" + key + ",\n")
+
+	field := func(name, value string) {
+		if value != "" {
+			b.WriteString("  " + name + " = {" + value + "},\n")
+		}
+	}
+	var authors []string
+	for _, n := range AuthorNames(p.Authors) {
+		authors = append(authors, bibEscaper.Replace(bibName(n)))
+	}
+	field("author", strings.Join(authors, " and "))
+	// Double braces keep the title's capitalization in every style
+	if p.Title != "" {
+		field("title", "{"+bibEscaper.Replace(p.Title)+"}")
+	}
+	field(venueField, bibEscaper.Replace(p.Journal))
+	field("volume", bibEscaper.Replace(p.Volume))
+	field("pages", bibEscaper.Replace(pagesWith(p.Pages, "--")))
+	if p.Year > 0 {
+		field("year", strconv.Itoa(p.Year))
+	}
+	// doi and url are read verbatim by BibLaTeX and the url package, so only their braces
+	// are encoded; DOI resolvers and browsers decode them again
+	field("doi", bibVerbatimEscaper.Replace(p.DOI))
+	field("url", bibVerbatimEscaper.Replace(p.PublicationURL))
+	b.WriteString("}\n")
+}
+
+// bibType returns the entry type of a publication and the field that names its venue
+func bibType(p model.Publication) (string, string) {
+	switch {
+	case p.Journal == "":
+		return "misc", "howpublished"
+	case p.VenueType == model.VenueConference:
+		return "inproceedings", "booktitle"
+	case p.VenueType == model.VenuePreprint:
+		return "misc", "howpublished"
+	}
+	return "article", "journal"
+}
+
+// bibName writes a name as "Family, Given", which BibTeX never splits wrongly
+func bibName(n Name) string {
+	if n.Given == "" {
+		return n.Family
+	}
+	return n.Family + ", " + n.Given
+}
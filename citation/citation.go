@@ -0,0 +1,213 @@
+// Package citation formats publications for reference managers (BibTeX, RIS,
+// CSL-JSON) and as ready-made reference strings (APA 7th edition and IEEE).
+package citation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"session-19/model"
+	"session-19/utils"
+)
+
+// Format names, as accepted by the ?format= query parameter
+const (
+	BibTeX  = "bibtex"
+	RIS     = "ris"
+	CSLJSON = "csl-json"
+	APA     = "apa"
+	IEEE    = "ieee"
+)
+
+// Format describes one citation output
+type Format struct {
+	Name        string
+	Label       string
+	ContentType string
+	// Ext is the extension of a downloaded file, including the dot
+	Ext string
+}
+
+// Formats lists the supported formats, BibTeX first as the default
+var Formats = []Format{
+	{Name: BibTeX, Label: "BibTeX", ContentType: "application/x-bibtex; charset=utf-8", Ext: ".bib"},
+	{Name: RIS, Label: "RIS", ContentType: "application/x-research-info-systems; charset=utf-8", Ext: ".ris"},
+	{Name: CSLJSON, Label: "CSL-JSON", ContentType: "application/vnd.citationstyles.csl+json", Ext: ".json"},
+	{Name: APA, Label: "APA", ContentType: "text/plain; charset=utf-8", Ext: ".txt"},
+	{Name: IEEE, Label: "IEEE", ContentType: "text/plain; charset=utf-8", Ext: ".txt"},
+}
+
+// BibPath is the public route of the BibTeX file of every publication
+const BibPath = "/publications.bib"
+
+// Lookup returns the format named name
+func Lookup(name string) (Format, bool) {
+	for _, f := range Formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Names returns the names of the supported formats
+func Names() []string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// Cite formats the publications in the named format. BibTeX, RIS and CSL-JSON
+// hold every publication in one document; APA and IEEE put one reference per line.
+func Cite(format string, pubs ...model.Publication) (string, error) {
+	switch format {
+	case BibTeX:
+		return bibtex(pubs), nil
+	case RIS:
+		return ris(pubs), nil
+	case CSLJSON:
+		return cslJSON(pubs)
+	case APA:
+		return lines(pubs, apa), nil
+	case IEEE:
+		return lines(pubs, ieee), nil
+	}
+	return "", fmt.Errorf("unknown citation format %q", format)
+}
+
+// Filename returns the download name of a publication's citation, e.g. "deep-learning.bib"
+func Filename(pub model.Publication, f Format) string {
+	name := pub.Slug
+	if name == "" {
+		name = "publication-" + strconv.FormatInt(pub.ID, 10)
+	}
+	return name + f.Ext
+}
+
+func lines(pubs []model.Publication, format func(model.Publication) string) string {
+	var b strings.Builder
+	for _, p := range pubs {
+		b.WriteString(format(p))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Name is an author split into given and family names
+type Name struct {
+	Given  string
+	Family string
+}
+
+// honorifics are dropped from author names, e.g. "Dr. John Doe"
+var honorifics = map[string]bool{"dr.": true, "dr": true, "prof.": true, "prof": true, "mr.": true, "mrs.": true, "ms.": true}
+
+// AuthorNames splits the authors of a publication into given and family names
+func AuthorNames(authors model.Authors) []Name {
+	names := make([]Name, 0, len(authors))
+	for _, a := range authors {
+		if n := SplitName(a.Name); n.Family != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// SplitName splits an author name written "Given Family" or "Family, Given".
+// Without a comma the last word is taken as the family name.
+func SplitName(name string) Name {
+	if family, given, ok := strings.Cut(name, ","); ok {
+		return Name{Given: strings.Join(strings.Fields(given), " "), Family: strings.Join(strings.Fields(family), " ")}
+	}
+	words := strings.Fields(name)
+	for len(words) > 1 && honorifics[strings.ToLower(words[0])] {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return Name{}
+	}
+	return Name{
+		Given:  strings.Join(words[:len(words)-1], " "),
+		Family: words[len(words)-1],
+	}
+}
+
+// Initials abbreviates the given names, "John Ronald" to "J. R.", keeping hyphenated parts
+func (n Name) Initials() string {
+	var parts []string
+	for _, given := range strings.Fields(n.Given) {
+		var hyphenated []string
+		for _, part := range strings.Split(given, "-") {
+			if r := []rune(part); len(r) > 0 {
+				hyphenated = append(hyphenated, string(r[0])+".")
+			}
+		}
+		parts = append(parts, strings.Join(hyphenated, "-"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Key returns the BibTeX key of a publication: family name of the first author,
+// year and first title word, e.g. "doe2023implementation"
+func Key(pub model.Publication) string {
+	var b strings.Builder
+	if names := AuthorNames(pub.Authors); len(names) > 0 {
+		b.WriteString(compact(names[0].Family))
+	}
+	if pub.Year > 0 {
+		b.WriteString(strconv.Itoa(pub.Year))
+	}
+	for _, word := range strings.Split(utils.Slugify(pub.Title), "-") {
+		if len(word) > 3 {
+			b.WriteString(word)
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return "publication" + strconv.FormatInt(pub.ID, 10)
+	}
+	return b.String()
+}
+
+// keys returns a key per publication, with "a", "b"... appended to duplicates
+func keys(pubs []model.Publication) []string {
+	out := make([]string, len(pubs))
+	count := map[string]int{}
+	for i, p := range pubs {
+		out[i] = Key(p)
+		count[out[i]]++
+	}
+	seen := map[string]int{}
+	for i, key := range out {
+		if count[key] > 1 {
+			out[i] = key + string(rune('a'+seen[key]%26))
+			seen[key]++
+		}
+	}
+	return out
+}
+
+// pageRange splits pages such as "34-56", "34--56" or "34–56" into the first
+// and last page; last is empty for a single page or an article number
+func pageRange(pages string) (string, string) {
+	pages = strings.NewReplacer("--", "-", "–", "-", "—", "-").Replace(pages)
+	first, last, _ := strings.Cut(pages, "-")
+	return strings.TrimSpace(first), strings.TrimSpace(last)
+}
+
+// pagesWith joins a page range with sep, e.g. "34–56"
+func pagesWith(pages, sep string) string {
+	first, last := pageRange(pages)
+	if last == "" {
+		return first
+	}
+	return first + sep + last
+}
+
+// compact keeps the ASCII letters and digits of s, lower-cased
+func compact(s string) string {
+	return strings.ReplaceAll(utils.Slugify(s), "-", "")
+}
@@ -0,0 +1,204 @@
+package citation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePub() model.Publication {
+	return model.Publication{
+		ID:        3,
+		Title:     "Implementation of Microservices Architecture in E-Commerce Systems",
+		Authors:   model.Authors{{Name: "Alvin Maulana", IsMe: true}, {Name: "Dr. John Ronald Doe", Affiliation: "MIT"}},
+		Journal:   "International Journal of Software Engineering",
+		VenueType: model.VenueJournal,
+		Volume:    "12",
+		Pages:     "34-56",
+		Year:      2023,
+		DOI:       "10.1000/example1",
+		Slug:      "microservices-e-commerce",
+	}
+}
+
+// authors builds an author list from names
+func authors(names ...string) model.Authors {
+	var list model.Authors
+	for _, name := range names {
+		list = append(list, model.Author{Name: name})
+	}
+	return list
+}
+
+func TestAuthorNames(t *testing.T) {
+	assert.Equal(t, []Name{
+		{Given: "Alvin", Family: "Maulana"},
+		{Given: "John Ronald", Family: "Doe"},
+		{Family: "Plato"},
+		{Given: "Jean-Paul", Family: "Sartre"},
+		{Given: "Ludwig", Family: "van Beethoven"},
+	}, AuthorNames(authors("Alvin Maulana", "Dr. John Ronald Doe", "Plato", " ", "Jean-Paul Sartre", "van Beethoven, Ludwig")))
+
+	assert.Equal(t, "J.-P.", Name{Given: "Jean-Paul", Family: "Sartre"}.Initials())
+}
+
+func TestBibTeX(t *testing.T) {
+	pub := samplePub()
+	pub.Journal = "Systems & Software"
+	pub.PublicationURL = "https://example.com/paper_1"
+
+	out, err := Cite(BibTeX, pub)
+
+	require.NoError(t, err)
+	assert.Equal(t, `@article{maulana2023implementation,
+  author = {Maulana, Alvin and Doe, John Ronald},
+  title = {{Implementation of Microservices Architecture in E-Commerce Systems}},
+  journal = {Systems \& Software},
+  volume = {12},
+  pages = {34--56},
+  year = {2023},
+  doi = {10.1000/example1},
+  url = {https://example.com/paper_1},
+}
+`, out)
+}
+
+func TestBibTeX_EncodesBracesInVerbatimFields(t *testing.T) {
+	pub := samplePub()
+	pub.DOI = "10.1000/a}b"
+	pub.PublicationURL = "https://example.com/?q={x"
+	other := samplePub()
+	other.Title = "Second Paper"
+
+	out, err := Cite(BibTeX, pub, other)
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "  doi = {10.1000/a%7Db},\n")
+	assert.Contains(t, out, "  url = {https://example.com/?q=%7Bx},\n")
+	// Both entries survive, so the stray braces did not unbalance the file
+	pubs, err := ParseBibTeX(out)
+	require.NoError(t, err)
+	require.Len(t, pubs, 2)
+	assert.Equal(t, "Second Paper", pubs[1].Title)
+}
+
+func TestBibTeX_VenueTypes(t *testing.T) {
+	pub := samplePub()
+	pub.VenueType = model.VenueConference
+	pub.Journal = "Proc. of GopherCon"
+	out, err := Cite(BibTeX, pub)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "@inproceedings{"), out)
+	assert.Contains(t, out, "  booktitle = {Proc. of GopherCon},\n")
+
+	pub.VenueType = model.VenuePreprint
+	pub.Journal = "arXiv"
+	out, err = Cite(BibTeX, pub)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "@misc{"), out)
+	assert.Contains(t, out, "  howpublished = {arXiv},\n")
+}
+
+func TestBibTeX_BulkKeysAreUnique(t *testing.T) {
+	other := samplePub()
+	other.Title = "Implementation Notes"
+	untitled := model.Publication{ID: 9}
+
+	out, err := Cite(BibTeX, samplePub(), other, untitled)
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "@article{maulana2023implementationa,")
+	assert.Contains(t, out, "@article{maulana2023implementationb,")
+	assert.Contains(t, out, "@misc{publication9,\n}")
+}
+
+func TestRIS(t *testing.T) {
+	out, err := Cite(RIS, samplePub())
+
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"TY  - JOUR",
+		"AU  - Maulana, Alvin",
+		"AU  - Doe, John Ronald",
+		"TI  - Implementation of Microservices Architecture in E-Commerce Systems",
+		"T2  - International Journal of Software Engineering",
+		"VL  - 12",
+		"SP  - 34",
+		"EP  - 56",
+		"PY  - 2023",
+		"DO  - 10.1000/example1",
+		"ER  - ",
+		"",
+	}, "\r\n"), out)
+}
+
+func TestCSLJSON(t *testing.T) {
+	out, err := Cite(CSLJSON, samplePub())
+	require.NoError(t, err)
+
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, "article-journal", items[0]["type"])
+	assert.Equal(t, "International Journal of Software Engineering", items[0]["container-title"])
+	assert.Equal(t, "12", items[0]["volume"])
+	assert.Equal(t, "34-56", items[0]["page"])
+	assert.Equal(t, "10.1000/example1", items[0]["DOI"])
+	assert.Equal(t, map[string]any{"date-parts": []any{[]any{float64(2023)}}}, items[0]["issued"])
+	assert.Equal(t, []any{
+		map[string]any{"family": "Maulana", "given": "Alvin"},
+		map[string]any{"family": "Doe", "given": "John Ronald"},
+	}, items[0]["author"])
+}
+
+func TestAPA(t *testing.T) {
+	assert.Equal(t, "Maulana, A., & Doe, J. R. (2023). Implementation of Microservices Architecture in E-Commerce Systems. "+
+		"International Journal of Software Engineering, 12, 34–56. https://doi.org/10.1000/example1", apa(samplePub()))
+
+	assert.Equal(t, "Is Go fast? (n.d.).", apa(model.Publication{Title: "Is Go fast?"}))
+
+	conference := model.Publication{Title: "Paper", Authors: authors("Ann Lee"), Year: 2021, Journal: "Proc. of X",
+		VenueType: model.VenueConference, Pages: "7", PublicationURL: "https://example.com/p"}
+	assert.Equal(t, "Lee, A. (2021). Paper. In Proc. of X (p. 7). https://example.com/p", apa(conference))
+
+	preprint := model.Publication{Title: "Draft", Authors: authors("Ann Lee"), Year: 2024, Journal: "arXiv", VenueType: model.VenuePreprint}
+	assert.Equal(t, "Lee, A. (2024). Draft [Preprint]. arXiv.", apa(preprint))
+
+	many := model.Publication{Title: "Big", Year: 2020}
+	for _, letter := range "ABCDEFGHIJKLMNOPQRSTUV" {
+		many.Authors = append(many.Authors, model.Author{Name: "X " + string(letter)})
+	}
+	assert.Contains(t, apa(many), "S, X., … V, X. (2020). Big.")
+}
+
+func TestIEEE(t *testing.T) {
+	assert.Equal(t, `A. Maulana and J. R. Doe, "Implementation of Microservices Architecture in E-Commerce Systems," `+
+		`International Journal of Software Engineering, vol. 12, pp. 34–56, 2023, doi: 10.1000/example1.`, ieee(samplePub()))
+
+	three := model.Publication{Title: "Paper", Authors: authors("Ann Lee", "Bo Kim", "Cy Park")}
+	assert.Equal(t, `A. Lee, B. Kim, and C. Park, "Paper."`, ieee(three))
+
+	seven := model.Publication{Title: "Paper", Year: 2021, Authors: authors("A One", "B Two", "C Three", "D Four", "E Five", "F Six", "G Seven")}
+	assert.Equal(t, `A. One et al., "Paper," 2021.`, ieee(seven))
+
+	conference := model.Publication{Title: "Paper", Authors: authors("Ann Lee"), Year: 2021, Journal: "Proc. of X",
+		VenueType: model.VenueConference, Pages: "1--9", PublicationURL: "https://example.com/p"}
+	assert.Equal(t, `A. Lee, "Paper," in Proc. of X, 2021, pp. 1–9. [Online]. Available: https://example.com/p`, ieee(conference))
+}
+
+func TestLookupAndFilename(t *testing.T) {
+	f, ok := Lookup(RIS)
+	require.True(t, ok)
+	assert.Equal(t, "microservices-e-commerce.ris", Filename(samplePub(), f))
+	assert.Equal(t, "publication-9.ris", Filename(model.Publication{ID: 9}, f))
+
+	_, ok = Lookup("mla")
+	assert.False(t, ok)
+	_, err := Cite("mla", samplePub())
+	assert.Error(t, err)
+}
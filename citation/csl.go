@@ -0,0 +1,70 @@
+package citation
+
+import (
+	"encoding/json"
+
+	"session-19/model"
+)
+
+// CSLItem is a publication in the Citation Style Language JSON schema,
+// read by Zotero, Mendeley, Pandoc and citation.js
+type CSLItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Author         []CSLName `json:"author,omitempty"`
+	ContainerTitle string    `json:"container-title,omitempty"`
+	Volume         string    `json:"volume,omitempty"`
+	Page           string    `json:"page,omitempty"`
+	Issued         *CSLDate  `json:"issued,omitempty"`
+	DOI            string    `json:"DOI,omitempty"`
+	URL            string    `json:"URL,omitempty"`
+}
+
+// CSLName is an author in CSL-JSON
+type CSLName struct {
+	Family string `json:"family"`
+	Given  string `json:"given,omitempty"`
+}
+
+// CSLDate is a CSL-JSON date; only the year is known
+type CSLDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// CSL returns the CSL-JSON items of the publications
+func CSL(pubs []model.Publication) []CSLItem {
+	items := make([]CSLItem, len(pubs))
+	for i, key := range keys(pubs) {
+		p := pubs[i]
+		item := CSLItem{ID: key, Type: cslType(p), Title: p.Title, ContainerTitle: p.Journal, Volume: p.Volume,
+			Page: pagesWith(p.Pages, "-"), DOI: p.DOI, URL: p.PublicationURL}
+		for _, n := range AuthorNames(p.Authors) {
+			item.Author = append(item.Author, CSLName{Family: n.Family, Given: n.Given})
+		}
+		if p.Year > 0 {
+			item.Issued = &CSLDate{DateParts: [][]int{{p.Year}}}
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// cslType returns the CSL item type; preprints are plain articles
+func cslType(p model.Publication) string {
+	switch {
+	case p.Journal == "" || p.VenueType == model.VenuePreprint:
+		return "article"
+	case p.VenueType == model.VenueConference:
+		return "paper-conference"
+	}
+	return "article-journal"
+}
+
+func cslJSON(pubs []model.Publication) (string, error) {
+	out, err := json.MarshalIndent(CSL(pubs), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
@@ -0,0 +1,185 @@
+package citation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"session-19/model"
+)
+
+// ErrDOINotFound is returned when the registry has no record of a DOI
+var ErrDOINotFound = errors.New("DOI not found")
+
+// doiPattern matches a DOI: the "10." directory, a registrant code and a suffix
+var doiPattern = regexp.MustCompile(`10\.\d{4,9}/\S+`)
+
+// DOI finds the DOI in s, which may be a bare DOI, "doi:10.…" or a doi.org link.
+// It returns "" when s holds none. DOIs are case-insensitive; compare them with strings.EqualFold.
+func DOI(s string) string {
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		s = unescaped
+	}
+	return strings.TrimRight(doiPattern.FindString(s), ".,;")
+}
+
+// Resolver looks up the metadata of a DOI
+type Resolver interface {
+	Resolve(ctx context.Context, doi string) (model.Publication, error)
+}
+
+// CrossrefURL is the public Crossref REST API
+const CrossrefURL = "https://api.crossref.org"
+
+// maxCrossrefResponse bounds the size of a work record read from the API
+const maxCrossrefResponse = 2 * 1024 * 1024
+
+// Crossref resolves DOIs with the Crossref REST API, or any server that answers
+// GET {BaseURL}/works/{doi} with the same JSON
+type Crossref struct {
+	BaseURL string
+	// Mailto is sent in the User-Agent, which gets requests into Crossref's polite pool
+	Mailto string
+	Client *http.Client
+}
+
+// NewCrossref creates a resolver against baseURL
+func NewCrossref(baseURL, mailto string) *Crossref {
+	return &Crossref{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Mailto:  mailto,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// DefaultCrossref creates a resolver configured from CROSSREF_URL and CROSSREF_MAILTO
+func DefaultCrossref() *Crossref {
+	baseURL := os.Getenv("CROSSREF_URL")
+	if baseURL == "" {
+		baseURL = CrossrefURL
+	}
+	return NewCrossref(baseURL, os.Getenv("CROSSREF_MAILTO"))
+}
+
+// crossrefWork is the part of a Crossref work record a publication is built from
+type crossrefWork struct {
+	Message struct {
+		DOI            string   `json:"DOI"`
+		Title          []string `json:"title"`
+		ContainerTitle []string `json:"container-title"`
+		Publisher      string   `json:"publisher"`
+		Abstract       string   `json:"abstract"`
+		Type           string   `json:"type"`
+		Volume         string   `json:"volume"`
+		Page           string   `json:"page"`
+		Author         []struct {
+			Given  string `json:"given"`
+			Family string `json:"family"`
+			// Name is set instead of given and family for organizations
+			Name string `json:"name"`
+		} `json:"author"`
+		Issued         crossrefDate `json:"issued"`
+		PublishedPrint crossrefDate `json:"published-print"`
+		Published      crossrefDate `json:"published"`
+	} `json:"message"`
+}
+
+type crossrefDate struct {
+	DateParts [][]*int `json:"date-parts"`
+}
+
+func (d crossrefDate) year() int {
+	if len(d.DateParts) == 0 || len(d.DateParts[0]) == 0 || d.DateParts[0][0] == nil {
+		return 0
+	}
+	return *d.DateParts[0][0]
+}
+
+// markupPattern matches the JATS and HTML tags Crossref leaves in titles and abstracts
+var markupPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Resolve fetches the work record of doi
+func (c *Crossref) Resolve(ctx context.Context, doi string) (model.Publication, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/works/"+url.PathEscape(doi), nil)
+	if err != nil {
+		return model.Publication{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	agent := "portfolio-publication-import/1.0"
+	if c.Mailto != "" {
+		agent += " (mailto:" + c.Mailto + ")"
+	}
+	req.Header.Set("User-Agent", agent)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return model.Publication{}, fmt.Errorf("failed to look up DOI %s: %w", doi, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return model.Publication{}, fmt.Errorf("%w: %s", ErrDOINotFound, doi)
+	case resp.StatusCode != http.StatusOK:
+		return model.Publication{}, fmt.Errorf("failed to look up DOI %s: %s", doi, resp.Status)
+	}
+
+	var work crossrefWork
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCrossrefResponse)).Decode(&work); err != nil {
+		return model.Publication{}, fmt.Errorf("failed to read metadata of DOI %s: %w", doi, err)
+	}
+	return work.publication(doi), nil
+}
+
+func (w crossrefWork) publication(doi string) model.Publication {
+	m := w.Message
+	var authors []string
+	for _, a := range m.Author {
+		authors = append(authors, strings.TrimSpace(first(strings.TrimSpace(a.Given+" "+a.Family), a.Name)))
+	}
+	venueType := model.VenueJournal
+	switch m.Type {
+	case "proceedings-article":
+		venueType = model.VenueConference
+	case "posted-content":
+		venueType = model.VenuePreprint
+	}
+	var title, journal string
+	if len(m.Title) > 0 {
+		title = m.Title[0]
+	}
+	if len(m.ContainerTitle) > 0 {
+		journal = m.ContainerTitle[0]
+	}
+
+	pub := model.Publication{
+		Title:       markupPattern.ReplaceAllString(title, ""),
+		Authors:     authorList(authors),
+		Journal:     first(journal, m.Publisher),
+		VenueType:   venueType,
+		Volume:      m.Volume,
+		Pages:       m.Page,
+		Year:        firstYear(m.Issued.year(), m.PublishedPrint.year(), m.Published.year()),
+		DOI:         first(m.DOI, doi),
+		Description: oneLine(markupPattern.ReplaceAllString(m.Abstract, " ")),
+	}
+	return clean(pub)
+}
+
+// firstYear returns the first known year
+func firstYear(values ...int) int {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package citation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDOI(t *testing.T) {
+	assert.Equal(t, "10.1000/xyz123", DOI("10.1000/xyz123"))
+	assert.Equal(t, "10.1000/xyz123", DOI("doi:10.1000/xyz123."))
+	assert.Equal(t, "10.1000/a(b)c", DOI("https://doi.org/10.1000/a%28b%29c"))
+	assert.Equal(t, "", DOI("https://example.com/paper"))
+}
+
+const crossrefWorkJSON = `{
+  "status": "ok",
+  "message-type": "work",
+  "message": {
+    "DOI": "10.1000/XYZ123",
+    "type": "proceedings-article",
+    "volume": "7",
+    "page": "101-110",
+    "title": ["On <i>Caching</i> at Scale"],
+    "container-title": ["Journal of Systems"],
+    "publisher": "Example Press",
+    "abstract": "<jats:p>We study\n caching.</jats:p>",
+    "author": [
+      {"given": "Jane", "family": "Doe", "sequence": "first"},
+      {"name": "The Caching Consortium", "sequence": "additional"}
+    ],
+    "issued": {"date-parts": [[null]]},
+    "published-print": {"date-parts": [[2024, 3]]}
+  }
+}`
+
+func TestCrossref_Resolve(t *testing.T) {
+	var agent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agent = r.UserAgent()
+		if r.URL.Path != "/works/10.1000/xyz123" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(crossrefWorkJSON))
+	}))
+	defer server.Close()
+
+	resolver := NewCrossref(server.URL+"/", "admin@example.com")
+	pub, err := resolver.Resolve(context.Background(), "10.1000/xyz123")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.Publication{
+		Title:       "On Caching at Scale",
+		Authors:     model.Authors{{Name: "Jane Doe"}, {Name: "The Caching Consortium"}},
+		Journal:     "Journal of Systems",
+		VenueType:   model.VenueConference,
+		Volume:      "7",
+		Pages:       "101-110",
+		Year:        2024,
+		DOI:         "10.1000/XYZ123",
+		Description: "We study caching.",
+	}, pub)
+	assert.Contains(t, agent, "(mailto:admin@example.com)")
+
+	_, err = resolver.Resolve(context.Background(), "10.1000/missing")
+	assert.ErrorIs(t, err, ErrDOINotFound)
+}
+
+func TestCrossref_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewCrossref(server.URL, "").Resolve(context.Background(), "10.1000/xyz123")
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDOINotFound)
+	assert.Contains(t, err.Error(), "503")
+}
@@ -0,0 +1,505 @@
+package citation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"session-19/model"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxEntries caps the entries read from one pasted document
+const MaxEntries = 100
+
+// Parse errors
+var (
+	ErrUnknownFormat = errors.New("the text is neither BibTeX (@article{...}) nor RIS (TY  - ...)")
+	ErrNoEntries     = errors.New("no publications found in the text")
+	ErrTooManyItems  = fmt.Errorf("at most %d publications can be imported at once", MaxEntries)
+)
+
+// risTagPattern matches a RIS line such as "AU  - Doe, John"
+var risTagPattern = regexp.MustCompile(`^([A-Z][A-Z0-9])  -(?: (.*))?$`)
+
+// Parse reads the publications of a BibTeX or RIS document, telling the two apart by their syntax
+func Parse(src string) ([]model.Publication, error) {
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "TY  -"):
+			return ParseRIS(src)
+		case strings.HasPrefix(line, "@"):
+			return ParseBibTeX(src)
+		}
+	}
+	return nil, ErrUnknownFormat
+}
+
+// ParseBibTeX reads the entries of a BibTeX document. @string abbreviations and
+// # concatenation are expanded; @comment and @preamble blocks are skipped.
+func ParseBibTeX(src string) ([]model.Publication, error) {
+	p := &bibParser{src: src, strings: map[string]string{}}
+	var pubs []model.Publication
+	for p.next() {
+		entryType, fields, err := p.entry()
+		if err != nil {
+			return nil, err
+		}
+		switch entryType {
+		case "comment", "preamble", "string":
+			continue
+		}
+		if len(pubs) == MaxEntries {
+			return nil, ErrTooManyItems
+		}
+		pubs = append(pubs, bibPublication(entryType, fields))
+	}
+	if len(pubs) == 0 {
+		return nil, ErrNoEntries
+	}
+	return pubs, nil
+}
+
+func bibPublication(entryType string, fields map[string]string) model.Publication {
+	pub := model.Publication{
+		Title:          fields["title"],
+		Authors:        authorList(bibAuthors(fields["author"])),
+		Journal:        first(fields["journal"], fields["booktitle"], fields["howpublished"], fields["archiveprefix"], fields["publisher"], fields["school"], fields["institution"]),
+		VenueType:      bibVenueType(entryType, fields),
+		Volume:         fields["volume"],
+		Pages:          fields["pages"],
+		Year:           year(fields["year"]),
+		DOI:            DOI(fields["doi"]),
+		Description:    fields["abstract"],
+		PublicationURL: fields["url"],
+	}
+	return clean(pub)
+}
+
+// preprintPattern recognizes preprint servers named in howpublished or archiveprefix
+var preprintPattern = regexp.MustCompile(`(?i)arxiv|biorxiv|medrxiv|ssrn|preprint`)
+
+// bibVenueType tells conference papers and preprints from journal articles by the entry type and fields
+func bibVenueType(entryType string, fields map[string]string) string {
+	switch entryType {
+	case "inproceedings", "conference", "proceedings":
+		return model.VenueConference
+	case "article":
+		if !preprintPattern.MatchString(fields["journal"]) {
+			return model.VenueJournal
+		}
+	}
+	if fields["eprint"] != "" || preprintPattern.MatchString(fields["howpublished"]+" "+fields["archiveprefix"]+" "+fields["journal"]) ||
+		entryType == "unpublished" || entryType == "online" {
+		return model.VenuePreprint
+	}
+	return model.VenueJournal
+}
+
+// authorList turns names into authors, in order
+func authorList(names []string) model.Authors {
+	var authors model.Authors
+	for _, name := range names {
+		authors = append(authors, model.Author{Name: name})
+	}
+	return authors
+}
+
+// bibAuthors splits a BibTeX name list on "and" and writes each name as "Given Family"
+func bibAuthors(list string) []string {
+	var names []string
+	for _, name := range splitAnd(list) {
+		if strings.EqualFold(name, "others") {
+			continue
+		}
+		// "Family, Given" or "Family, Jr, Given"
+		parts := strings.Split(name, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		switch len(parts) {
+		case 2:
+			name = strings.TrimSpace(parts[1] + " " + parts[0])
+		case 3:
+			name = strings.TrimSpace(parts[2] + " " + parts[0] + " " + parts[1])
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// splitAnd splits on the word "and" outside braces, which protect names like {Barnes and Noble}
+func splitAnd(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(list); i++ {
+		switch list[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ' ', '\t', '\n', '\r':
+			if depth == 0 && len(list) >= i+5 && strings.EqualFold(list[i+1:i+4], "and") && unicode.IsSpace(rune(list[i+4])) {
+				parts = append(parts, list[start:i])
+				start = i + 5
+				i += 4
+			}
+		}
+	}
+	parts = append(parts, list[start:])
+
+	var names []string
+	for _, part := range parts {
+		if name := delatex(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// bibParser reads a BibTeX document entry by entry
+type bibParser struct {
+	src     string
+	pos     int
+	strings map[string]string
+}
+
+// next moves to the next "@" outside an entry, reporting whether there is one.
+// Text between entries is a comment in BibTeX.
+func (p *bibParser) next() bool {
+	i := strings.IndexByte(p.src[p.pos:], '@')
+	if i < 0 {
+		return false
+	}
+	p.pos += i + 1
+	return true
+}
+
+// entry reads the entry after "@": its type and its fields, values cleaned of LaTeX
+func (p *bibParser) entry() (string, map[string]string, error) {
+	entryType := strings.ToLower(p.ident())
+	p.space()
+	if p.pos >= len(p.src) || (p.src[p.pos] != '{' && p.src[p.pos] != '(') {
+		// An @ in the text between entries, such as an email address, starts no entry
+		return "comment", nil, nil
+	}
+	closer := byte('}')
+	if p.src[p.pos] == '(' {
+		closer = ')'
+	}
+	p.pos++
+
+	if entryType == "comment" || entryType == "preamble" {
+		_, err := p.braced(closer)
+		return entryType, nil, err
+	}
+
+	fields := map[string]string{}
+	if entryType != "string" {
+		// The citation key runs up to the first comma
+		end := strings.IndexAny(p.src[p.pos:], ",\n"+string(closer))
+		if end < 0 {
+			return "", nil, p.errorf("unterminated @%s entry", entryType)
+		}
+		p.pos += end
+		p.space()
+		if p.pos < len(p.src) && p.src[p.pos] == ',' {
+			p.pos++
+		}
+	}
+
+	for {
+		p.space()
+		if p.pos >= len(p.src) {
+			return "", nil, p.errorf("unterminated @%s entry", entryType)
+		}
+		if p.src[p.pos] == closer {
+			p.pos++
+			return entryType, fields, nil
+		}
+		name := strings.ToLower(p.ident())
+		if name == "" {
+			return "", nil, p.errorf("expected a field name in @%s entry", entryType)
+		}
+		p.space()
+		if p.pos >= len(p.src) || p.src[p.pos] != '=' {
+			return "", nil, p.errorf("expected = after %s", name)
+		}
+		p.pos++
+		value, err := p.value()
+		if err != nil {
+			return "", nil, err
+		}
+		if entryType == "string" {
+			p.strings[name] = value
+		} else if name == "author" || name == "url" || name == "doi" {
+			// Names are split before LaTeX is removed, as braces protect them;
+			// links are taken verbatim, as ~ and _ are common in them
+			fields[name] = strings.TrimSpace(value)
+		} else {
+			fields[name] = delatex(value)
+		}
+		p.space()
+		if p.pos < len(p.src) && p.src[p.pos] == ',' {
+			p.pos++
+		}
+	}
+}
+
+// value reads a field value: braced or quoted text, a number or an @string name, joined by #
+func (p *bibParser) value() (string, error) {
+	var b strings.Builder
+	for {
+		p.space()
+		if p.pos >= len(p.src) {
+			return "", p.errorf("missing field value")
+		}
+		switch c := p.src[p.pos]; {
+		case c == '{':
+			p.pos++
+			s, err := p.braced('}')
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		case c == '"':
+			p.pos++
+			s, err := p.braced('"')
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+		default:
+			word := p.ident()
+			if word == "" {
+				return "", p.errorf("unexpected %q in field value", c)
+			}
+			if abbr, ok := p.strings[strings.ToLower(word)]; ok {
+				word = abbr
+			}
+			b.WriteString(word)
+		}
+		p.space()
+		if p.pos >= len(p.src) || p.src[p.pos] != '#' {
+			return b.String(), nil
+		}
+		p.pos++
+	}
+}
+
+// braced reads up to closer at brace depth zero and skips it. Inner braces are kept.
+func (p *bibParser) braced(closer byte) (string, error) {
+	start, depth := p.pos, 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch c := p.src[p.pos]; {
+		case c == '\\':
+			p.pos++
+		case c == closer && depth == 0:
+			s := p.src[start:p.pos]
+			p.pos++
+			return s, nil
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		}
+	}
+	return "", p.errorf("unbalanced braces")
+}
+
+// ident reads a type, field name, key or number
+func (p *bibParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ',' || c == '=' || c == '#' || c == '{' || c == '}' || c == '(' || c == ')' || c == '"' || unicode.IsSpace(rune(c)) {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *bibParser) space() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// errorf reports a syntax error with the line it was found on
+func (p *bibParser) errorf(format string, args ...any) error {
+	line := strings.Count(p.src[:min(p.pos, len(p.src))], "\n") + 1
+	return fmt.Errorf("bibtex line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// latexAccents maps accent commands to combining characters, so {\"o} becomes ö
+var latexAccents = map[byte]rune{'\'': '́', '`': '̀', '^': '̂', '"': '̈', '~': '̃', '=': '̄', '.': '̇', 'c': '̧', 'v': '̌', 'u': '̆', 'H': '̋'}
+
+// accentPattern matches an accent command and the letter it applies to: \'e, \'{e}, \c{c} or \c c
+var accentPattern = regexp.MustCompile(`\\(['` + "`" + `^"~=.]|[cvuH](?:\s|\{))\s*\{?\\?([A-Za-z])\}?`)
+
+// latexSymbols replaces the escapes and ligatures left once accents are resolved
+var latexSymbols = strings.NewReplacer(
+	`\&`, `&`, `\%`, `%`, `\$`, `$`, `\#`, `#`, `\_`, `_`, `\{`, "\x00", `\}`, "\x01",
+	`\textbackslash`, `\`, `\textasciitilde`, `~`, `\textasciicircum`, `^`,
+	`\ss`, `ß`, `\o`, `ø`, `\O`, `Ø`, `\ae`, `æ`, `\AE`, `Æ`, `\aa`, `å`, `\AA`, `Å`, `\l`, `ł`, `\L`, `Ł`,
+	`---`, `—`, `--`, `–`, `~`, ` `,
+)
+
+// latexCommand matches a formatting command such as \emph or \textbf, which is dropped keeping its argument
+var latexCommand = regexp.MustCompile(`\\[A-Za-z]+\s*`)
+
+// delatex turns a BibTeX value into plain text: accents resolved, escapes
+// replaced, formatting commands and grouping braces removed, whitespace collapsed
+func delatex(s string) string {
+	s = accentPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := accentPattern.FindStringSubmatch(m)
+		return sub[2] + string(latexAccents[sub[1][0]])
+	})
+	s = latexSymbols.Replace(s)
+	s = latexCommand.ReplaceAllString(s, "")
+	s = strings.NewReplacer("{", "", "}", "", "\x00", "{", "\x01", "}").Replace(s)
+	return norm.NFC.String(strings.Join(strings.Fields(s), " "))
+}
+
+// ParseRIS reads the records of a RIS document. Lines without a tag continue
+// the value of the line before them.
+func ParseRIS(src string) ([]model.Publication, error) {
+	var pubs []model.Publication
+	var record map[string][]string
+	var last string
+
+	flush := func() error {
+		if record == nil {
+			return nil
+		}
+		if len(pubs) == MaxEntries {
+			return ErrTooManyItems
+		}
+		pubs = append(pubs, risPublication(record))
+		record = nil
+		return nil
+	}
+
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimRight(line, "\r")
+		m := risTagPattern.FindStringSubmatch(line)
+		if m == nil {
+			if record != nil && last != "" && strings.TrimSpace(line) != "" {
+				values := record[last]
+				values[len(values)-1] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		tag, value := m[1], strings.TrimSpace(m[2])
+		switch tag {
+		case "TY":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			record = map[string][]string{}
+		case "ER":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			last = ""
+			continue
+		}
+		if record == nil {
+			continue
+		}
+		record[tag] = append(record[tag], value)
+		last = tag
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(pubs) == 0 {
+		return nil, ErrNoEntries
+	}
+	return pubs, nil
+}
+
+// risVenueTypes maps the RIS reference types that are not journal articles
+var risVenueTypes = map[string]string{"CONF": model.VenueConference, "CPAPER": model.VenueConference, "UNPB": model.VenuePreprint}
+
+func risPublication(r map[string][]string) model.Publication {
+	tag := func(names ...string) string {
+		for _, name := range names {
+			if values := r[name]; len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+		return ""
+	}
+
+	var authors []string
+	for _, name := range append(r["AU"], r["A1"]...) {
+		authors = append(authors, bibAuthors(name)...)
+	}
+
+	pages := tag("SP")
+	if end := tag("EP"); pages != "" && end != "" {
+		pages += "-" + end
+	}
+
+	pub := model.Publication{
+		Title:          tag("TI", "T1", "CT"),
+		Authors:        authorList(authors),
+		Journal:        tag("T2", "JO", "JF", "JA", "BT", "PB"),
+		VenueType:      risVenueTypes[tag("TY")],
+		Volume:         tag("VL"),
+		Pages:          pages,
+		Year:           year(tag("PY", "Y1", "DA")),
+		DOI:            DOI(tag("DO")),
+		Description:    tag("AB", "N2"),
+		PublicationURL: tag("UR"),
+	}
+	return clean(pub)
+}
+
+// yearPattern finds the year in dates such as "2023", "2023/05/01/" or "May 2023"
+var yearPattern = regexp.MustCompile(`\b(1[89]|2[01])\d\d\b`)
+
+func year(s string) int {
+	y, _ := strconv.Atoi(yearPattern.FindString(s))
+	return y
+}
+
+// first returns the first non-empty value
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clean collapses the whitespace of the single-line fields, writes
+// page ranges with a hyphen and defaults the venue type to journal
+func clean(pub model.Publication) model.Publication {
+	pub.Title = oneLine(pub.Title)
+	pub.Journal = oneLine(pub.Journal)
+	pub.Volume = oneLine(pub.Volume)
+	if first, last := pageRange(pub.Pages); last != "" {
+		pub.Pages = first + "-" + last
+	} else {
+		pub.Pages = first
+	}
+	if pub.VenueType == "" {
+		pub.VenueType = model.VenueJournal
+	}
+	pub.PublicationURL = strings.TrimSpace(pub.PublicationURL)
+	pub.Description = strings.TrimSpace(pub.Description)
+	return pub
+}
@@ -0,0 +1,145 @@
+package citation
+
+import (
+	"strings"
+	"testing"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleBib = `Exported from a reference manager, contact me@example.com
+
+@string{jse = "Journal of Software " # "Engineering"}
+
+@Article{maulana2023,
+  author    = {Maulana, Alvin and John Ronald Doe and {Barnes and Noble} and others},
+  title     = {{Microservices} in {E}-Commerce: a \emph{case} study},
+  journal   = jse,
+  volume    = {12},
+  pages     = {34--56},
+  year      = 2023,
+  doi       = {10.1000/Example_1},
+  abstract  = "We measure 50\% lower latency -- with caching."
+}
+
+@comment{ignored @misc{not, title = {Not an entry}} }
+
+@inproceedings(g{\"o}del,
+  author = {G{\"o}del, Kurt and Fran{\c c}ois, Jean and de la Cruz, Jr, Juan},
+  title = {Caf\'{e} Proofs},
+  booktitle = {Proc. of Logic},
+  year = {1931},
+  url = {https://example.com/~kurt/proofs_1931.pdf},
+)
+`
+
+func TestParseBibTeX(t *testing.T) {
+	pubs, err := Parse(sampleBib)
+
+	require.NoError(t, err)
+	require.Len(t, pubs, 2)
+	assert.Equal(t, model.Publication{
+		Title:       "Microservices in E-Commerce: a case study",
+		Authors:     authors("Alvin Maulana", "John Ronald Doe", "Barnes and Noble"),
+		Journal:     "Journal of Software Engineering",
+		VenueType:   model.VenueJournal,
+		Volume:      "12",
+		Pages:       "34-56",
+		Year:        2023,
+		DOI:         "10.1000/Example_1",
+		Description: "We measure 50% lower latency – with caching.",
+	}, pubs[0])
+	assert.Equal(t, model.Publication{
+		Title:          "Café Proofs",
+		Authors:        authors("Kurt Gödel", "Jean François", "Juan de la Cruz Jr"),
+		Journal:        "Proc. of Logic",
+		VenueType:      model.VenueConference,
+		Year:           1931,
+		PublicationURL: "https://example.com/~kurt/proofs_1931.pdf",
+	}, pubs[1])
+}
+
+func TestParseBibTeX_RoundTrip(t *testing.T) {
+	out, err := Cite(BibTeX, samplePub())
+	require.NoError(t, err)
+
+	pubs, err := ParseBibTeX(out)
+
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	pub := samplePub()
+	pub.ID, pub.Slug = 0, ""
+	// Only the names survive, the rest of the author details are not part of BibTeX
+	pub.Authors = authors("Alvin Maulana", "John Ronald Doe")
+	assert.Equal(t, pub, pubs[0])
+}
+
+func TestParseBibTeX_Errors(t *testing.T) {
+	_, err := ParseBibTeX("@article{key,\n  title = {Open")
+	assert.EqualError(t, err, "bibtex line 2: unbalanced braces")
+
+	_, err = ParseBibTeX("@article{key,\n  title {x}}")
+	assert.EqualError(t, err, "bibtex line 2: expected = after title")
+
+	_, err = ParseBibTeX("no entries here")
+	assert.ErrorIs(t, err, ErrNoEntries)
+
+	_, err = ParseBibTeX(strings.Repeat("@misc{k, title={t}}\n", MaxEntries+1))
+	assert.ErrorIs(t, err, ErrTooManyItems)
+}
+
+func TestParseRIS(t *testing.T) {
+	src := strings.Join([]string{
+		"TY  - JOUR",
+		"AU  - Maulana, Alvin",
+		"A1  - Doe, John",
+		"TI  - Implementation of Microservices",
+		"  in E-Commerce",
+		"JO  - Software Journal",
+		"VL  - 4",
+		"SP  - 10",
+		"EP  - 19",
+		"PY  - 2023/05/01/",
+		"DO  - 10.1000/example1",
+		"UR  - https://example.com/paper",
+		"ER  - ",
+		"",
+		"TY  - CONF",
+		"T1  - Second",
+		"BT  - Proceedings",
+		"Y1  - 2021",
+	}, "\r\n")
+
+	pubs, err := Parse(src)
+
+	require.NoError(t, err)
+	require.Len(t, pubs, 2)
+	assert.Equal(t, model.Publication{
+		Title:          "Implementation of Microservices in E-Commerce",
+		Authors:        authors("Alvin Maulana", "John Doe"),
+		Journal:        "Software Journal",
+		VenueType:      model.VenueJournal,
+		Volume:         "4",
+		Pages:          "10-19",
+		Year:           2023,
+		DOI:            "10.1000/example1",
+		PublicationURL: "https://example.com/paper",
+	}, pubs[0])
+	assert.Equal(t, model.Publication{Title: "Second", Journal: "Proceedings", VenueType: model.VenueConference, Year: 2021}, pubs[1])
+}
+
+func TestParseBibTeX_Preprint(t *testing.T) {
+	pubs, err := ParseBibTeX(`@misc{lee2024, title = {Draft}, author = {Lee, Ann}, year = {2024}, eprint = {2401.00001}, archivePrefix = {arXiv}}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, model.VenuePreprint, pubs[0].VenueType)
+	assert.Equal(t, "arXiv", pubs[0].Journal)
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse("Doe, J. (2023). A paper. Journal.")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
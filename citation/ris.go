@@ -0,0 +1,73 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+
+	"session-19/model"
+)
+
+// risEOL ends RIS lines; the format comes from tools that expect CRLF
+const risEOL = "\r\n"
+
+func ris(pubs []model.Publication) string {
+	var b strings.Builder
+	for i, p := range pubs {
+		if i > 0 {
+			b.WriteString(risEOL)
+		}
+		writeRISRecord(&b, p)
+	}
+	return b.String()
+}
+
+func writeRISRecord(b *strings.Builder, p model.Publication) {
+	tag := func(name, value string) {
+		b.WriteString(name + "  - " + value + risEOL)
+	}
+	tag("TY", risType(p))
+	for _, n := range AuthorNames(p.Authors) {
+		tag("AU", bibName(n))
+	}
+	tag("TI", oneLine(p.Title))
+	if p.Journal != "" {
+		tag("T2", oneLine(p.Journal))
+	}
+	if p.Volume != "" {
+		tag("VL", oneLine(p.Volume))
+	}
+	if first, last := pageRange(p.Pages); first != "" {
+		tag("SP", first)
+		if last != "" {
+			tag("EP", last)
+		}
+	}
+	if p.Year > 0 {
+		tag("PY", strconv.Itoa(p.Year))
+	}
+	if p.DOI != "" {
+		tag("DO", p.DOI)
+	}
+	if p.PublicationURL != "" {
+		tag("UR", p.PublicationURL)
+	}
+	tag("ER", "")
+}
+
+// risType returns the RIS reference type of a publication
+func risType(p model.Publication) string {
+	switch {
+	case p.Journal == "":
+		return "GEN"
+	case p.VenueType == model.VenueConference:
+		return "CPAPER"
+	case p.VenueType == model.VenuePreprint:
+		return "UNPB"
+	}
+	return "JOUR"
+}
+
+// oneLine joins the lines of s, as a RIS value cannot span lines
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,179 @@
+package citation
+
+import (
+	"strconv"
+	"strings"
+
+	"session-19/model"
+)
+
+// apa formats a reference in APA 7th edition style:
+// Family, G., & Family, G. (Year). Title. Journal, Volume, Pages. https://doi.org/DOI
+func apa(p model.Publication) string {
+	names := AuthorNames(p.Authors)
+	var authors []string
+	for _, n := range names {
+		authors = append(authors, strings.TrimSpace(n.Family+", "+n.Initials()))
+	}
+	// Up to 20 authors are listed, more are cut to the first 19, an ellipsis and the last
+	if len(authors) > 20 {
+		authors = append(append(authors[:19:19], "…"), authors[len(authors)-1])
+	}
+
+	year := "(n.d.)."
+	if p.Year > 0 {
+		year = "(" + strconv.Itoa(p.Year) + ")."
+	}
+
+	var parts []string
+	switch len(authors) {
+	case 0:
+		// Without authors the title takes their place
+		parts = append(parts, period(p.Title), year)
+	default:
+		parts = append(parts, period(apaAuthors(authors)), year, period(apaTitle(p)))
+	}
+	if venue := apaVenue(p); venue != "" {
+		parts = append(parts, period(venue))
+	}
+	// APA prefers the DOI link over any other URL
+	if link := first(p.DOIURL(), p.PublicationURL); link != "" {
+		parts = append(parts, link)
+	}
+	return oneLine(strings.Join(parts, " "))
+}
+
+// apaTitle marks preprints, which APA describes in brackets after the title
+func apaTitle(p model.Publication) string {
+	if p.VenueType == model.VenuePreprint && p.Journal != "" {
+		return strings.TrimRight(p.Title, ".") + " [Preprint]"
+	}
+	return p.Title
+}
+
+// apaVenue returns "Journal, 12, 34–56" or, for a conference paper, "In Proceedings (pp. 34–56)"
+func apaVenue(p model.Publication) string {
+	if p.Journal == "" {
+		return ""
+	}
+	pages := pagesWith(p.Pages, "–")
+	if p.VenueType == model.VenueConference {
+		venue := "In " + p.Journal
+		if pages != "" {
+			venue += " (" + pagePrefix(p.Pages) + " " + pages + ")"
+		}
+		return venue
+	}
+	parts := []string{p.Journal}
+	if p.VenueType != model.VenuePreprint {
+		for _, part := range []string{p.Volume, pages} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func apaAuthors(authors []string) string {
+	switch {
+	case len(authors) == 1:
+		return authors[0]
+	case authors[len(authors)-2] == "…":
+		return strings.Join(authors[:len(authors)-1], ", ") + " " + authors[len(authors)-1]
+	default:
+		return strings.Join(authors[:len(authors)-1], ", ") + ", & " + authors[len(authors)-1]
+	}
+}
+
+// ieee formats a reference in IEEE style:
+// G. Family and G. Family, "Title," Journal, vol. 12, pp. 34–56, Year, doi: DOI.
+// A publication without a DOI ends with "[Online]. Available: URL" instead.
+func ieee(p model.Publication) string {
+	names := AuthorNames(p.Authors)
+	var authors []string
+	for _, n := range names {
+		authors = append(authors, strings.TrimSpace(n.Initials()+" "+n.Family))
+	}
+
+	var b strings.Builder
+	if len(authors) > 0 {
+		b.WriteString(ieeeAuthors(authors) + ", ")
+	}
+	rest := ieeeVenue(p)
+	if p.DOI != "" {
+		rest = append(rest, "doi: "+p.DOI)
+	}
+	if len(rest) == 0 {
+		b.WriteString(`"` + strings.TrimRight(p.Title, ".") + `."`)
+	} else {
+		b.WriteString(`"` + strings.TrimRight(p.Title, ".") + `," ` + strings.Join(rest, ", ") + ".")
+	}
+	if p.DOI == "" && p.PublicationURL != "" {
+		b.WriteString(" [Online]. Available: " + p.PublicationURL)
+	}
+	return oneLine(b.String())
+}
+
+// ieeeVenue lists the venue parts in IEEE order: a journal's volume and pages
+// come before the year, a conference paper's pages after it
+func ieeeVenue(p model.Publication) []string {
+	var rest []string
+	year := ""
+	if p.Year > 0 {
+		year = strconv.Itoa(p.Year)
+	}
+	pages := ""
+	if pagesWith(p.Pages, "–") != "" {
+		pages = pagePrefix(p.Pages) + " " + pagesWith(p.Pages, "–")
+	}
+	switch {
+	case p.Journal == "" || p.VenueType == model.VenuePreprint:
+		rest = append(rest, p.Journal, year)
+	case p.VenueType == model.VenueConference:
+		rest = append(rest, "in "+p.Journal, year, pages)
+	default:
+		volume := ""
+		if p.Volume != "" {
+			volume = "vol. " + p.Volume
+		}
+		rest = append(rest, p.Journal, volume, pages, year)
+	}
+	var parts []string
+	for _, part := range rest {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// pagePrefix returns "pp." for a page range and "p." for a single page
+func pagePrefix(pages string) string {
+	if _, last := pageRange(pages); last != "" {
+		return "pp."
+	}
+	return "p."
+}
+
+func ieeeAuthors(authors []string) string {
+	switch {
+	case len(authors) > 6:
+		return authors[0] + " et al."
+	case len(authors) == 1:
+		return authors[0]
+	case len(authors) == 2:
+		return authors[0] + " and " + authors[1]
+	default:
+		return strings.Join(authors[:len(authors)-1], ", ") + ", and " + authors[len(authors)-1]
+	}
+}
+
+// period ends s with a full stop unless it already ends a sentence
+func period(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") {
+		return s
+	}
+	return s + "."
+}
@@ -0,0 +1,177 @@
+// Command export-static renders the public portfolio into a static site.
+//
+// Pages are rendered in-process through the same routes and templates the server
+// uses, public/ is copied with fingerprinted filenames, and links are rewritten
+// to relative paths, so the output works on any static host or straight from disk.
+//
+//	go run ./cmd/export-static                     # write ./dist
+//	go run ./cmd/export-static -o site -api https://portfolio.example.com
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"session-19/citation"
+	"session-19/database"
+	"session-19/feed"
+	"session-19/handler"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/resume"
+	"session-19/router"
+	"session-19/seo"
+	"session-19/service"
+	"session-19/staticsite"
+	"session-19/storage"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+func main() {
+	out := flag.String("o", "dist", "output directory; cleared first when a previous export wrote it")
+	api := flag.String("api", "", "base URL of a running server for the contact form, e.g. https://portfolio.example.com")
+	flag.Parse()
+
+	db, err := database.InitDB()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close(nil)
+
+	store, err := storage.New(storage.GetDefaultConfig())
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
+	logger := zap.NewNop()
+	repo := repository.NewRepository(db, logger)
+	svc := service.NewService(repo, store)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	// A static copy outlives the failure, so never publish one with sections missing
+	data, err := svc.PortfolioService.GetPortfolioData(ctx)
+	if err != nil {
+		log.Fatal("Failed to load portfolio:", err)
+	}
+	if data.Partial() {
+		log.Fatalf("Portfolio sections failed to load: %v", data.Failures)
+	}
+
+	files, err := cvFiles(ctx, svc.CVService)
+	if err != nil {
+		log.Fatal("Failed to read the active CV:", err)
+	}
+
+	h := handler.Handler{
+		PortfolioHandler: handler.NewPortfolioHandler(svc.PortfolioService, logger),
+		CVHandler:        handler.NewCVHandler(svc.CVService, logger),
+	}
+	r := chi.NewRouter()
+	router.PublicRoutes(r, h)
+
+	result, err := staticsite.Export(ctx, staticsite.Options{
+		Handler:   r,
+		Pages:     pages(data),
+		Files:     files,
+		PublicDir: "public",
+		Exclude:   []string{router.LegacyCVDir},
+		OutDir:    *out,
+		APIBase:   *api,
+	})
+	if err != nil {
+		log.Fatal("Export failed: ", err)
+	}
+
+	fmt.Println("=== Static Export ===")
+	fmt.Printf("Output: %s\n", *out)
+	fmt.Printf("Assets: %d\n", result.Assets)
+	fmt.Printf("Pages (%d):\n", len(result.Pages))
+	for _, page := range result.Pages {
+		fmt.Printf("  %s\n", page)
+	}
+	for _, url := range result.Unresolved {
+		fmt.Println("WARNING: link needs the Go server:", url)
+	}
+	if *api == "" {
+		fmt.Println("NOTE: the contact form posts to /api/v1; pass -api to point it at a running server")
+	}
+	if data.SEO.SiteURL == "" {
+		fmt.Println("NOTE: no Site URL is set in /admin/seo, so canonical, sharing and sitemap links point at a placeholder host")
+	}
+}
+
+// pages lists the public routes to render, including a detail page per project and publication
+func pages(data *model.PortfolioData) []staticsite.Page {
+	pages := []staticsite.Page{
+		{URL: "/", File: "index.html"},
+		{URL: seo.SitemapPath, File: strings.TrimPrefix(seo.SitemapPath, "/")},
+		{URL: seo.RobotsPath, File: strings.TrimPrefix(seo.RobotsPath, "/")},
+		{URL: citation.BibPath, File: strings.TrimPrefix(citation.BibPath, "/")},
+	}
+	for _, kind := range feed.Kinds {
+		pages = append(pages,
+			staticsite.Page{URL: kind.AtomPath(), File: strings.TrimPrefix(kind.AtomPath(), "/")},
+			staticsite.Page{URL: kind.RSSPath(), File: strings.TrimPrefix(kind.RSSPath(), "/")})
+	}
+	for _, layout := range resume.Layouts {
+		file := "resume-" + layout + ".pdf"
+		if !strings.Contains(resume.URL(layout), "?") {
+			file = strings.TrimPrefix(resume.Path, "/")
+		}
+		pages = append(pages, staticsite.Page{URL: resume.URL(layout), File: file})
+	}
+	for _, p := range data.Projects {
+		pages = appendDetailPage(pages, p.Path())
+	}
+	for _, p := range data.Publications {
+		pages = appendDetailPage(pages, p.Path())
+	}
+	return pages
+}
+
+// appendDetailPage adds the page at path as path/index.html so its URL works on static hosts;
+// records without a slug have no page
+func appendDetailPage(pages []staticsite.Page, path string) []staticsite.Page {
+	if path == "" {
+		return pages
+	}
+	return append(pages, staticsite.Page{URL: path, File: strings.TrimPrefix(path, "/") + "/index.html"})
+}
+
+// cvFiles returns the active CV, which the site links as /cv. It is read directly,
+// as requesting /cv would count a download.
+func cvFiles(ctx context.Context, cvService service.CVServiceInterface) ([]staticsite.File, error) {
+	versions, err := cvService.GetAllCVVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if !v.Active {
+			continue
+		}
+		_, rc, err := cvService.OpenCV(ctx, v.ID)
+		if err != nil {
+			var notFound *service.NotFoundError
+			if errors.As(err, &notFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		return []staticsite.File{{URL: service.CVPath, File: "cv.pdf", Data: data}}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,96 @@
+// Command media-gc reconciles uploaded media with the database.
+//
+// It lists every upload in storage, compares it with the image, photo and CV URLs
+// recorded in the database, and reports orphaned files and broken references.
+// With -delete, orphans older than the grace period are removed.
+//
+//	go run ./cmd/media-gc                 # report only
+//	go run ./cmd/media-gc -delete -grace 72h
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"session-19/database"
+	"session-19/repository"
+	"session-19/service"
+	"session-19/storage"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	grace := flag.Duration("grace", service.DefaultOrphanGracePeriod, "minimum age of an orphan before it is deleted")
+	del := flag.Bool("delete", false, "delete orphans older than the grace period (default: report only)")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	db, err := database.InitDB()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close(nil)
+
+	store, err := storage.New(storage.GetDefaultConfig())
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
+	repo := repository.NewRepository(db, zap.NewNop())
+	media := service.NewMediaService(repo.PortfolioRepo, repo.MediaRepo, repo.CVRepo, store)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	report, err := media.Reconcile(ctx, service.ReconcileOptions{GracePeriod: *grace, Delete: *del})
+	if err != nil {
+		log.Fatal("Media reconciliation failed:", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		printReport(report, *grace, *del)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport writes a human-readable summary of the run
+func printReport(report *service.MediaReport, grace time.Duration, deleted bool) {
+	fmt.Println("=== Media Reconciliation ===")
+	fmt.Printf("Uploads scanned:  %d\n", report.Scanned)
+	fmt.Printf("Files referenced: %d\n\n", report.Referenced)
+
+	fmt.Printf("Orphans (%d):\n", len(report.Orphans))
+	for _, obj := range report.Orphans {
+		fmt.Printf("  %s  %d bytes  modified %s\n", obj.Key, obj.Size, obj.ModTime.Format(time.RFC3339))
+	}
+
+	fmt.Printf("\nBroken references (%d):\n", len(report.Broken))
+	for _, ref := range report.Broken {
+		fmt.Printf("  %s #%d %s -> %s\n", ref.Resource, ref.ID, ref.Field, ref.URL)
+	}
+
+	if deleted {
+		fmt.Printf("\nDeleted orphans older than %s (%d):\n", grace, len(report.Deleted))
+		for _, key := range report.Deleted {
+			fmt.Printf("  %s\n", key)
+		}
+	} else {
+		fmt.Println("\nDry run: rerun with -delete to remove orphans older than", grace)
+	}
+
+	for _, msg := range report.Errors {
+		fmt.Println("ERROR:", msg)
+	}
+}
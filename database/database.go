@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PgxIface defines the interface for database operations
@@ -14,9 +15,39 @@ type PgxIface interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, query string, args ...any) pgx.Row
 	Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 	Close(ctx context.Context) error
 }
 
+// Tx adapts a pgx.Tx to PgxIface so repositories can run inside a transaction.
+// Close is a no-op; the transaction is finished by WithTx.
+type Tx struct {
+	pgx.Tx
+}
+
+// Close implements PgxIface
+func (t Tx) Close(ctx context.Context) error {
+	return nil
+}
+
+// WithTx runs fn in a transaction on db, committing when fn succeeds and rolling back otherwise
+func WithTx(ctx context.Context, db PgxIface, fn func(tx PgxIface) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback after a successful commit is a no-op
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	if err := fn(Tx{Tx: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // DBConfig holds database configuration
 type DBConfig struct {
 	Host     string
@@ -25,6 +56,7 @@ type DBConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	MaxConns string
 }
 
 // GetDefaultConfig returns default database configuration
@@ -36,6 +68,7 @@ func GetDefaultConfig() DBConfig {
 		Password: getEnv("DB_PASSWORD", "root"),
 		DBName:   getEnv("DB_NAME", "portfolio_db"),
 		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+		MaxConns: getEnv("DB_MAX_CONNS", "10"),
 	}
 }
 
@@ -47,39 +80,53 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-// InitDB initializes and returns a database connection
-func InitDB() (*pgx.Conn, error) {
-	config := GetDefaultConfig()
+// Pool wraps pgxpool.Pool so it satisfies PgxIface.
+// Unlike a single pgx.Conn, a pool is safe for concurrent use.
+type Pool struct {
+	*pgxpool.Pool
+}
+
+// Close closes all connections in the pool
+func (p *Pool) Close(ctx context.Context) error {
+	p.Pool.Close()
+	return nil
+}
+
+// connString builds the connection string for a configuration
+func connString(config DBConfig) string {
 	connStr := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode,
 	)
+	if config.MaxConns != "" {
+		connStr += " pool_max_conns=" + config.MaxConns
+	}
+	return connStr
+}
 
-	conn, err := pgx.Connect(context.Background(), connStr)
+// InitDB initializes and returns a database connection pool
+func InitDB() (*Pool, error) {
+	pool, err := InitDBWithConfig(GetDefaultConfig())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, err
 	}
 
 	// Test connection
-	if err := conn.Ping(context.Background()); err != nil {
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Pool.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	fmt.Println("✓ Database connection established")
-	return conn, nil
+	return pool, nil
 }
 
-// InitDBWithConfig initializes database with custom configuration
-func InitDBWithConfig(config DBConfig) (*pgx.Conn, error) {
-	connStr := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode,
-	)
-
-	conn, err := pgx.Connect(context.Background(), connStr)
+// InitDBWithConfig initializes a database connection pool with custom configuration
+func InitDBWithConfig(config DBConfig) (*Pool, error) {
+	pool, err := pgxpool.New(context.Background(), connString(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return conn, nil
+	return &Pool{Pool: pool}, nil
 }
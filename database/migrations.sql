@@ -54,28 +54,152 @@ CREATE TABLE IF NOT EXISTS projects (
     title VARCHAR(200) NOT NULL,
     description TEXT,
     image_url VARCHAR(500),
+    image_variants JSONB NOT NULL DEFAULT '[]',
     project_url VARCHAR(500),
     github_url VARCHAR(500),
     tech_stack VARCHAR(500),
     color VARCHAR(50) DEFAULT 'cyan',
     profile_id INTEGER REFERENCES profiles(id),
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    slug VARCHAR(100) CONSTRAINT projects_slug_key UNIQUE,
+    content TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Create publications table
 CREATE TABLE IF NOT EXISTS publications (
     id SERIAL PRIMARY KEY,
     title VARCHAR(200) NOT NULL,
-    authors VARCHAR(500),
+    -- Ordered list of {"name", "affiliation", "orcid", "url", "is_me"}, see model.Author
+    authors JSONB NOT NULL DEFAULT '[]',
     journal VARCHAR(200),
+    venue_type VARCHAR(20) NOT NULL DEFAULT 'journal' CONSTRAINT publications_venue_type_check CHECK (venue_type IN ('journal', 'conference', 'preprint')),
+    volume VARCHAR(50),
+    pages VARCHAR(50),
     year INTEGER CHECK (year >= 1900 AND year <= 2100),
+    doi VARCHAR(255),
     description TEXT,
     image_url VARCHAR(500),
+    image_variants JSONB NOT NULL DEFAULT '[]',
     publication_url VARCHAR(500),
     color VARCHAR(50) DEFAULT 'red',
+    slug VARCHAR(100) CONSTRAINT publications_slug_key UNIQUE,
+    content TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create education table; dates are stored as days, an empty end date means still studying
+CREATE TABLE IF NOT EXISTS education (
+    id SERIAL PRIMARY KEY,
+    institution VARCHAR(200) NOT NULL,
+    degree VARCHAR(200),
+    field VARCHAR(200),
+    start_date DATE,
+    end_date DATE CONSTRAINT education_end_date_check CHECK (end_date >= start_date),
+    -- Kept as written with its scale, e.g. "3.85/4.00"
+    gpa VARCHAR(20),
+    honors VARCHAR(200),
+    description TEXT,
+    color VARCHAR(50) DEFAULT 'lime',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create certifications table
+CREATE TABLE IF NOT EXISTS certifications (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    issuer VARCHAR(200) NOT NULL,
+    credential_id VARCHAR(100),
+    issue_date DATE,
+    expiry_date DATE CONSTRAINT certifications_expiry_date_check CHECK (expiry_date >= issue_date),
+    verification_url VARCHAR(500),
+    color VARCHAR(50) DEFAULT 'orange',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create testimonial invites table; the token is the secret part of the link the
+-- owner sends, and a link can be used for one submission until it expires
+CREATE TABLE IF NOT EXISTS testimonial_invites (
+    id SERIAL PRIMARY KEY,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    -- Who the link was sent to, for the owner's reference
+    note VARCHAR(200),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create testimonials table; submissions through an invite wait in the
+-- moderation queue as pending, and only approved ones are shown
+CREATE TABLE IF NOT EXISTS testimonials (
+    id SERIAL PRIMARY KEY,
+    author VARCHAR(100) NOT NULL,
+    role VARCHAR(100),
+    company VARCHAR(100),
+    quote TEXT NOT NULL,
+    avatar_url VARCHAR(500),
+    relationship VARCHAR(200),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    invite_id INTEGER REFERENCES testimonial_invites(id) ON DELETE SET NULL,
+    color VARCHAR(50) DEFAULT 'yellow',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create slug redirect tables; a slug a record used before keeps pointing at it
+-- for as long as the record exists. Browsers cache the 301, so the slug stays
+-- reserved and no other record can take it.
+CREATE TABLE IF NOT EXISTS project_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY,
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS publication_slug_redirects (
+    slug VARCHAR(100) PRIMARY KEY,
+    publication_id INTEGER NOT NULL REFERENCES publications(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create media library table; hash is the SHA-256 of the uploaded bytes and deduplicates uploads
+CREATE TABLE IF NOT EXISTS media (
+    id SERIAL PRIMARY KEY,
+    filename VARCHAR(255) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    hash CHAR(64) NOT NULL CONSTRAINT media_hash_key UNIQUE,
+    alt_text VARCHAR(300),
+    variants JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create CV versions table; at most one version is active and linked from the profile
+CREATE TABLE IF NOT EXISTS cv_versions (
+    id SERIAL PRIMARY KEY,
+    filename VARCHAR(255) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    hash CHAR(64) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    downloads BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create SEO settings table; a single row of site-wide overrides
+CREATE TABLE IF NOT EXISTS seo_settings (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    site_url VARCHAR(255),
+    title VARCHAR(255),
+    description TEXT,
+    image_url VARCHAR(500),
+    twitter_handle VARCHAR(50),
+    robots TEXT,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Insert sample data
 
 -- Sample profile
@@ -124,8 +248,20 @@ INSERT INTO projects (title, description, image_url, project_url, github_url, te
 
 -- Sample publications
 INSERT INTO publications (title, authors, journal, year, description, image_url, publication_url, color) VALUES
-('Implementation of Microservices Architecture in E-Commerce Systems', 'Alvin Maulana, Dr. John Doe', 'International Journal of Software Engineering', 2023, 'This paper discusses the implementation and benefits of microservices architecture in large-scale e-commerce systems.', '/public/assets/pub1.jpg', 'https://doi.org/example1', 'red'),
-('Performance Analysis of Go vs Node.js for Backend Development', 'Alvin Maulana', 'Tech Conference Proceedings', 2022, 'A comparative study analyzing the performance characteristics of Go and Node.js in various backend scenarios.', '/public/assets/pub2.jpg', 'https://doi.org/example2', 'orange');
+('Implementation of Microservices Architecture in E-Commerce Systems', '[{"name": "Alvin Maulana", "is_me": true}, {"name": "Dr. John Doe"}]', 'International Journal of Software Engineering', 2023, 'This paper discusses the implementation and benefits of microservices architecture in large-scale e-commerce systems.', '/public/assets/pub1.jpg', 'https://doi.org/example1', 'red'),
+('Performance Analysis of Go vs Node.js for Backend Development', '[{"name": "Alvin Maulana", "is_me": true}]', 'Tech Conference Proceedings', 2022, 'A comparative study analyzing the performance characteristics of Go and Node.js in various backend scenarios.', '/public/assets/pub2.jpg', 'https://doi.org/example2', 'orange');
+
+-- Sample education
+INSERT INTO education (institution, degree, field, start_date, end_date, gpa, honors, description, color) VALUES
+('University of Technology', 'Bachelor of Computer Science', 'Informatics', '2018-09-01', '2022-07-31', '3.78/4.00', 'Cum Laude', 'Thesis on event-driven architectures for e-commerce systems.', 'lime');
+
+-- Sample certifications
+INSERT INTO certifications (name, issuer, credential_id, issue_date, expiry_date, verification_url, color) VALUES
+('Certified Kubernetes Application Developer', 'The Linux Foundation', 'LF-EXAMPLE-1234', '2023-04-15', '2026-04-15', 'https://training.linuxfoundation.org/certification/verify', 'orange');
+
+-- Sample testimonials
+INSERT INTO testimonials (author, role, company, quote, relationship, status, color) VALUES
+('Jane Smith', 'Engineering Manager', 'Tech Company Inc.', 'Alvin consistently delivered reliable backend services and was always the first to help teammates debug tricky issues.', 'Managed Alvin directly', 'approved', 'yellow');
 
 -- Sample admin user (password: admin123 - hashed with bcrypt)
 INSERT INTO users (email, password, name, role) VALUES
@@ -136,3 +272,76 @@ CREATE INDEX IF NOT EXISTS idx_experiences_type ON experiences(type);
 CREATE INDEX IF NOT EXISTS idx_skills_category ON skills(category);
 CREATE INDEX IF NOT EXISTS idx_projects_profile_id ON projects(profile_id);
 CREATE INDEX IF NOT EXISTS idx_publications_year ON publications(year);
+CREATE INDEX IF NOT EXISTS idx_education_start_date ON education(start_date);
+CREATE INDEX IF NOT EXISTS idx_certifications_issue_date ON certifications(issue_date);
+
+-- Upgrades for databases created before the columns above existed
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS image_variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS image_variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS slug VARCHAR(100) CONSTRAINT projects_slug_key UNIQUE;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS content TEXT;
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS slug VARCHAR(100) CONSTRAINT publications_slug_key UNIQUE;
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS content TEXT;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE seo_settings ADD COLUMN IF NOT EXISTS robots TEXT;
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS venue_type VARCHAR(20) NOT NULL DEFAULT 'journal' CONSTRAINT publications_venue_type_check CHECK (venue_type IN ('journal', 'conference', 'preprint'));
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS volume VARCHAR(50);
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS pages VARCHAR(50);
+ALTER TABLE publications ADD COLUMN IF NOT EXISTS doi VARCHAR(255);
+
+-- Turn the comma separated authors string into the JSON author list, marking the
+-- name that matches the profile as the owner. ALTER ... USING cannot hold the
+-- subqueries this needs, so the list is built in a new column that replaces the old one.
+-- The application reads the profile from `profile`, which is what a database restored
+-- from backup_db_portofolio.sql has; this script's `profiles` is only filled on a fresh
+-- install. The owner names come from both, `profile` through EXECUTE as it may not exist.
+DO $$
+DECLARE
+    owners TEXT[] := ARRAY(SELECT lower(trim(name)) FROM profiles);
+    app_owners TEXT[];
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'publications' AND column_name = 'authors') <> 'jsonb' THEN
+        IF to_regclass('profile') IS NOT NULL THEN
+            EXECUTE 'SELECT ARRAY(SELECT lower(trim(name)) FROM profile)' INTO app_owners;
+            owners := owners || app_owners;
+        END IF;
+        ALTER TABLE publications ADD COLUMN author_list JSONB NOT NULL DEFAULT '[]';
+        UPDATE publications p SET author_list = CASE
+            WHEN trim(p.authors) LIKE '[%' THEN p.authors::jsonb
+            ELSE COALESCE((
+                SELECT jsonb_agg(jsonb_build_object('name', trim(a.name), 'is_me',
+                    lower(trim(a.name)) = ANY(owners)) ORDER BY a.n)
+                FROM unnest(string_to_array(p.authors, ',')) WITH ORDINALITY AS a(name, n)
+                WHERE trim(a.name) <> ''
+            ), '[]')
+        END;
+        ALTER TABLE publications DROP COLUMN authors;
+        ALTER TABLE publications RENAME COLUMN author_list TO authors;
+    END IF;
+END $$;
+
+-- Keep the DOI of publications linked through doi.org
+UPDATE publications SET doi = rtrim(substring(publication_url FROM '10\.\d{4,9}/\S+'), '.,;')
+WHERE doi IS NULL AND publication_url ~ '10\.\d{4,9}/\S+';
+
+-- Give rows without a slug one derived from the title, numbering duplicates
+-- (the application does the same for new rows, see utils.Slugify)
+UPDATE projects p SET slug = s.slug FROM (
+    SELECT id, base || CASE WHEN n > 1 THEN '-' || n ELSE '' END AS slug FROM (
+        SELECT id, base, row_number() OVER (PARTITION BY base ORDER BY id) AS n FROM (
+            SELECT id, COALESCE(NULLIF(trim(both '-' FROM left(lower(regexp_replace(title, '[^a-zA-Z0-9]+', '-', 'g')), 80)), ''), 'project-' || id) AS base
+            FROM projects WHERE slug IS NULL
+        ) b
+    ) numbered
+) s WHERE p.id = s.id;
+
+UPDATE publications p SET slug = s.slug FROM (
+    SELECT id, base || CASE WHEN n > 1 THEN '-' || n ELSE '' END AS slug FROM (
+        SELECT id, base, row_number() OVER (PARTITION BY base ORDER BY id) AS n FROM (
+            SELECT id, COALESCE(NULLIF(trim(both '-' FROM left(lower(regexp_replace(title, '[^a-zA-Z0-9]+', '-', 'g')), 80)), ''), 'publication-' || id) AS base
+            FROM publications WHERE slug IS NULL
+        ) b
+    ) numbered
+) s WHERE p.id = s.id;
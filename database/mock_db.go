@@ -34,12 +34,66 @@ func (m *MockDB) Exec(ctx context.Context, query string, args ...any) (pgconn.Co
 	return callArgs.Get(0).(pgconn.CommandTag), callArgs.Error(1)
 }
 
+// Begin mocks the Begin method
+func (m *MockDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	callArgs := m.Called(ctx)
+	if callArgs.Get(0) == nil {
+		return nil, callArgs.Error(1)
+	}
+	return callArgs.Get(0).(pgx.Tx), callArgs.Error(1)
+}
+
 // Close mocks the Close method
 func (m *MockDB) Close(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
 
+// MockTx is a mock implementation of pgx.Tx. Statements run on the embedded MockDB,
+// so expectations are set the same way as for a plain connection.
+// Methods not overridden here (CopyFrom, SendBatch, ...) panic when called.
+type MockTx struct {
+	pgx.Tx
+	*MockDB
+}
+
+// NewMockTx creates a MockTx whose statements are expected on db
+func NewMockTx(db *MockDB) *MockTx {
+	return &MockTx{MockDB: db}
+}
+
+// Query runs on the embedded MockDB
+func (m *MockTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return m.MockDB.Query(ctx, sql, args...)
+}
+
+// QueryRow runs on the embedded MockDB
+func (m *MockTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return m.MockDB.QueryRow(ctx, sql, args...)
+}
+
+// Exec runs on the embedded MockDB
+func (m *MockTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return m.MockDB.Exec(ctx, sql, args...)
+}
+
+// Begin mocks starting a nested transaction (a savepoint)
+func (m *MockTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return m.MockDB.Begin(ctx)
+}
+
+// Commit mocks the Commit method
+func (m *MockTx) Commit(ctx context.Context) error {
+	args := m.MockDB.Called(ctx)
+	return args.Error(0)
+}
+
+// Rollback mocks the Rollback method
+func (m *MockTx) Rollback(ctx context.Context) error {
+	args := m.MockDB.Called(ctx)
+	return args.Error(0)
+}
+
 // MockRow is a mock implementation of pgx.Row
 type MockRow struct {
 	mock.Mock
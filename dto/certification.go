@@ -0,0 +1,13 @@
+package dto
+
+// CertificationRequest represents the request body for creating/updating certification
+type CertificationRequest struct {
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer"`
+	CredentialID string `json:"credential_id"`
+	// IssueDate and ExpiryDate are "YYYY-MM-DD"; leave ExpiryDate empty when it does not expire
+	IssueDate       string `json:"issue_date"`
+	ExpiryDate      string `json:"expiry_date"`
+	VerificationURL string `json:"verification_url"`
+	Color           string `json:"color"`
+}
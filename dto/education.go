@@ -0,0 +1,15 @@
+package dto
+
+// EducationRequest represents the request body for creating/updating education
+type EducationRequest struct {
+	Institution string `json:"institution"`
+	Degree      string `json:"degree"`
+	Field       string `json:"field"`
+	// StartDate and EndDate are "YYYY-MM-DD"; leave EndDate empty while still studying
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	GPA         string `json:"gpa"`
+	Honors      string `json:"honors"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
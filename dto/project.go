@@ -1,13 +1,19 @@
 package dto
 
+import "session-19/model"
+
 // ProjectRequest represents the request body for creating/updating project
 type ProjectRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
-	ProjectURL  string `json:"project_url"`
-	GithubURL   string `json:"github_url"`
-	TechStack   string `json:"tech_stack"`
-	Color       string `json:"color"`
-	ProfileID   int64  `json:"profile_id"`
+	Title         string              `json:"title"`
+	Description   string              `json:"description"`
+	ImageURL      string              `json:"image_url"`
+	ImageVariants model.ImageVariants `json:"image_variants"`
+	ProjectURL    string              `json:"project_url"`
+	GithubURL     string              `json:"github_url"`
+	TechStack     string              `json:"tech_stack"`
+	Color         string              `json:"color"`
+	ProfileID     int64               `json:"profile_id"`
+	// Slug is generated from the title when empty; on update an empty slug keeps the current one
+	Slug    string `json:"slug"`
+	Content string `json:"content"`
 }
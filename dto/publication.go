@@ -1,13 +1,25 @@
 package dto
 
+import "session-19/model"
+
 // PublicationRequest represents the request body for creating/updating publication
 type PublicationRequest struct {
-	Title          string `json:"title"`
-	Authors        string `json:"authors"`
-	Journal        string `json:"journal"`
-	Year           int    `json:"year"`
-	Description    string `json:"description"`
-	ImageURL       string `json:"image_url"`
-	PublicationURL string `json:"publication_url"`
-	Color          string `json:"color"`
+	Title string `json:"title"`
+	// Authors is the ordered author list; a comma separated string of names is accepted as well
+	Authors   model.Authors `json:"authors"`
+	Journal   string        `json:"journal"`
+	VenueType string        `json:"venue_type"`
+	Volume    string        `json:"volume"`
+	Pages     string        `json:"pages"`
+	Year      int           `json:"year"`
+	// DOI may be bare or a doi.org link; it is stored bare
+	DOI            string              `json:"doi"`
+	Description    string              `json:"description"`
+	ImageURL       string              `json:"image_url"`
+	ImageVariants  model.ImageVariants `json:"image_variants"`
+	PublicationURL string              `json:"publication_url"`
+	Color          string              `json:"color"`
+	// Slug is generated from the title when empty; on update an empty slug keeps the current one
+	Slug    string `json:"slug"`
+	Content string `json:"content"`
 }
@@ -0,0 +1,11 @@
+package dto
+
+// SEORequest represents the request body for updating the SEO settings
+type SEORequest struct {
+	SiteURL       string `json:"site_url"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	ImageURL      string `json:"image_url"`
+	TwitterHandle string `json:"twitter_handle"`
+	Robots        string `json:"robots"`
+}
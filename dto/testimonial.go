@@ -0,0 +1,19 @@
+package dto
+
+// TestimonialRequest represents the request body for creating/updating testimonial
+type TestimonialRequest struct {
+	Author       string `json:"author"`
+	Role         string `json:"role"`
+	Company      string `json:"company"`
+	Quote        string `json:"quote"`
+	AvatarURL    string `json:"avatar_url"`
+	Relationship string `json:"relationship"`
+	// Status defaults to approved for testimonials the owner adds; submissions are always pending
+	Status string `json:"status"`
+	Color  string `json:"color"`
+}
+
+// TestimonialInviteRequest represents the request for creating a testimonial invite link
+type TestimonialInviteRequest struct {
+	Note string `json:"note"`
+}
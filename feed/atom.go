@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+
+	"session-19/model"
+)
+
+// atomFeed is an Atom feed as defined by RFC 4287
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Link      atomLink     `xml:"link"`
+	Published string       `xml:"published"`
+	Updated   string       `xml:"updated"`
+	Category  atomCategory `xml:"category"`
+	Summary   string       `xml:"summary,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Atom renders the feed in the Atom format
+func Atom(data *model.PortfolioData, kind Kind, base string) ([]byte, error) {
+	items := entries(data, kind, base)
+	feed := atomFeed{
+		// The feed URL is its permanent ID, as RFC 4287 suggests
+		ID:       base + kind.AtomPath(),
+		Title:    title(data, kind),
+		Subtitle: data.Profile.Title,
+		Updated:  updated(data, items).Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: AtomType, Href: base + kind.AtomPath()},
+			{Rel: "alternate", Type: "text/html", Href: base + "/"},
+		},
+		Author: atomPerson{Name: author(data), URI: base + "/"},
+	}
+	for _, e := range items {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:        e.id,
+			Title:     e.title,
+			Link:      atomLink{Rel: "alternate", Type: "text/html", Href: e.link},
+			Published: e.published.UTC().Format(time.RFC3339),
+			Updated:   e.updated.UTC().Format(time.RFC3339),
+			Category:  atomCategory{Term: e.category},
+			Summary:   e.summary,
+		})
+	}
+	return marshal(feed)
+}
+
+func marshal(v any) ([]byte, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,166 @@
+// Package feed builds the Atom and RSS feeds followers subscribe to: one with
+// every project and publication, and one per type.
+//
+// Entries are ordered by the time they were added to the portfolio, newest
+// first. Their IDs are tag URIs (RFC 4151) built from the record ID, so renaming
+// a slug does not make readers show an entry again.
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"session-19/model"
+	"session-19/seo"
+)
+
+// MaxEntries caps the entries of a feed; readers only need the recent ones
+const MaxEntries = 50
+
+// Content types of the two formats
+const (
+	AtomType = "application/atom+xml"
+	RSSType  = "application/rss+xml"
+)
+
+// Kind is one of the feeds, served at Path plus ".atom" or ".rss"
+type Kind struct {
+	Name         string
+	Path         string
+	Projects     bool
+	Publications bool
+}
+
+// AtomPath returns the URL path of the Atom version of the feed
+func (k Kind) AtomPath() string { return k.Path + ".atom" }
+
+// RSSPath returns the URL path of the RSS version of the feed
+func (k Kind) RSSPath() string { return k.Path + ".rss" }
+
+// Kinds lists the feeds: everything, then one per type next to its detail pages
+var Kinds = []Kind{
+	{Name: "Projects & Publications", Path: "/feed", Projects: true, Publications: true},
+	{Name: "Projects", Path: model.ProjectsPath + "feed", Projects: true},
+	{Name: "Publications", Path: model.PublicationsPath + "feed", Publications: true},
+}
+
+// Alternates returns the head links of every feed, Atom before RSS
+func Alternates(siteName string) []seo.Alternate {
+	var links []seo.Alternate
+	for _, k := range Kinds {
+		links = append(links,
+			seo.Alternate{Title: siteName + " - " + k.Name + " (Atom)", Type: AtomType, Href: k.AtomPath()},
+			seo.Alternate{Title: siteName + " - " + k.Name + " (RSS)", Type: RSSType, Href: k.RSSPath()})
+	}
+	return links
+}
+
+// Lookup returns the feed and its content type served at path
+func Lookup(path string) (Kind, string, bool) {
+	for _, k := range Kinds {
+		switch path {
+		case k.AtomPath():
+			return k, AtomType, true
+		case k.RSSPath():
+			return k, RSSType, true
+		}
+	}
+	return Kind{}, "", false
+}
+
+// entry is a feed item in the terms shared by both formats
+type entry struct {
+	id        string
+	title     string
+	link      string
+	category  string
+	summary   string
+	published time.Time
+	updated   time.Time
+}
+
+// entries returns the items of the feed, newest first
+func entries(data *model.PortfolioData, kind Kind, base string) []entry {
+	var items []entry
+	if kind.Projects {
+		for _, p := range data.Projects {
+			items = append(items, entry{
+				id:        tagURI(base, p.CreatedAt, "project", p.ID),
+				title:     p.Title,
+				link:      link(base, p.Path(), "/#projects"),
+				category:  "project",
+				summary:   seo.Summary(p.Description),
+				published: p.CreatedAt,
+				updated:   p.UpdatedAt,
+			})
+		}
+	}
+	if kind.Publications {
+		for _, p := range data.Publications {
+			items = append(items, entry{
+				id:        tagURI(base, p.CreatedAt, "publication", p.ID),
+				title:     p.Title,
+				link:      link(base, p.Path(), "/#publications"),
+				category:  "publication",
+				summary:   seo.Summary(p.Description),
+				published: p.CreatedAt,
+				updated:   p.UpdatedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].published.After(items[j].published)
+	})
+	if len(items) > MaxEntries {
+		items = items[:MaxEntries]
+	}
+	for i := range items {
+		if items[i].updated.Before(items[i].published) {
+			items[i].updated = items[i].published
+		}
+	}
+	return items
+}
+
+// updated returns when the feed last changed: its newest entry update or the profile's
+func updated(data *model.PortfolioData, items []entry) time.Time {
+	last := data.Profile.UpdatedAt
+	for _, e := range items {
+		if e.updated.After(last) {
+			last = e.updated
+		}
+	}
+	return last.UTC()
+}
+
+// tagURI builds a permanent entry ID such as "tag:example.com,2026-01-02:project/7"
+func tagURI(base string, created time.Time, kind string, id int64) string {
+	host := base
+	if u, err := url.Parse(base); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return fmt.Sprintf("tag:%s,%s:%s/%d", host, created.UTC().Format("2006-01-02"), kind, id)
+}
+
+// link returns the page of an entry; without a detail page it is the portfolio section
+func link(base, path, fallback string) string {
+	if path == "" {
+		path = fallback
+	}
+	return base + path
+}
+
+// author returns the portfolio owner's name, as shown on the portfolio page
+func author(data *model.PortfolioData) string {
+	if data.Profile.Name == "" {
+		return seo.DefaultName
+	}
+	return data.Profile.Name
+}
+
+func title(data *model.PortfolioData, kind Kind) string {
+	return author(data) + " - " + kind.Name
+}
@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const base = "https://example.com"
+
+func testData() *model.PortfolioData {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 9, 0, 0, 0, time.UTC) }
+	return &model.PortfolioData{
+		Profile: model.Profile{Name: "John Doe", Title: "Software Engineer", UpdatedAt: day(1)},
+		Projects: []model.Project{
+			{ID: 1, Title: "Old API", Slug: "old-api", Description: "A **REST** API.", CreatedAt: day(2), UpdatedAt: day(9)},
+			{ID: 2, Title: "Draft", CreatedAt: day(5)},
+		},
+		Publications: []model.Publication{
+			{ID: 7, Title: "Paper", Slug: "paper", CreatedAt: day(4), UpdatedAt: day(4)},
+		},
+	}
+}
+
+func TestLookup(t *testing.T) {
+	kind, contentType, ok := Lookup("/projects/feed.rss")
+	require.True(t, ok)
+	assert.Equal(t, "Projects", kind.Name)
+	assert.Equal(t, RSSType, contentType)
+
+	kind, contentType, ok = Lookup("/feed.atom")
+	require.True(t, ok)
+	assert.True(t, kind.Projects && kind.Publications)
+	assert.Equal(t, AtomType, contentType)
+
+	_, _, ok = Lookup("/feed.json")
+	assert.False(t, ok)
+}
+
+func TestAtom(t *testing.T) {
+	out, err := Atom(testData(), Kinds[0], base)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(out, &feed))
+	assert.Equal(t, "https://example.com/feed.atom", feed.ID)
+	assert.Equal(t, "John Doe - Projects & Publications", feed.Title)
+	assert.Equal(t, "2026-01-09T09:00:00Z", feed.Updated)
+	assert.Equal(t, atomLink{Rel: "self", Type: AtomType, Href: "https://example.com/feed.atom"}, feed.Links[0])
+
+	// Newest first by publish date, whatever was edited last
+	require.Len(t, feed.Entries, 3)
+	assert.Equal(t, []string{"Draft", "Paper", "Old API"},
+		[]string{feed.Entries[0].Title, feed.Entries[1].Title, feed.Entries[2].Title})
+
+	draft := feed.Entries[0]
+	assert.Equal(t, "tag:example.com,2026-01-05:project/2", draft.ID)
+	assert.Equal(t, "https://example.com/#projects", draft.Link.Href)
+	// Never edited, so updated is when it was published
+	assert.Equal(t, "2026-01-05T09:00:00Z", draft.Updated)
+
+	old := feed.Entries[2]
+	assert.Equal(t, "https://example.com/projects/old-api", old.Link.Href)
+	assert.Equal(t, "2026-01-02T09:00:00Z", old.Published)
+	assert.Equal(t, "2026-01-09T09:00:00Z", old.Updated)
+	assert.Equal(t, "A REST API.", old.Summary)
+	assert.Equal(t, "project", old.Category.Term)
+}
+
+func TestRSS_PerType(t *testing.T) {
+	out, err := RSS(testData(), Kinds[2], base)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, string(out), `<atom:link href="https://example.com/publications/feed.rss" rel="self" type="application/rss+xml"></atom:link>`)
+
+	var feed rssFeed
+	require.NoError(t, xml.Unmarshal(out, &feed))
+	assert.Equal(t, "John Doe - Publications", feed.Channel.Title)
+	require.Len(t, feed.Channel.Items, 1)
+	item := feed.Channel.Items[0]
+	assert.Equal(t, "https://example.com/publications/paper", item.Link)
+	assert.Equal(t, rssGUID{Value: "tag:example.com,2026-01-04:publication/7"}, item.GUID)
+	assert.Equal(t, "Sun, 04 Jan 2026 09:00:00 +0000", item.PubDate)
+}
+
+func TestEntries_Capped(t *testing.T) {
+	data := &model.PortfolioData{}
+	for i := 0; i < MaxEntries+5; i++ {
+		data.Projects = append(data.Projects, model.Project{ID: int64(i), Title: fmt.Sprint(i), CreatedAt: time.Unix(int64(i), 0)})
+	}
+
+	items := entries(data, Kinds[1], base)
+
+	assert.Len(t, items, MaxEntries)
+	assert.Equal(t, fmt.Sprint(MaxEntries+4), items[0].title)
+}
@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+
+	"session-19/model"
+)
+
+// rssFeed is an RSS 2.0 feed, with the Atom self link feed validators ask for
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XMLNSAtom string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	AtomLink      rssSelf   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category"`
+	Description string  `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS renders the feed in the RSS 2.0 format
+func RSS(data *model.PortfolioData, kind Kind, base string) ([]byte, error) {
+	items := entries(data, kind, base)
+	feed := rssFeed{
+		Version:   "2.0",
+		XMLNSAtom: "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         title(data, kind),
+			Link:          base + "/",
+			Description:   first(data.Profile.Title, title(data, kind)),
+			LastBuildDate: updated(data, items).Format(time.RFC1123Z),
+			AtomLink:      rssSelf{Href: base + kind.RSSPath(), Rel: "self", Type: RSSType},
+		},
+	}
+	for _, e := range items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title: e.title,
+			Link:  e.link,
+			// Links follow slug changes, so the tag URI identifies the item instead
+			GUID:        rssGUID{Value: e.id},
+			PubDate:     e.published.UTC().Format(time.RFC1123Z),
+			Category:    e.category,
+			Description: e.summary,
+		})
+	}
+	return marshal(feed)
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
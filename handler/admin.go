@@ -1,10 +1,22 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"html/template"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"session-19/backup"
 	"session-19/dto"
+	"session-19/jsonresume"
+	"session-19/markdown"
+	"session-19/model"
+	"session-19/resume"
+	"session-19/seo"
 	"session-19/service"
+	"session-19/storage"
 	"session-19/utils"
 	"strconv"
 	"strings"
@@ -16,14 +28,26 @@ import (
 // AdminHandler handles admin panel requests
 type AdminHandler struct {
 	portfolioService service.PortfolioServiceInterface
+	mediaService     service.MediaServiceInterface
+	cvService        service.CVServiceInterface
+	jsonResume       service.JSONResumeServiceInterface
+	backupService    service.BackupServiceInterface
+	pubImport        service.PublicationImportServiceInterface
+	storage          storage.Storage
 	log              *zap.Logger
 	tmpl             *template.Template
 }
 
 // NewAdminHandler creates a new admin handler
-func NewAdminHandler(portfolioService service.PortfolioServiceInterface, log *zap.Logger, tmpl *template.Template) *AdminHandler {
+func NewAdminHandler(portfolioService service.PortfolioServiceInterface, mediaService service.MediaServiceInterface, cvService service.CVServiceInterface, jsonResume service.JSONResumeServiceInterface, backupService service.BackupServiceInterface, pubImport service.PublicationImportServiceInterface, store storage.Storage, log *zap.Logger, tmpl *template.Template) *AdminHandler {
 	return &AdminHandler{
 		portfolioService: portfolioService,
+		mediaService:     mediaService,
+		cvService:        cvService,
+		jsonResume:       jsonResume,
+		backupService:    backupService,
+		pubImport:        pubImport,
+		storage:          store,
 		log:              log,
 		tmpl:             tmpl,
 	}
@@ -40,24 +64,33 @@ func (h *AdminHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 
 	// Count items
 	stats := map[string]int{
-		"experiences":  0,
-		"skills":       0,
-		"projects":     0,
-		"publications": 0,
+		"experiences":    0,
+		"skills":         0,
+		"projects":       0,
+		"publications":   0,
+		"education":      0,
+		"certifications": 0,
+		"testimonials":   0,
 	}
 
-	if data != nil {
-		stats["experiences"] = len(data.Experiences)
-		for _, skills := range data.Skills {
-			stats["skills"] += len(skills)
-		}
-		stats["projects"] = len(data.Projects)
-		stats["publications"] = len(data.Publications)
+	if data == nil {
+		data = &model.PortfolioData{}
+	}
+	stats["experiences"] = len(data.Experiences)
+	for _, skills := range data.Skills {
+		stats["skills"] += len(skills)
 	}
+	stats["projects"] = len(data.Projects)
+	stats["publications"] = len(data.Publications)
+	stats["education"] = len(data.Education)
+	stats["certifications"] = len(data.Certifications)
+	stats["testimonials"] = len(data.Testimonials)
 
 	if err := h.tmpl.ExecuteTemplate(w, "dashboard", map[string]interface{}{
-		"Stats":   stats,
-		"Profile": data.Profile,
+		"Stats":    stats,
+		"Profile":  data.Profile,
+		"Failures": data.Failures,
+		"Pending":  h.pendingTestimonials(ctx),
 	}); err != nil {
 		h.log.Error("Failed to render dashboard", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -77,6 +110,7 @@ func (h *AdminHandler) ProfileEdit(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.tmpl.ExecuteTemplate(w, "profile_form", map[string]interface{}{
 		"Profile": profile,
+		"Media":   h.mediaLibrary(ctx),
 	}); err != nil {
 		h.log.Error("Failed to render profile form", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -90,21 +124,22 @@ func (h *AdminHandler) ProfileSave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse multipart form (max 10MB)
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		h.log.Error("Failed to parse form", zap.Error(err))
+	if err := h.parseUploadForm(w, r, utils.MaxUploadRequestSize, utils.ErrFileTooLarge); err != nil {
+		h.renderProfileError(r.Context(), w, &dto.ProfileRequest{}, sentence(err))
+		return
 	}
 
 	ctx := r.Context()
 
 	// Handle photo upload
 	photoURL := r.FormValue("existing_photo")
+	var uploaded string
 	if file, header, err := r.FormFile("photo"); err == nil {
 		defer file.Close()
-		uploadedPath, uploadErr := utils.UploadFile(file, header, "uploads/profile")
+		uploadedPath, uploadErr := utils.UploadFile(ctx, h.storage, file, header, "uploads/profile")
 		if uploadErr != nil {
 			h.log.Error("Failed to upload photo", zap.Error(uploadErr))
-			h.renderProfileError(w, &dto.ProfileRequest{
+			h.renderProfileError(ctx, w, &dto.ProfileRequest{
 				Name:        r.FormValue("name"),
 				Title:       r.FormValue("title"),
 				Description: r.FormValue("description"),
@@ -117,6 +152,28 @@ func (h *AdminHandler) ProfileSave(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		photoURL = uploadedPath
+		uploaded = uploadedPath
+	}
+
+	// A photo picked from the media library applies unless a file was uploaded
+	if uploaded == "" {
+		media, err := h.pickedMedia(r)
+		if err != nil {
+			h.renderProfileError(ctx, w, &dto.ProfileRequest{
+				Name:        r.FormValue("name"),
+				Title:       r.FormValue("title"),
+				Description: r.FormValue("description"),
+				PhotoURL:    photoURL,
+				Email:       r.FormValue("email"),
+				LinkedInURL: r.FormValue("linkedin_url"),
+				GithubURL:   r.FormValue("github_url"),
+				CVURL:       r.FormValue("cv_url"),
+			}, errorMessage(err))
+			return
+		}
+		if media != nil {
+			photoURL = media.URL
+		}
 	}
 
 	req := &dto.ProfileRequest{
@@ -133,15 +190,21 @@ func (h *AdminHandler) ProfileSave(w http.ResponseWriter, r *http.Request) {
 	idStr := r.FormValue("id")
 	if idStr != "" && idStr != "0" {
 		id, _ := strconv.ParseInt(idStr, 10, 64)
+		existing, _ := h.portfolioService.GetProfile(ctx)
 		_, err := h.portfolioService.UpdateProfile(ctx, id, req)
 		if err != nil {
-			h.renderProfileError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, nil)
+			h.renderProfileError(ctx, w, req, errorMessage(err))
 			return
 		}
+		if existing != nil && existing.ID == id && existing.PhotoURL != photoURL {
+			h.deleteImage(ctx, existing.PhotoURL, nil)
+		}
 	} else {
 		_, err := h.portfolioService.CreateProfile(ctx, req)
 		if err != nil {
-			h.renderProfileError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, nil)
+			h.renderProfileError(ctx, w, req, errorMessage(err))
 			return
 		}
 	}
@@ -149,10 +212,11 @@ func (h *AdminHandler) ProfileSave(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/admin/dashboard?success=profile", http.StatusSeeOther)
 }
 
-func (h *AdminHandler) renderProfileError(w http.ResponseWriter, req *dto.ProfileRequest, errMsg string) {
+func (h *AdminHandler) renderProfileError(ctx context.Context, w http.ResponseWriter, req *dto.ProfileRequest, errMsg string) {
 	h.tmpl.ExecuteTemplate(w, "profile_form", map[string]interface{}{
 		"Error":   errMsg,
 		"Profile": req,
+		"Media":   h.mediaLibrary(ctx),
 	})
 }
 
@@ -221,13 +285,13 @@ func (h *AdminHandler) ExperienceSave(w http.ResponseWriter, r *http.Request) {
 		id, _ := strconv.ParseInt(idStr, 10, 64)
 		_, err := h.portfolioService.UpdateExperience(ctx, id, req)
 		if err != nil {
-			h.renderExperienceError(w, req, err.Error(), nil)
+			h.renderExperienceError(w, req, errorMessage(err), nil)
 			return
 		}
 	} else {
 		_, err := h.portfolioService.CreateExperience(ctx, req)
 		if err != nil {
-			h.renderExperienceError(w, req, err.Error(), nil)
+			h.renderExperienceError(w, req, errorMessage(err), nil)
 			return
 		}
 	}
@@ -330,13 +394,13 @@ func (h *AdminHandler) SkillSave(w http.ResponseWriter, r *http.Request) {
 		id, _ := strconv.ParseInt(idStr, 10, 64)
 		_, err := h.portfolioService.UpdateSkill(ctx, id, req)
 		if err != nil {
-			h.renderSkillError(w, req, err.Error())
+			h.renderSkillError(w, req, errorMessage(err))
 			return
 		}
 	} else {
 		_, err := h.portfolioService.CreateSkill(ctx, req)
 		if err != nil {
-			h.renderSkillError(w, req, err.Error())
+			h.renderSkillError(w, req, errorMessage(err))
 			return
 		}
 	}
@@ -401,6 +465,7 @@ func (h *AdminHandler) ProjectForm(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.tmpl.ExecuteTemplate(w, "project_form", map[string]interface{}{
 		"Project": project,
+		"Media":   h.mediaLibrary(ctx),
 	}); err != nil {
 		h.log.Error("Failed to render project form", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -414,21 +479,23 @@ func (h *AdminHandler) ProjectSave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse multipart form (max 10MB)
-	if err := r.ParseMultipartForm(10 << 20); err != nil {
-		h.log.Error("Failed to parse form", zap.Error(err))
+	if err := h.parseUploadForm(w, r, utils.MaxUploadRequestSize, utils.ErrFileTooLarge); err != nil {
+		h.renderProjectError(r.Context(), w, &dto.ProjectRequest{}, sentence(err))
+		return
 	}
 
 	ctx := r.Context()
 
 	// Handle image upload
 	imageURL := r.FormValue("existing_image")
+	var uploaded string
+	var variants model.ImageVariants
 	if file, header, err := r.FormFile("image"); err == nil {
 		defer file.Close()
-		uploadedPath, uploadErr := utils.UploadFile(file, header, "uploads/projects")
+		uploadedPath, uploadErr := utils.UploadFile(ctx, h.storage, file, header, "uploads/projects")
 		if uploadErr != nil {
 			h.log.Error("Failed to upload project image", zap.Error(uploadErr))
-			h.renderProjectError(w, &dto.ProjectRequest{
+			h.renderProjectError(ctx, w, &dto.ProjectRequest{
 				Title:       r.FormValue("title"),
 				Description: r.FormValue("description"),
 				ImageURL:    imageURL,
@@ -436,20 +503,50 @@ func (h *AdminHandler) ProjectSave(w http.ResponseWriter, r *http.Request) {
 				GithubURL:   r.FormValue("github_url"),
 				TechStack:   r.FormValue("tech_stack"),
 				Color:       r.FormValue("color"),
+				Slug:        r.FormValue("slug"),
+				Content:     r.FormValue("content"),
 			}, uploadErr.Error())
 			return
 		}
 		imageURL = uploadedPath
+		uploaded = uploadedPath
+		variants = h.imageVariants(ctx, uploadedPath)
+	}
+
+	// An image picked from the media library applies unless a file was uploaded
+	if uploaded == "" {
+		media, err := h.pickedMedia(r)
+		if err != nil {
+			h.renderProjectError(ctx, w, &dto.ProjectRequest{
+				Title:       r.FormValue("title"),
+				Description: r.FormValue("description"),
+				ImageURL:    imageURL,
+				ProjectURL:  r.FormValue("project_url"),
+				GithubURL:   r.FormValue("github_url"),
+				TechStack:   r.FormValue("tech_stack"),
+				Color:       r.FormValue("color"),
+				Slug:        r.FormValue("slug"),
+				Content:     r.FormValue("content"),
+			}, errorMessage(err))
+			return
+		}
+		if media != nil {
+			imageURL = media.URL
+			variants = media.Variants
+		}
 	}
 
 	req := &dto.ProjectRequest{
-		Title:       r.FormValue("title"),
-		Description: r.FormValue("description"),
-		ImageURL:    imageURL,
-		ProjectURL:  r.FormValue("project_url"),
-		GithubURL:   r.FormValue("github_url"),
-		TechStack:   r.FormValue("tech_stack"),
-		Color:       r.FormValue("color"),
+		Title:         r.FormValue("title"),
+		Description:   r.FormValue("description"),
+		ImageURL:      imageURL,
+		ImageVariants: variants,
+		ProjectURL:    r.FormValue("project_url"),
+		GithubURL:     r.FormValue("github_url"),
+		TechStack:     r.FormValue("tech_stack"),
+		Color:         r.FormValue("color"),
+		Slug:          r.FormValue("slug"),
+		Content:       r.FormValue("content"),
 	}
 
 	// Get profile ID for foreign key
@@ -461,15 +558,25 @@ func (h *AdminHandler) ProjectSave(w http.ResponseWriter, r *http.Request) {
 	idStr := r.FormValue("id")
 	if idStr != "" && idStr != "0" {
 		id, _ := strconv.ParseInt(idStr, 10, 64)
+		existing, _ := h.portfolioService.GetProjectByID(ctx, id)
+		// Keep the variants of an unchanged image
+		if uploaded == "" && existing != nil && existing.ImageURL == imageURL {
+			req.ImageVariants = existing.ImageVariants
+		}
 		_, err := h.portfolioService.UpdateProject(ctx, id, req)
 		if err != nil {
-			h.renderProjectError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, variants)
+			h.renderProjectError(ctx, w, req, errorMessage(err))
 			return
 		}
+		if existing != nil && existing.ImageURL != imageURL {
+			h.deleteImage(ctx, existing.ImageURL, existing.ImageVariants)
+		}
 	} else {
 		_, err := h.portfolioService.CreateProject(ctx, req)
 		if err != nil {
-			h.renderProjectError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, variants)
+			h.renderProjectError(ctx, w, req, errorMessage(err))
 			return
 		}
 	}
@@ -483,17 +590,21 @@ func (h *AdminHandler) ProjectDelete(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, _ := strconv.ParseInt(idStr, 10, 64)
 
+	existing, _ := h.portfolioService.GetProjectByID(ctx, id)
 	if err := h.portfolioService.DeleteProject(ctx, id); err != nil {
 		h.log.Error("Failed to delete project", zap.Error(err))
+	} else if existing != nil {
+		h.deleteImage(ctx, existing.ImageURL, existing.ImageVariants)
 	}
 
 	http.Redirect(w, r, "/admin/projects?success=deleted", http.StatusSeeOther)
 }
 
-func (h *AdminHandler) renderProjectError(w http.ResponseWriter, req *dto.ProjectRequest, errMsg string) {
+func (h *AdminHandler) renderProjectError(ctx context.Context, w http.ResponseWriter, req *dto.ProjectRequest, errMsg string) {
 	h.tmpl.ExecuteTemplate(w, "project_form", map[string]interface{}{
 		"Error":   errMsg,
 		"Project": req,
+		"Media":   h.mediaLibrary(ctx),
 	})
 }
 
@@ -533,12 +644,40 @@ func (h *AdminHandler) PublicationForm(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.tmpl.ExecuteTemplate(w, "publication_form", map[string]interface{}{
 		"Publication": publication,
+		"Media":       h.mediaLibrary(ctx),
 	}); err != nil {
 		h.log.Error("Failed to render publication form", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
 }
 
+// formAuthors reads the author rows of the publication form. Each row posts one
+// author_name, author_affiliation, author_orcid, author_url and author_me value,
+// so the lists line up; rows left entirely blank are dropped.
+func formAuthors(r *http.Request) model.Authors {
+	at := func(key string, i int) string {
+		if values := r.Form[key]; i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+	var authors model.Authors
+	for i := range r.Form["author_name"] {
+		a := model.Author{
+			Name:        at("author_name", i),
+			Affiliation: at("author_affiliation", i),
+			ORCID:       at("author_orcid", i),
+			URL:         at("author_url", i),
+			IsMe:        at("author_me", i) == "1",
+		}
+		if a == (model.Author{}) {
+			continue
+		}
+		authors = append(authors, a)
+	}
+	return authors
+}
+
 // PublicationSave handles publication create/update
 func (h *AdminHandler) PublicationSave(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -546,31 +685,80 @@ func (h *AdminHandler) PublicationSave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.parseUploadForm(w, r, utils.MaxUploadRequestSize, utils.ErrFileTooLarge); err != nil {
+		h.renderPublicationError(r.Context(), w, &dto.PublicationRequest{}, sentence(err))
+		return
+	}
+
 	ctx := r.Context()
 	year, _ := strconv.Atoi(r.FormValue("year"))
 	req := &dto.PublicationRequest{
 		Title:          r.FormValue("title"),
-		Authors:        r.FormValue("authors"),
+		Authors:        formAuthors(r),
 		Journal:        r.FormValue("journal"),
+		VenueType:      r.FormValue("venue_type"),
+		Volume:         r.FormValue("volume"),
+		Pages:          r.FormValue("pages"),
 		Year:           year,
+		DOI:            r.FormValue("doi"),
 		Description:    r.FormValue("description"),
 		ImageURL:       r.FormValue("image_url"),
 		PublicationURL: r.FormValue("publication_url"),
 		Color:          r.FormValue("color"),
+		Slug:           r.FormValue("slug"),
+		Content:        r.FormValue("content"),
+	}
+
+	// Handle image upload; an uploaded file replaces the image URL field
+	var uploaded string
+	if file, header, err := r.FormFile("image"); err == nil {
+		defer file.Close()
+		uploadedPath, uploadErr := utils.UploadFile(ctx, h.storage, file, header, "uploads/publications")
+		if uploadErr != nil {
+			h.log.Error("Failed to upload publication image", zap.Error(uploadErr))
+			h.renderPublicationError(ctx, w, req, uploadErr.Error())
+			return
+		}
+		req.ImageURL = uploadedPath
+		req.ImageVariants = h.imageVariants(ctx, uploadedPath)
+		uploaded = uploadedPath
+	}
+
+	// An image picked from the media library applies unless a file was uploaded
+	if uploaded == "" {
+		media, err := h.pickedMedia(r)
+		if err != nil {
+			h.renderPublicationError(ctx, w, req, errorMessage(err))
+			return
+		}
+		if media != nil {
+			req.ImageURL = media.URL
+			req.ImageVariants = media.Variants
+		}
 	}
 
 	idStr := r.FormValue("id")
 	if idStr != "" && idStr != "0" {
 		id, _ := strconv.ParseInt(idStr, 10, 64)
+		existing, _ := h.portfolioService.GetPublicationByID(ctx, id)
+		// Keep the variants of an unchanged image
+		if uploaded == "" && existing != nil && existing.ImageURL == req.ImageURL {
+			req.ImageVariants = existing.ImageVariants
+		}
 		_, err := h.portfolioService.UpdatePublication(ctx, id, req)
 		if err != nil {
-			h.renderPublicationError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, req.ImageVariants)
+			h.renderPublicationError(ctx, w, req, errorMessage(err))
 			return
 		}
+		if existing != nil && existing.ImageURL != req.ImageURL {
+			h.deleteImage(ctx, existing.ImageURL, existing.ImageVariants)
+		}
 	} else {
 		_, err := h.portfolioService.CreatePublication(ctx, req)
 		if err != nil {
-			h.renderPublicationError(w, req, err.Error())
+			h.deleteImage(ctx, uploaded, req.ImageVariants)
+			h.renderPublicationError(ctx, w, req, errorMessage(err))
 			return
 		}
 	}
@@ -584,20 +772,981 @@ func (h *AdminHandler) PublicationDelete(w http.ResponseWriter, r *http.Request)
 	idStr := chi.URLParam(r, "id")
 	id, _ := strconv.ParseInt(idStr, 10, 64)
 
+	existing, _ := h.portfolioService.GetPublicationByID(ctx, id)
 	if err := h.portfolioService.DeletePublication(ctx, id); err != nil {
 		h.log.Error("Failed to delete publication", zap.Error(err))
+	} else if existing != nil {
+		h.deleteImage(ctx, existing.ImageURL, existing.ImageVariants)
 	}
 
 	http.Redirect(w, r, "/admin/publications?success=deleted", http.StatusSeeOther)
 }
 
-func (h *AdminHandler) renderPublicationError(w http.ResponseWriter, req *dto.PublicationRequest, errMsg string) {
+func (h *AdminHandler) renderPublicationError(ctx context.Context, w http.ResponseWriter, req *dto.PublicationRequest, errMsg string) {
 	h.tmpl.ExecuteTemplate(w, "publication_form", map[string]interface{}{
 		"Error":       errMsg,
 		"Publication": req,
+		"Media":       h.mediaLibrary(ctx),
+	})
+}
+
+// ==================== EDUCATION ====================
+
+// EducationList renders the education list
+func (h *AdminHandler) EducationList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	education, err := h.portfolioService.GetAllEducation(ctx)
+	if err != nil {
+		h.log.Error("Failed to get education", zap.Error(err))
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "education_list", map[string]interface{}{
+		"Education": education,
+		"Success":   r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render education list", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// EducationForm renders the education form
+func (h *AdminHandler) EducationForm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+
+	var education interface{}
+	var id int64
+	if idStr != "" {
+		id, _ = strconv.ParseInt(idStr, 10, 64)
+		edu, err := h.portfolioService.GetEducationByID(ctx, id)
+		if err == nil {
+			education = edu
+		}
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "education_form", map[string]interface{}{
+		"ID":        id,
+		"Education": education,
+	}); err != nil {
+		h.log.Error("Failed to render education form", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// EducationSave handles education create/update
+func (h *AdminHandler) EducationSave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &dto.EducationRequest{
+		Institution: r.FormValue("institution"),
+		Degree:      r.FormValue("degree"),
+		Field:       r.FormValue("field"),
+		StartDate:   r.FormValue("start_date"),
+		EndDate:     r.FormValue("end_date"),
+		GPA:         r.FormValue("gpa"),
+		Honors:      r.FormValue("honors"),
+		Description: r.FormValue("description"),
+		Color:       r.FormValue("color"),
+	}
+
+	id, _ := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	var err error
+	if id > 0 {
+		_, err = h.portfolioService.UpdateEducation(ctx, id, req)
+	} else {
+		_, err = h.portfolioService.CreateEducation(ctx, req)
+	}
+	if err != nil {
+		h.renderEducationError(w, id, req, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/education?success=saved", http.StatusSeeOther)
+}
+
+// EducationDelete handles education deletion
+func (h *AdminHandler) EducationDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	if err := h.portfolioService.DeleteEducation(ctx, id); err != nil {
+		h.log.Error("Failed to delete education", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/admin/education?success=deleted", http.StatusSeeOther)
+}
+
+// renderEducationError re-renders the form with the submitted values; id is 0 for a new entry
+func (h *AdminHandler) renderEducationError(w http.ResponseWriter, id int64, req *dto.EducationRequest, errMsg string) {
+	h.tmpl.ExecuteTemplate(w, "education_form", map[string]interface{}{
+		"Error":     errMsg,
+		"ID":        id,
+		"Education": req,
+	})
+}
+
+// ==================== CERTIFICATIONS ====================
+
+// CertificationsList renders the certifications list
+func (h *AdminHandler) CertificationsList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	certifications, err := h.portfolioService.GetAllCertifications(ctx)
+	if err != nil {
+		h.log.Error("Failed to get certifications", zap.Error(err))
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "certifications_list", map[string]interface{}{
+		"Certifications": certifications,
+		"Success":        r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render certifications list", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// CertificationForm renders the certification form
+func (h *AdminHandler) CertificationForm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+
+	var certification interface{}
+	var id int64
+	if idStr != "" {
+		id, _ = strconv.ParseInt(idStr, 10, 64)
+		cert, err := h.portfolioService.GetCertificationByID(ctx, id)
+		if err == nil {
+			certification = cert
+		}
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "certification_form", map[string]interface{}{
+		"ID":            id,
+		"Certification": certification,
+	}); err != nil {
+		h.log.Error("Failed to render certification form", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// CertificationSave handles certification create/update
+func (h *AdminHandler) CertificationSave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &dto.CertificationRequest{
+		Name:            r.FormValue("name"),
+		Issuer:          r.FormValue("issuer"),
+		CredentialID:    r.FormValue("credential_id"),
+		IssueDate:       r.FormValue("issue_date"),
+		ExpiryDate:      r.FormValue("expiry_date"),
+		VerificationURL: r.FormValue("verification_url"),
+		Color:           r.FormValue("color"),
+	}
+
+	id, _ := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	var err error
+	if id > 0 {
+		_, err = h.portfolioService.UpdateCertification(ctx, id, req)
+	} else {
+		_, err = h.portfolioService.CreateCertification(ctx, req)
+	}
+	if err != nil {
+		h.renderCertificationError(w, id, req, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/certifications?success=saved", http.StatusSeeOther)
+}
+
+// CertificationDelete handles certification deletion
+func (h *AdminHandler) CertificationDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	if err := h.portfolioService.DeleteCertification(ctx, id); err != nil {
+		h.log.Error("Failed to delete certification", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/admin/certifications?success=deleted", http.StatusSeeOther)
+}
+
+// renderCertificationError re-renders the form with the submitted values; id is 0 for a new certification
+func (h *AdminHandler) renderCertificationError(w http.ResponseWriter, id int64, req *dto.CertificationRequest, errMsg string) {
+	h.tmpl.ExecuteTemplate(w, "certification_form", map[string]interface{}{
+		"Error":         errMsg,
+		"ID":            id,
+		"Certification": req,
+	})
+}
+
+// ==================== TESTIMONIALS ====================
+
+// TestimonialsList renders the testimonials, pending ones first, and the invite links
+func (h *AdminHandler) TestimonialsList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	testimonials, err := h.portfolioService.GetAllTestimonials(ctx)
+	if err != nil {
+		h.log.Error("Failed to get testimonials", zap.Error(err))
+	}
+	invites, err := h.portfolioService.GetAllTestimonialInvites(ctx)
+	if err != nil {
+		h.log.Error("Failed to get testimonial invites", zap.Error(err))
+	}
+
+	h.renderTestimonialsList(w, r, testimonials, invites, "")
+}
+
+// renderTestimonialsList renders the testimonials list; errMsg reports a failed invite
+func (h *AdminHandler) renderTestimonialsList(w http.ResponseWriter, r *http.Request, testimonials []model.Testimonial, invites []model.TestimonialInvite, errMsg string) {
+	var siteURL string
+	if settings, err := h.portfolioService.GetSEOSettings(r.Context()); err == nil {
+		siteURL = settings.SiteURL
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "testimonials_list", map[string]interface{}{
+		"Testimonials": testimonials,
+		"Invites":      invites,
+		"BaseURL":      seo.BaseURL(r, siteURL),
+		"Success":      r.URL.Query().Get("success"),
+		"Error":        errMsg,
+	}); err != nil {
+		h.log.Error("Failed to render testimonials list", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// TestimonialForm renders the testimonial form
+func (h *AdminHandler) TestimonialForm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+
+	var testimonial interface{}
+	var id int64
+	if idStr != "" {
+		id, _ = strconv.ParseInt(idStr, 10, 64)
+		t, err := h.portfolioService.GetTestimonialByID(ctx, id)
+		if err == nil {
+			testimonial = t
+		}
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "testimonial_form", map[string]interface{}{
+		"ID":          id,
+		"Testimonial": testimonial,
+		"Statuses":    model.TestimonialStatuses,
+	}); err != nil {
+		h.log.Error("Failed to render testimonial form", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// TestimonialSave handles testimonial create/update
+func (h *AdminHandler) TestimonialSave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := &dto.TestimonialRequest{
+		Author:       r.FormValue("author"),
+		Role:         r.FormValue("role"),
+		Company:      r.FormValue("company"),
+		Quote:        r.FormValue("quote"),
+		AvatarURL:    r.FormValue("avatar_url"),
+		Relationship: r.FormValue("relationship"),
+		Status:       r.FormValue("status"),
+		Color:        r.FormValue("color"),
+	}
+
+	id, _ := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	var err error
+	if id > 0 {
+		_, err = h.portfolioService.UpdateTestimonial(ctx, id, req)
+	} else {
+		_, err = h.portfolioService.CreateTestimonial(ctx, req)
+	}
+	if err != nil {
+		h.renderTestimonialError(w, id, req, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/testimonials?success=saved", http.StatusSeeOther)
+}
+
+// TestimonialStatus approves or rejects a testimonial
+func (h *AdminHandler) TestimonialStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	status := r.FormValue("status")
+
+	if err := h.portfolioService.SetTestimonialStatus(ctx, id, status); err != nil {
+		h.log.Error("Failed to moderate testimonial", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/admin/testimonials?success="+url.QueryEscape(status), http.StatusSeeOther)
+}
+
+// TestimonialDelete handles testimonial deletion
+func (h *AdminHandler) TestimonialDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	if err := h.portfolioService.DeleteTestimonial(ctx, id); err != nil {
+		h.log.Error("Failed to delete testimonial", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/admin/testimonials?success=deleted", http.StatusSeeOther)
+}
+
+// renderTestimonialError re-renders the form with the submitted values; id is 0 for a new testimonial
+func (h *AdminHandler) renderTestimonialError(w http.ResponseWriter, id int64, req *dto.TestimonialRequest, errMsg string) {
+	h.tmpl.ExecuteTemplate(w, "testimonial_form", map[string]interface{}{
+		"Error":       errMsg,
+		"ID":          id,
+		"Testimonial": req,
+		"Statuses":    model.TestimonialStatuses,
 	})
 }
 
+// TestimonialInviteCreate issues a new invite link for a colleague
+func (h *AdminHandler) TestimonialInviteCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if _, err := h.portfolioService.IssueTestimonialInvite(ctx, &dto.TestimonialInviteRequest{Note: r.FormValue("note")}); err != nil {
+		testimonials, _ := h.portfolioService.GetAllTestimonials(ctx)
+		invites, _ := h.portfolioService.GetAllTestimonialInvites(ctx)
+		h.renderTestimonialsList(w, r, testimonials, invites, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/testimonials?success=invited#invites", http.StatusSeeOther)
+}
+
+// TestimonialInviteDelete revokes an invite link
+func (h *AdminHandler) TestimonialInviteDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := chi.URLParam(r, "id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	if err := h.portfolioService.RevokeTestimonialInvite(ctx, id); err != nil {
+		h.log.Error("Failed to revoke testimonial invite", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/admin/testimonials?success=revoked#invites", http.StatusSeeOther)
+}
+
+// pendingTestimonials counts the testimonials waiting for moderation
+func (h *AdminHandler) pendingTestimonials(ctx context.Context) int {
+	testimonials, err := h.portfolioService.GetAllTestimonials(ctx)
+	if err != nil {
+		h.log.Error("Failed to get testimonials", zap.Error(err))
+	}
+	var pending int
+	for _, t := range testimonials {
+		if t.Status == model.TestimonialPending {
+			pending++
+		}
+	}
+	return pending
+}
+
+// ==================== MEDIA ====================
+
+// MediaList renders the media library
+func (h *AdminHandler) MediaList(w http.ResponseWriter, r *http.Request) {
+	h.renderMediaList(w, r, "")
+}
+
+// MediaUpload adds an uploaded image to the media library
+func (h *AdminHandler) MediaUpload(w http.ResponseWriter, r *http.Request) {
+	if err := h.parseUploadForm(w, r, utils.MaxUploadRequestSize, utils.ErrFileTooLarge); err != nil {
+		h.renderMediaList(w, r, sentence(err))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.renderMediaList(w, r, "Please choose an image to upload")
+		return
+	}
+	defer file.Close()
+
+	img, err := utils.ReadImage(file, header)
+	if err != nil {
+		h.log.Error("Failed to read media upload", zap.Error(err))
+		h.renderMediaList(w, r, err.Error())
+		return
+	}
+
+	_, duplicate, err := h.mediaService.UploadMedia(r.Context(), img, r.FormValue("alt_text"))
+	if err != nil {
+		h.log.Error("Failed to upload media", zap.Error(err))
+		h.renderMediaList(w, r, errorMessage(err))
+		return
+	}
+
+	if duplicate {
+		http.Redirect(w, r, "/admin/media?success=duplicate", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/admin/media?success=uploaded", http.StatusSeeOther)
+}
+
+// MediaSave updates the alt text of a media item
+func (h *AdminHandler) MediaSave(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if _, err := h.mediaService.UpdateMediaAltText(r.Context(), id, r.FormValue("alt_text")); err != nil {
+		h.log.Error("Failed to update media", zap.Error(err))
+		h.renderMediaList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/media?success=saved", http.StatusSeeOther)
+}
+
+// MediaDelete removes a media item that is no longer in use
+func (h *AdminHandler) MediaDelete(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.mediaService.DeleteMedia(r.Context(), id); err != nil {
+		h.log.Error("Failed to delete media", zap.Error(err))
+		h.renderMediaList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/media?success=deleted", http.StatusSeeOther)
+}
+
+func (h *AdminHandler) renderMediaList(w http.ResponseWriter, r *http.Request, errMsg string) {
+	if err := h.tmpl.ExecuteTemplate(w, "media_list", map[string]interface{}{
+		"Media":   h.mediaLibrary(r.Context()),
+		"Error":   errMsg,
+		"Success": r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render media list", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== CV ====================
+
+// CVList renders the CV version history
+func (h *AdminHandler) CVList(w http.ResponseWriter, r *http.Request) {
+	h.renderCVList(w, r, "")
+}
+
+// CVUpload stores an uploaded PDF as a new CV version
+func (h *AdminHandler) CVUpload(w http.ResponseWriter, r *http.Request) {
+	if err := h.parseUploadForm(w, r, utils.MaxPDFRequestSize, utils.ErrPDFTooLarge); err != nil {
+		h.renderCVList(w, r, sentence(err))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.renderCVList(w, r, "Please choose a PDF to upload")
+		return
+	}
+	defer file.Close()
+
+	pdf, err := utils.ReadPDF(file, header)
+	if err != nil {
+		h.log.Error("Failed to read CV upload", zap.Error(err))
+		h.renderCVList(w, r, err.Error())
+		return
+	}
+
+	if _, err := h.cvService.UploadCV(r.Context(), pdf, r.FormValue("activate") == "on"); err != nil {
+		h.log.Error("Failed to upload CV", zap.Error(err))
+		h.renderCVList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/cv?success=uploaded", http.StatusSeeOther)
+}
+
+// CVActivate makes a version the CV linked from the profile
+func (h *AdminHandler) CVActivate(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.cvService.ActivateCV(r.Context(), id); err != nil {
+		h.log.Error("Failed to activate CV", zap.Error(err))
+		h.renderCVList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/cv?success=activated", http.StatusSeeOther)
+}
+
+// CVView serves any version to the admin without counting a download
+func (h *AdminHandler) CVView(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	cv, rc, err := h.cvService.OpenCV(r.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to open CV", zap.Error(err))
+		h.renderCVList(w, r, errorMessage(err))
+		return
+	}
+	defer rc.Close()
+
+	serveCV(w, cv, rc, h.log)
+}
+
+// CVDelete removes an inactive CV version
+func (h *AdminHandler) CVDelete(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.cvService.DeleteCV(r.Context(), id); err != nil {
+		h.log.Error("Failed to delete CV", zap.Error(err))
+		h.renderCVList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/cv?success=deleted", http.StatusSeeOther)
+}
+
+// CVUseGenerated links the profile's CV to the résumé generated in the chosen layout
+func (h *AdminHandler) CVUseGenerated(w http.ResponseWriter, r *http.Request) {
+	layout := r.FormValue("layout")
+	if !resume.ValidLayout(layout) {
+		h.renderCVList(w, r, "Please choose a résumé layout")
+		return
+	}
+
+	if err := h.cvService.LinkGeneratedCV(r.Context(), resume.URL(layout)); err != nil {
+		h.log.Error("Failed to link generated résumé", zap.Error(err))
+		h.renderCVList(w, r, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/cv?success=generated", http.StatusSeeOther)
+}
+
+func (h *AdminHandler) renderCVList(w http.ResponseWriter, r *http.Request, errMsg string) {
+	versions, err := h.cvService.GetAllCVVersions(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get CV versions", zap.Error(err))
+	}
+
+	// The current link is only informational, so the page still renders without it
+	var cvURL string
+	if profile, err := h.portfolioService.GetProfile(r.Context()); err == nil {
+		cvURL = profile.CVURL
+	}
+
+	type resumeLayout struct {
+		Name, URL string
+		Linked    bool
+	}
+	layouts := make([]resumeLayout, len(resume.Layouts))
+	for i, name := range resume.Layouts {
+		url := resume.URL(name)
+		layouts[i] = resumeLayout{Name: name, URL: url, Linked: cvURL == url}
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "cv_list", map[string]interface{}{
+		"Versions":      versions,
+		"CVPath":        service.CVPath,
+		"CVURL":         cvURL,
+		"ResumeLayouts": layouts,
+		"Error":         errMsg,
+		"Success":       r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render CV list", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== Import ====================
+
+// ImportForm renders the JSON Resume import page
+func (h *AdminHandler) ImportForm(w http.ResponseWriter, r *http.Request) {
+	h.renderImport(w, r, "", nil, "")
+}
+
+// ImportPreview shows what importing a JSON Resume document would change
+func (h *AdminHandler) ImportPreview(w http.ResponseWriter, r *http.Request) {
+	doc, resume, err := h.readJSONResume(w, r)
+	if err != nil {
+		h.renderImport(w, r, doc, nil, sentence(err))
+		return
+	}
+
+	plan, err := h.jsonResume.PreviewJSONResume(r.Context(), resume)
+	if err != nil {
+		h.log.Error("Failed to preview JSON Resume import", zap.Error(err))
+		h.renderImport(w, r, doc, nil, errorMessage(err))
+		return
+	}
+
+	h.renderImport(w, r, doc, plan, "")
+}
+
+// ImportApply upserts the records of a previewed JSON Resume document in one transaction
+func (h *AdminHandler) ImportApply(w http.ResponseWriter, r *http.Request) {
+	doc, resume, err := h.readJSONResume(w, r)
+	if err != nil {
+		h.renderImport(w, r, doc, nil, sentence(err))
+		return
+	}
+
+	plan, err := h.jsonResume.ImportJSONResume(r.Context(), resume)
+	if err != nil {
+		h.log.Error("Failed to import JSON Resume", zap.Error(err))
+		h.renderImport(w, r, doc, plan, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/import?success=imported", http.StatusSeeOther)
+}
+
+// readJSONResume decodes the document from the uploaded file, or from the
+// pasted text when no file was chosen. The raw text is returned as well so
+// the page can carry it from the preview to the apply step.
+func (h *AdminHandler) readJSONResume(w http.ResponseWriter, r *http.Request) (string, *jsonresume.Resume, error) {
+	if err := h.parseUploadForm(w, r, jsonresume.MaxSize+1024*1024, jsonresume.ErrTooLarge); err != nil {
+		return "", nil, err
+	}
+
+	doc := r.FormValue("document")
+	if file, _, err := r.FormFile("file"); err == nil {
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, jsonresume.MaxSize+1))
+		if err != nil {
+			return "", nil, err
+		}
+		doc = string(data)
+	}
+
+	if strings.TrimSpace(doc) == "" {
+		return "", nil, errors.New("Please choose a JSON Resume file or paste a document")
+	}
+
+	resume, err := jsonresume.Decode(strings.NewReader(doc))
+	if err != nil {
+		h.log.Warn("Failed to decode JSON Resume", zap.Error(err))
+		return doc, nil, err
+	}
+	return doc, resume, nil
+}
+
+func (h *AdminHandler) renderImport(w http.ResponseWriter, r *http.Request, doc string, plan *service.ImportPlan, errMsg string) {
+	if err := h.tmpl.ExecuteTemplate(w, "jsonresume_import", map[string]interface{}{
+		"Document":  doc,
+		"Plan":      plan,
+		"ExportURL": "/api/v1/export/jsonresume",
+		"Error":     errMsg,
+		"Success":   r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render import page", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== Publication Import ====================
+
+// maxPublicationImport bounds the pasted BibTeX or RIS text
+const maxPublicationImport = 1024 * 1024
+
+// PublicationImportForm renders the page that imports publications from BibTeX, RIS or DOIs
+func (h *AdminHandler) PublicationImportForm(w http.ResponseWriter, r *http.Request) {
+	h.renderPublicationImport(w, r, nil, "")
+}
+
+// PublicationImportPreview shows which publications an import would create and which it would skip
+func (h *AdminHandler) PublicationImportPreview(w http.ResponseWriter, r *http.Request) {
+	pubs, err := h.readPublicationImport(w, r)
+	if err != nil {
+		h.renderPublicationImport(w, r, nil, errorMessage(err))
+		return
+	}
+
+	plan, err := h.pubImport.PreviewPublications(r.Context(), pubs)
+	if err != nil {
+		h.log.Error("Failed to preview publication import", zap.Error(err))
+		h.renderPublicationImport(w, r, nil, errorMessage(err))
+		return
+	}
+
+	h.renderPublicationImport(w, r, plan, "")
+}
+
+// PublicationImportApply creates the new publications of a previewed import in one transaction
+func (h *AdminHandler) PublicationImportApply(w http.ResponseWriter, r *http.Request) {
+	pubs, err := h.readPublicationImport(w, r)
+	if err != nil {
+		h.renderPublicationImport(w, r, nil, errorMessage(err))
+		return
+	}
+
+	plan, err := h.pubImport.ImportPublications(r.Context(), pubs)
+	if err != nil {
+		h.log.Error("Failed to import publications", zap.Error(err))
+		h.renderPublicationImport(w, r, plan, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/publications?success=imported", http.StatusSeeOther)
+}
+
+// readPublicationImport reads the publications of the pasted text and the DOIs of the form.
+// The preview carries both fields to the apply step, which reads them again.
+func (h *AdminHandler) readPublicationImport(w http.ResponseWriter, r *http.Request) ([]model.Publication, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPublicationImport)
+	if err := r.ParseForm(); err != nil {
+		h.log.Warn("Failed to parse publication import form", zap.Error(err))
+		return nil, errors.New("The pasted text is too large, import it in parts")
+	}
+
+	pubs, err := h.pubImport.ReadPublications(r.Context(), r.FormValue("document"), r.FormValue("dois"))
+	if err != nil && service.ErrorCode(err) == service.CodeInternal {
+		h.log.Error("Failed to read publications", zap.Error(err))
+		return nil, errors.New("The DOI lookup failed, try again later or paste BibTeX instead")
+	}
+	return pubs, err
+}
+
+func (h *AdminHandler) renderPublicationImport(w http.ResponseWriter, r *http.Request, plan *service.ImportPlan, errMsg string) {
+	if err := h.tmpl.ExecuteTemplate(w, "publication_import", map[string]interface{}{
+		"Document": r.FormValue("document"),
+		"DOIs":     r.FormValue("dois"),
+		"Plan":     plan,
+		"Error":    errMsg,
+	}); err != nil {
+		h.log.Error("Failed to render publication import page", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== Backup ====================
+
+// BackupPage renders the backup and restore page
+func (h *AdminHandler) BackupPage(w http.ResponseWriter, r *http.Request) {
+	h.renderBackup(w, r, nil, "")
+}
+
+// BackupDownload sends an archive of all content. It is built in a temporary
+// file first, so a failure can still be reported instead of a truncated download.
+func (h *AdminHandler) BackupDownload(w http.ResponseWriter, r *http.Request) {
+	tmp, err := os.CreateTemp("", "portfolio-backup-*.zip")
+	if err != nil {
+		h.log.Error("Failed to create backup file", zap.Error(err))
+		h.renderBackup(w, r, nil, "Failed to create backup")
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	manifest, err := h.backupService.CreateBackup(r.Context(), tmp)
+	if err != nil {
+		h.log.Error("Failed to create backup", zap.Error(err))
+		h.renderBackup(w, r, nil, errorMessage(err))
+		return
+	}
+	if len(manifest.Missing) > 0 {
+		h.log.Warn("Backup is missing referenced uploads", zap.Strings("keys", manifest.Missing))
+	}
+
+	filename := "portfolio-backup-" + manifest.CreatedAt.Format("20060102-150405") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	http.ServeContent(w, r, filename, manifest.CreatedAt, tmp)
+}
+
+// BackupRestore replaces all content with an uploaded archive
+func (h *AdminHandler) BackupRestore(w http.ResponseWriter, r *http.Request) {
+	if err := h.parseUploadForm(w, r, backup.MaxArchiveSize+1024*1024, errBackupTooLarge); err != nil {
+		h.renderBackup(w, r, nil, sentence(err))
+		return
+	}
+
+	if r.FormValue("confirm") != "on" {
+		h.renderBackup(w, r, nil, "Please confirm that the restore replaces all content")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.renderBackup(w, r, nil, "Please choose a backup archive to restore")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.backupService.RestoreBackup(r.Context(), file, header.Size)
+	if err != nil {
+		h.log.Error("Failed to restore backup", zap.Error(err))
+		h.renderBackup(w, r, nil, errorMessage(err))
+		return
+	}
+
+	h.renderBackup(w, r, result, "")
+}
+
+func (h *AdminHandler) renderBackup(w http.ResponseWriter, r *http.Request, result *service.RestoreResult, errMsg string) {
+	if err := h.tmpl.ExecuteTemplate(w, "backup", map[string]interface{}{
+		"Result":  result,
+		"Error":   errMsg,
+		"Success": r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render backup page", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== SEO ====================
+
+// SEOEdit renders the SEO settings page
+func (h *AdminHandler) SEOEdit(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.portfolioService.GetSEOSettings(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get SEO settings", zap.Error(err))
+		settings = &model.SEOSettings{}
+	}
+	h.renderSEO(w, r, settings, "")
+}
+
+// SEOSave handles the SEO settings update
+func (h *AdminHandler) SEOSave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &dto.SEORequest{
+		SiteURL:       r.FormValue("site_url"),
+		Title:         r.FormValue("title"),
+		Description:   r.FormValue("description"),
+		ImageURL:      r.FormValue("image_url"),
+		TwitterHandle: r.FormValue("twitter_handle"),
+		Robots:        r.FormValue("robots"),
+	}
+
+	media, err := h.pickedMedia(r)
+	if err != nil {
+		h.renderSEO(w, r, req, errorMessage(err))
+		return
+	}
+	if media != nil {
+		req.ImageURL = media.URL
+	}
+
+	if _, err := h.portfolioService.UpdateSEOSettings(ctx, req); err != nil {
+		h.renderSEO(w, r, req, errorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/seo?success=saved", http.StatusSeeOther)
+}
+
+// renderSEO renders the SEO page with the tags generated from the profile as placeholders
+func (h *AdminHandler) renderSEO(w http.ResponseWriter, r *http.Request, settings any, errMsg string) {
+	ctx := r.Context()
+	data := &model.PortfolioData{}
+	if profile, err := h.portfolioService.GetProfile(ctx); err == nil && profile != nil {
+		data.Profile = *profile
+	}
+
+	if err := h.tmpl.ExecuteTemplate(w, "seo_form", map[string]interface{}{
+		"Settings": settings,
+		"Defaults": seo.Home(data, seo.BaseURL(r, "")),
+		"Media":    h.mediaLibrary(ctx),
+		"Error":    errMsg,
+		"Success":  r.URL.Query().Get("success"),
+	}); err != nil {
+		h.log.Error("Failed to render SEO page", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ==================== Markdown ====================
+
+// MarkdownPreview renders the posted "text" field the way the public pages will,
+// for the live preview next to description fields
+func (h *AdminHandler) MarkdownPreview(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, markdown.MaxPreviewSize)
+	if err := r.ParseForm(); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "Text too long to preview", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	io.WriteString(w, string(markdown.HTML(r.PostFormValue("text"))))
+}
+
+// errInvalidForm is shown when an upload form cannot be read for a reason other than its size
+var errInvalidForm = errors.New("the form could not be read, please try again")
+
+// errBackupTooLarge is shown when a backup archive exceeds the request limit
+var errBackupTooLarge = errors.New("backup archive is too large (max 1GB)")
+
+// parseUploadForm bounds the request to limit, so an oversized upload is cut off while
+// streaming, and parses the multipart form. It returns tooLarge when the limit is hit and
+// errInvalidForm when the form is otherwise unreadable; show either with sentence.
+// A form posted without multipart encoding still has its fields parsed.
+func (h *AdminHandler) parseUploadForm(w http.ResponseWriter, r *http.Request, limit int64, tooLarge error) error {
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+	err := r.ParseMultipartForm(10 << 20)
+	if err == nil || errors.Is(err, http.ErrNotMultipart) {
+		return nil
+	}
+	h.log.Error("Failed to parse form", zap.Error(err))
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return tooLarge
+	}
+	return errInvalidForm
+}
+
+// mediaLibrary loads the media library for the list and the form pickers.
+// Without it the forms still work with plain uploads, so a failure is only logged.
+func (h *AdminHandler) mediaLibrary(ctx context.Context) []model.Media {
+	media, err := h.mediaService.GetAllMedia(ctx)
+	if err != nil {
+		h.log.Error("Failed to get media", zap.Error(err))
+	}
+	return media
+}
+
+// pickedMedia returns the media library item chosen in a form's picker, nil when none was chosen
+func (h *AdminHandler) pickedMedia(r *http.Request) (*model.Media, error) {
+	idStr := r.FormValue("media_id")
+	if idStr == "" {
+		return nil, nil
+	}
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	return h.mediaService.GetMediaByID(r.Context(), id)
+}
+
+// imageVariants generates the responsive copies of a freshly uploaded image.
+// A failure only costs the responsive markup, so it is logged rather than shown.
+func (h *AdminHandler) imageVariants(ctx context.Context, imageURL string) model.ImageVariants {
+	variants, err := utils.GenerateImageVariants(ctx, h.storage, imageURL)
+	if err != nil {
+		h.log.Warn("Failed to generate image variants", zap.String("image", imageURL), zap.Error(err))
+	}
+	return variants
+}
+
+// deleteImage removes an uploaded image and its variants from storage. It runs once no
+// record points at the files any more, so a failure only leaves an orphan and is logged.
+func (h *AdminHandler) deleteImage(ctx context.Context, imageURL string, variants model.ImageVariants) {
+	if imageURL == "" {
+		return
+	}
+	urls := []string{imageURL}
+	for _, variant := range variants {
+		urls = append(urls, variant.URL)
+	}
+	if err := utils.DeleteUploads(ctx, h.storage, urls...); err != nil {
+		h.log.Warn("Failed to delete uploaded image", zap.String("image", imageURL), zap.Error(err))
+	}
+}
+
 // Helper function to get template FuncMap for admin templates
 func GetAdminTemplateFuncs() template.FuncMap {
 	return template.FuncMap{
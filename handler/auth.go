@@ -56,7 +56,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.log.Warn("Login failed", zap.String("email", req.Email), zap.Error(err))
 		h.tmpl.ExecuteTemplate(w, "login", map[string]interface{}{
-			"Error": err.Error(),
+			"Error": errorMessage(err),
 			"Email": req.Email,
 		})
 		return
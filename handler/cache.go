@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+	"session-19/service"
+	"session-19/utils"
+
+	"go.uber.org/zap"
+)
+
+// CacheHandler exposes cache counters for monitoring
+type CacheHandler struct {
+	service service.PortfolioServiceInterface
+	log     *zap.Logger
+}
+
+// NewCacheHandler creates a new cache handler
+func NewCacheHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *CacheHandler {
+	return &CacheHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// GetCacheStats returns the portfolio cache hit/miss counters
+func (h *CacheHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.service.(service.CacheStatsProvider)
+	if !ok {
+		writeError(w, r, h.log, "Portfolio cache is disabled", &service.NotFoundError{Resource: "portfolio cache"})
+		return
+	}
+	utils.ResponseSuccess(w, http.StatusOK, "Cache stats retrieved successfully", provider.CacheStats())
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"session-19/dto"
+	"session-19/service"
+	"session-19/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// CertificationHandler handles HTTP requests for certifications
+type CertificationHandler struct {
+	service service.PortfolioServiceInterface
+	log     *zap.Logger
+}
+
+// NewCertificationHandler creates a new certification handler
+func NewCertificationHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *CertificationHandler {
+	return &CertificationHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// GetAllCertifications returns all certifications
+func (h *CertificationHandler) GetAllCertifications(w http.ResponseWriter, r *http.Request) {
+	certifications, err := h.service.GetAllCertifications(r.Context())
+	if err != nil {
+		writeError(w, r, h.log, "Failed to get certifications", err)
+		return
+	}
+	utils.ResponseSuccess(w, http.StatusOK, "Certifications retrieved successfully", certifications)
+}
+
+// GetCertificationByID returns a certification by ID
+func (h *CertificationHandler) GetCertificationByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid certification ID", invalidID())
+		return
+	}
+
+	cert, err := h.service.GetCertificationByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to get certification", err)
+		return
+	}
+	utils.ResponseSuccess(w, http.StatusOK, "Certification retrieved successfully", cert)
+}
+
+// CreateCertification creates a new certification
+func (h *CertificationHandler) CreateCertification(w http.ResponseWriter, r *http.Request) {
+	var req dto.CertificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
+		return
+	}
+
+	cert, err := h.service.CreateCertification(r.Context(), &req)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to create certification", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusCreated, "Certification created successfully", cert)
+}
+
+// UpdateCertification updates a certification
+func (h *CertificationHandler) UpdateCertification(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid certification ID", invalidID())
+		return
+	}
+
+	var req dto.CertificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
+		return
+	}
+
+	cert, err := h.service.UpdateCertification(r.Context(), id, &req)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to update certification", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusOK, "Certification updated successfully", cert)
+}
+
+// DeleteCertification deletes a certification
+func (h *CertificationHandler) DeleteCertification(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid certification ID", invalidID())
+		return
+	}
+
+	if err := h.service.DeleteCertification(r.Context(), id); err != nil {
+		writeError(w, r, h.log, "Failed to delete certification", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusOK, "Certification deleted successfully", nil)
+}
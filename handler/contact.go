@@ -28,14 +28,12 @@ func NewContactHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *
 func (h *ContactHandler) SubmitContact(w http.ResponseWriter, r *http.Request) {
 	var req dto.ContactRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	if err := h.service.SubmitContact(r.Context(), &req); err != nil {
-		h.log.Error("Failed to submit contact", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to submit contact", err.Error())
+		writeError(w, r, h.log, "Failed to submit contact", err)
 		return
 	}
 
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"session-19/model"
+	"session-19/service"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// CVHandler serves the active CV to visitors
+type CVHandler struct {
+	service service.CVServiceInterface
+	log     *zap.Logger
+}
+
+// NewCVHandler creates a new CV handler
+func NewCVHandler(svc service.CVServiceInterface, log *zap.Logger) *CVHandler {
+	return &CVHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// Download serves the active CV and counts the download
+func (h *CVHandler) Download(w http.ResponseWriter, r *http.Request) {
+	cv, rc, err := h.service.DownloadActiveCV(r.Context())
+	if err != nil {
+		h.log.Warn("Failed to open active CV", zap.Error(err))
+		http.Error(w, errorMessage(err), errorStatus[errorCode(err)])
+		return
+	}
+	defer rc.Close()
+
+	serveCV(w, cv, rc, h.log)
+}
+
+// serveCV streams a CV as a PDF attachment named after the uploaded file
+func serveCV(w http.ResponseWriter, cv *model.CVVersion, rc io.Reader, log *zap.Logger) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": cv.Filename}))
+	w.Header().Set("Content-Length", strconv.FormatInt(cv.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// The active version changes behind the same URL
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if _, err := io.Copy(w, rc); err != nil {
+		log.Warn("Failed to stream CV", zap.Int64("id", cv.ID), zap.Error(err))
+	}
+}
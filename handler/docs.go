@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"session-19/openapi"
+
+	"go.uber.org/zap"
+)
+
+// DocsHandler serves the OpenAPI document and the API docs UI
+type DocsHandler struct {
+	log  *zap.Logger
+	tmpl *template.Template
+	spec []byte
+}
+
+// NewDocsHandler creates a new docs handler
+func NewDocsHandler(log *zap.Logger, tmpl *template.Template) *DocsHandler {
+	spec, err := json.MarshalIndent(openapi.Spec(), "", "  ")
+	if err != nil {
+		// The document is built from static Go types, so this is a programming error
+		log.Fatal("Failed to encode OpenAPI document", zap.Error(err))
+	}
+
+	return &DocsHandler{
+		log:  log,
+		tmpl: tmpl,
+		spec: spec,
+	}
+}
+
+// OpenAPISpec returns the OpenAPI document for /api/v1
+func (h *DocsHandler) OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", openapi.ContentType)
+	w.Write(h.spec)
+}
+
+// DocsUI renders the interactive API documentation
+func (h *DocsHandler) DocsUI(w http.ResponseWriter, r *http.Request) {
+	if err := h.tmpl.ExecuteTemplate(w, "api_docs", map[string]interface{}{
+		"SpecURL": "/api/v1/openapi.json",
+	}); err != nil {
+		h.log.Error("Failed to render API docs", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"session-19/dto"
+	"session-19/service"
+	"session-19/utils"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// EducationHandler handles HTTP requests for education
+type EducationHandler struct {
+	service service.PortfolioServiceInterface
+	log     *zap.Logger
+}
+
+// NewEducationHandler creates a new education handler
+func NewEducationHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *EducationHandler {
+	return &EducationHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// GetAllEducation returns all education
+func (h *EducationHandler) GetAllEducation(w http.ResponseWriter, r *http.Request) {
+	education, err := h.service.GetAllEducation(r.Context())
+	if err != nil {
+		writeError(w, r, h.log, "Failed to get education", err)
+		return
+	}
+	utils.ResponseSuccess(w, http.StatusOK, "Education retrieved successfully", education)
+}
+
+// GetEducationByID returns an education by ID
+func (h *EducationHandler) GetEducationByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid education ID", invalidID())
+		return
+	}
+
+	edu, err := h.service.GetEducationByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to get education", err)
+		return
+	}
+	utils.ResponseSuccess(w, http.StatusOK, "Education retrieved successfully", edu)
+}
+
+// CreateEducation creates a new education
+func (h *EducationHandler) CreateEducation(w http.ResponseWriter, r *http.Request) {
+	var req dto.EducationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
+		return
+	}
+
+	edu, err := h.service.CreateEducation(r.Context(), &req)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to create education", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusCreated, "Education created successfully", edu)
+}
+
+// UpdateEducation updates an education
+func (h *EducationHandler) UpdateEducation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid education ID", invalidID())
+		return
+	}
+
+	var req dto.EducationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
+		return
+	}
+
+	edu, err := h.service.UpdateEducation(r.Context(), id, &req)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to update education", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusOK, "Education updated successfully", edu)
+}
+
+// DeleteEducation deletes an education
+func (h *EducationHandler) DeleteEducation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid education ID", invalidID())
+		return
+	}
+
+	if err := h.service.DeleteEducation(r.Context(), id); err != nil {
+		writeError(w, r, h.log, "Failed to delete education", err)
+		return
+	}
+
+	utils.ResponseSuccess(w, http.StatusOK, "Education deleted successfully", nil)
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"session-19/service"
+	"session-19/utils"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// errorStatus maps error codes to HTTP statuses
+var errorStatus = map[string]int{
+	service.CodeValidation:    http.StatusBadRequest,
+	service.CodeNotFound:      http.StatusNotFound,
+	service.CodeConflict:      http.StatusConflict,
+	service.CodeUnauthorized:  http.StatusUnauthorized,
+	service.CodeInternal:      http.StatusInternalServerError,
+	service.CodeMalformedBody: http.StatusBadRequest,
+}
+
+// errorTitle is the problem details title of each error code
+var errorTitle = map[string]string{
+	service.CodeValidation:    "Validation failed",
+	service.CodeNotFound:      "Resource not found",
+	service.CodeConflict:      "Conflict with existing data",
+	service.CodeUnauthorized:  "Unauthorized",
+	service.CodeInternal:      "Internal server error",
+	service.CodeMalformedBody: "Malformed request body",
+}
+
+// malformedBodyError wraps a JSON decoding failure
+type malformedBodyError struct {
+	err error
+}
+
+func (e *malformedBodyError) Error() string { return "request body is not valid JSON" }
+func (e *malformedBodyError) Unwrap() error { return e.err }
+
+// malformedBody reports a request body that could not be decoded
+func malformedBody(err error) error {
+	return &malformedBodyError{err: err}
+}
+
+// invalidID reports an {id} path parameter that is not a positive integer
+func invalidID() error {
+	return service.ValidateID(0)
+}
+
+// errorCode returns the stable code for err
+func errorCode(err error) string {
+	var bodyErr *malformedBodyError
+	if errors.As(err, &bodyErr) {
+		return service.CodeMalformedBody
+	}
+	return service.ErrorCode(err)
+}
+
+// errorMessage returns a message for err that is safe to show to clients.
+// Internal errors may carry driver or SQL details, so they are replaced.
+func errorMessage(err error) string {
+	if errorCode(err) == service.CodeInternal {
+		return "an unexpected error occurred"
+	}
+	return err.Error()
+}
+
+// sentence capitalizes an error message for display on a page
+func sentence(err error) string {
+	msg := err.Error()
+	if msg == "" {
+		return msg
+	}
+	r, size := utf8.DecodeRuneInString(msg)
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
+// writeError is the single place API errors are turned into responses. It maps err
+// to its status and code, logs it, and writes either problem details (when the client
+// accepts application/problem+json) or the usual JSON envelope.
+func writeError(w http.ResponseWriter, r *http.Request, log *zap.Logger, message string, err error) {
+	code := errorCode(err)
+	status := errorStatus[code]
+
+	if status >= http.StatusInternalServerError {
+		log.Error(message, zap.Error(err), zap.String("path", r.URL.Path))
+	} else {
+		log.Warn(message, zap.Error(err), zap.String("code", code), zap.String("path", r.URL.Path))
+	}
+
+	detail := errorMessage(err)
+	fields := service.FieldErrors(err)
+
+	if utils.WantsProblem(r) {
+		problem := utils.Problem{
+			Type:     utils.ProblemTypePrefix + code,
+			Title:    errorTitle[code],
+			Status:   status,
+			Detail:   message + ": " + detail,
+			Instance: r.URL.Path,
+			Code:     code,
+		}
+		if fields != nil {
+			problem.Errors = fields
+		}
+		utils.ResponseProblem(w, problem)
+		return
+	}
+
+	var details any = detail
+	if fields != nil {
+		details = fields
+	}
+	utils.ResponseError(w, status, message, code, details)
+}
@@ -30,8 +30,7 @@ func NewExperienceHandler(svc service.PortfolioServiceInterface, log *zap.Logger
 func (h *ExperienceHandler) GetAllExperiences(w http.ResponseWriter, r *http.Request) {
 	experiences, err := h.service.GetAllExperiences(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get experiences", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusInternalServerError, "Failed to get experiences", err.Error())
+		writeError(w, r, h.log, "Failed to get experiences", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Experiences retrieved successfully", experiences)
@@ -42,14 +41,13 @@ func (h *ExperienceHandler) GetExperienceByID(w http.ResponseWriter, r *http.Req
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid experience ID", err.Error())
+		writeError(w, r, h.log, "Invalid experience ID", invalidID())
 		return
 	}
 
 	exp, err := h.service.GetExperienceByID(r.Context(), id)
 	if err != nil {
-		h.log.Error("Failed to get experience", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusNotFound, "Experience not found", err.Error())
+		writeError(w, r, h.log, "Failed to get experience", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Experience retrieved successfully", exp)
@@ -59,15 +57,13 @@ func (h *ExperienceHandler) GetExperienceByID(w http.ResponseWriter, r *http.Req
 func (h *ExperienceHandler) CreateExperience(w http.ResponseWriter, r *http.Request) {
 	var req dto.ExperienceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	exp, err := h.service.CreateExperience(r.Context(), &req)
 	if err != nil {
-		h.log.Error("Failed to create experience", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to create experience", err.Error())
+		writeError(w, r, h.log, "Failed to create experience", err)
 		return
 	}
 
@@ -79,21 +75,19 @@ func (h *ExperienceHandler) UpdateExperience(w http.ResponseWriter, r *http.Requ
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid experience ID", err.Error())
+		writeError(w, r, h.log, "Invalid experience ID", invalidID())
 		return
 	}
 
 	var req dto.ExperienceRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	exp, err := h.service.UpdateExperience(r.Context(), id, &req)
 	if err != nil {
-		h.log.Error("Failed to update experience", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to update experience", err.Error())
+		writeError(w, r, h.log, "Failed to update experience", err)
 		return
 	}
 
@@ -105,13 +99,12 @@ func (h *ExperienceHandler) DeleteExperience(w http.ResponseWriter, r *http.Requ
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid experience ID", err.Error())
+		writeError(w, r, h.log, "Invalid experience ID", invalidID())
 		return
 	}
 
 	if err := h.service.DeleteExperience(r.Context(), id); err != nil {
-		h.log.Error("Failed to delete experience", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to delete experience", err.Error())
+		writeError(w, r, h.log, "Failed to delete experience", err)
 		return
 	}
 
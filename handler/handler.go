@@ -3,34 +3,47 @@ package handler
 import (
 	"html/template"
 	"session-19/service"
+	"session-19/storage"
 
 	"go.uber.org/zap"
 )
 
 // Handler contains all handlers for the application
 type Handler struct {
-	PortfolioHandler   *PortfolioHandler
-	ProfileHandler     *ProfileHandler
-	ExperienceHandler  *ExperienceHandler
-	SkillHandler       *SkillHandler
-	ProjectHandler     *ProjectHandler
-	PublicationHandler *PublicationHandler
-	ContactHandler     *ContactHandler
-	AuthHandler        *AuthHandler
-	AdminHandler       *AdminHandler
+	PortfolioHandler     *PortfolioHandler
+	ProfileHandler       *ProfileHandler
+	ExperienceHandler    *ExperienceHandler
+	SkillHandler         *SkillHandler
+	ProjectHandler       *ProjectHandler
+	PublicationHandler   *PublicationHandler
+	EducationHandler     *EducationHandler
+	CertificationHandler *CertificationHandler
+	ContactHandler       *ContactHandler
+	CVHandler            *CVHandler
+	JSONResumeHandler    *JSONResumeHandler
+	AuthHandler          *AuthHandler
+	AdminHandler         *AdminHandler
+	CacheHandler         *CacheHandler
+	DocsHandler          *DocsHandler
 }
 
 // NewHandler creates a new handler with all sub-handlers
-func NewHandler(svc service.Service, log *zap.Logger, tmpl *template.Template) Handler {
+func NewHandler(svc service.Service, store storage.Storage, log *zap.Logger, tmpl *template.Template) Handler {
 	return Handler{
-		PortfolioHandler:   NewPortfolioHandler(svc.PortfolioService, log),
-		ProfileHandler:     NewProfileHandler(svc.PortfolioService, log),
-		ExperienceHandler:  NewExperienceHandler(svc.PortfolioService, log),
-		SkillHandler:       NewSkillHandler(svc.PortfolioService, log),
-		ProjectHandler:     NewProjectHandler(svc.PortfolioService, log),
-		PublicationHandler: NewPublicationHandler(svc.PortfolioService, log),
-		ContactHandler:     NewContactHandler(svc.PortfolioService, log),
-		AuthHandler:        NewAuthHandler(svc.AuthService, log, tmpl),
-		AdminHandler:       NewAdminHandler(svc.PortfolioService, log, tmpl),
+		PortfolioHandler:     NewPortfolioHandler(svc.PortfolioService, log),
+		ProfileHandler:       NewProfileHandler(svc.PortfolioService, log),
+		ExperienceHandler:    NewExperienceHandler(svc.PortfolioService, log),
+		SkillHandler:         NewSkillHandler(svc.PortfolioService, log),
+		ProjectHandler:       NewProjectHandler(svc.PortfolioService, log),
+		PublicationHandler:   NewPublicationHandler(svc.PortfolioService, log),
+		EducationHandler:     NewEducationHandler(svc.PortfolioService, log),
+		CertificationHandler: NewCertificationHandler(svc.PortfolioService, log),
+		ContactHandler:       NewContactHandler(svc.PortfolioService, log),
+		CVHandler:            NewCVHandler(svc.CVService, log),
+		JSONResumeHandler:    NewJSONResumeHandler(svc.JSONResumeService, log),
+		AuthHandler:          NewAuthHandler(svc.AuthService, log, tmpl),
+		AdminHandler:         NewAdminHandler(svc.PortfolioService, svc.MediaService, svc.CVService, svc.JSONResumeService, svc.BackupService, svc.PublicationImportService, store, log, tmpl),
+		CacheHandler:         NewCacheHandler(svc.PortfolioService, log),
+		DocsHandler:          NewDocsHandler(log, tmpl),
 	}
 }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"session-19/service"
+
+	"go.uber.org/zap"
+)
+
+// JSONResumeHandler exports the portfolio as a JSON Resume document
+type JSONResumeHandler struct {
+	service service.JSONResumeServiceInterface
+	log     *zap.Logger
+}
+
+// NewJSONResumeHandler creates a new JSON Resume handler
+func NewJSONResumeHandler(svc service.JSONResumeServiceInterface, log *zap.Logger) *JSONResumeHandler {
+	return &JSONResumeHandler{
+		service: svc,
+		log:     log,
+	}
+}
+
+// Export returns the portfolio as a JSON Resume document. The document is not
+// wrapped in the response envelope so it can be used by JSON Resume tools as is.
+func (h *JSONResumeHandler) Export(w http.ResponseWriter, r *http.Request) {
+	resume, err := h.service.ExportJSONResume(r.Context())
+	if err != nil {
+		writeError(w, r, h.log, "Failed to export JSON Resume", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(resume); err != nil {
+		h.log.Warn("Failed to write JSON Resume", zap.Error(err))
+	}
+}
@@ -1,13 +1,25 @@
 package handler
 
 import (
+	"bytes"
 	"html/template"
+	"io"
+	"mime"
 	"net/http"
+	"regexp"
+	"session-19/citation"
+	"session-19/feed"
+	"session-19/markdown"
+	"session-19/model"
+	"session-19/resume"
+	"session-19/seo"
 	"session-19/service"
 	"session-19/utils"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +28,9 @@ type PortfolioHandler struct {
 	service service.PortfolioServiceInterface
 	log     *zap.Logger
 	tmpl    *template.Template
+	// version changes with every start, so a deploy that changes the templates,
+	// styles or résumé layout also changes the ETags of unchanged data
+	version string
 }
 
 // NewPortfolioHandler creates a new portfolio handler
@@ -44,6 +59,13 @@ func NewPortfolioHandler(svc service.PortfolioServiceInterface, log *zap.Logger)
 		"mod": func(a, b int) int {
 			return a % b
 		},
+		// markdown renders a description or content field to sanitized HTML
+		"markdown": markdown.HTML,
+		// cite formats a publication reference, e.g. {{cite "apa" .}}
+		"cite": func(format string, pub model.Publication) string {
+			out, _ := citation.Cite(format, pub)
+			return strings.TrimSpace(out)
+		},
 		"colorClass": func(color string) string {
 			colors := map[string]string{
 				"cyan":   "cyan-400",
@@ -92,6 +114,7 @@ func NewPortfolioHandler(svc service.PortfolioServiceInterface, log *zap.Logger)
 		service: svc,
 		log:     log,
 		tmpl:    tmpl,
+		version: strconv.FormatInt(time.Now().UnixNano(), 36),
 	}
 }
 
@@ -104,20 +127,328 @@ func (h *PortfolioHandler) RenderPortfolio(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if h.notModified(w, r, "html", data) {
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := h.tmpl.ExecuteTemplate(w, "index.html", data); err != nil {
+	page := indexPage{PortfolioData: data, Meta: withFeeds(seo.Home(data, seo.BaseURL(r, data.SEO.SiteURL)))}
+	if err := h.tmpl.ExecuteTemplate(w, "index.html", page); err != nil {
 		h.log.Error("Failed to render template", zap.Error(err))
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 	}
 }
 
+// indexPage is the data of the portfolio page
+type indexPage struct {
+	*model.PortfolioData
+	Meta seo.Page
+}
+
+// projectPage is the data of a project detail page
+type projectPage struct {
+	Profile model.Profile
+	Project model.Project
+	Meta    seo.Page
+}
+
+// publicationPage is the data of a publication detail page
+type publicationPage struct {
+	Profile     model.Profile
+	Publication model.Publication
+	Meta        seo.Page
+}
+
+func newProjectPage(r *http.Request, data *model.PortfolioData, project model.Project) projectPage {
+	meta := seo.Project(data, project, seo.BaseURL(r, data.SEO.SiteURL))
+	return projectPage{Profile: data.Profile, Project: project, Meta: withFeeds(meta)}
+}
+
+func newPublicationPage(r *http.Request, data *model.PortfolioData, pub model.Publication) publicationPage {
+	meta := seo.Publication(data, pub, seo.BaseURL(r, data.SEO.SiteURL))
+	return publicationPage{Profile: data.Profile, Publication: pub, Meta: withFeeds(meta)}
+}
+
+// withFeeds links the feeds from a page's head
+func withFeeds(meta seo.Page) seo.Page {
+	meta.Alternates = feed.Alternates(meta.SiteName)
+	return meta
+}
+
+// RenderProject renders the detail page of the project named by {slug}.
+// A slug the project had before it was renamed redirects to the current one.
+func (h *PortfolioHandler) RenderProject(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	for _, p := range data.Projects {
+		if p.Slug == slug {
+			if h.notModified(w, r, "project-"+slug, data) {
+				return
+			}
+			h.renderPage(w, "project.html", newProjectPage(r, data, p))
+			return
+		}
+	}
+
+	// Not among the loaded projects: an old slug, or a project the cached data predates
+	project, err := h.service.GetProjectBySlug(r.Context(), slug)
+	if err != nil {
+		h.slugError(w, r, err, slug)
+		return
+	}
+	if project.Slug != slug {
+		http.Redirect(w, r, project.Path(), http.StatusMovedPermanently)
+		return
+	}
+	h.renderPage(w, "project.html", newProjectPage(r, data, *project))
+}
+
+// RenderPublication renders the detail page of the publication named by {slug}.
+// A slug the publication had before it was renamed redirects to the current one.
+func (h *PortfolioHandler) RenderPublication(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	for _, p := range data.Publications {
+		if p.Slug == slug {
+			if h.notModified(w, r, "publication-"+slug, data) {
+				return
+			}
+			h.renderPage(w, "publication.html", newPublicationPage(r, data, p))
+			return
+		}
+	}
+
+	pub, err := h.service.GetPublicationBySlug(r.Context(), slug)
+	if err != nil {
+		h.slugError(w, r, err, slug)
+		return
+	}
+	if pub.Slug != slug {
+		http.Redirect(w, r, pub.Path(), http.StatusMovedPermanently)
+		return
+	}
+	h.renderPage(w, "publication.html", newPublicationPage(r, data, *pub))
+}
+
+// slugError answers a failed lookup of a detail page by slug
+func (h *PortfolioHandler) slugError(w http.ResponseWriter, r *http.Request, err error, slug string) {
+	if service.ErrorCode(err) == service.CodeNotFound {
+		http.NotFound(w, r)
+		return
+	}
+	h.log.Error("Failed to look up page by slug", zap.Error(err), zap.String("slug", slug))
+	http.Error(w, "Failed to load page", http.StatusInternalServerError)
+}
+
+// renderPage executes a public page template
+func (h *PortfolioHandler) renderPage(w http.ResponseWriter, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.ExecuteTemplate(w, name, data); err != nil {
+		h.log.Error("Failed to render template", zap.Error(err), zap.String("template", name))
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
 // GetPortfolioData returns all portfolio data as JSON
 func (h *PortfolioHandler) GetPortfolioData(w http.ResponseWriter, r *http.Request) {
 	data, err := h.service.GetPortfolioData(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get portfolio data", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusInternalServerError, "Failed to get portfolio data", err.Error())
+		writeError(w, r, h.log, "Failed to get portfolio data", err)
+		return
+	}
+
+	if h.notModified(w, r, "json", data) {
+		return
+	}
+
+	if data.Partial() {
+		utils.ResponseSuccess(w, http.StatusOK, "Portfolio data partially retrieved", data)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Portfolio data retrieved successfully", data)
 }
+
+// RenderResume renders the portfolio as a PDF résumé in the layout named by ?layout=
+func (h *PortfolioHandler) RenderResume(w http.ResponseWriter, r *http.Request) {
+	layout := r.URL.Query().Get("layout")
+	if layout == "" {
+		layout = resume.Layouts[0]
+	}
+	if !resume.ValidLayout(layout) {
+		http.Error(w, "Unknown résumé layout", http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	// A résumé gets saved and forwarded, so one with sections silently missing is worse than none
+	if data.Partial() {
+		h.log.Warn("Résumé unavailable while portfolio sections fail", zap.Any("failures", data.Failures))
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "Résumé is temporarily unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	if h.notModified(w, r, "pdf-"+layout, data) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := resume.Render(&buf, data, layout); err != nil {
+		h.log.Error("Failed to render résumé", zap.Error(err))
+		http.Error(w, "Failed to render résumé", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": resumeFilename(data.Profile.Name)}))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	if _, err := buf.WriteTo(w); err != nil {
+		h.log.Warn("Failed to write résumé", zap.Error(err))
+	}
+}
+
+// RenderSitemap renders sitemap.xml listing the portfolio and detail pages
+func (h *PortfolioHandler) RenderSitemap(w http.ResponseWriter, r *http.Request) {
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	base := seo.BaseURL(r, data.SEO.SiteURL)
+	if h.notModified(w, r, "sitemap-"+base, data) {
+		return
+	}
+
+	sitemap, err := seo.Sitemap(data, base)
+	if err != nil {
+		h.log.Error("Failed to render sitemap", zap.Error(err))
+		http.Error(w, "Failed to render sitemap", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write(sitemap)
+}
+
+// RenderRobots renders robots.txt from the SEO settings
+func (h *PortfolioHandler) RenderRobots(w http.ResponseWriter, r *http.Request) {
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	base := seo.BaseURL(r, data.SEO.SiteURL)
+	if h.notModified(w, r, "robots-"+base, data) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, seo.Robots(data.SEO, base))
+}
+
+// RenderFeed renders the Atom or RSS feed served at the request path
+func (h *PortfolioHandler) RenderFeed(w http.ResponseWriter, r *http.Request) {
+	kind, contentType, ok := feed.Lookup(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	base := seo.BaseURL(r, data.SEO.SiteURL)
+	if h.notModified(w, r, "feed-"+r.URL.Path+"-"+base, data) {
+		return
+	}
+
+	render := feed.Atom
+	if contentType == feed.RSSType {
+		render = feed.RSS
+	}
+	out, err := render(data, kind, base)
+	if err != nil {
+		h.log.Error("Failed to render feed", zap.Error(err), zap.String("path", r.URL.Path))
+		http.Error(w, "Failed to render feed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Write(out)
+}
+
+// RenderBibliography serves the BibTeX entries of every publication as one .bib file
+func (h *PortfolioHandler) RenderBibliography(w http.ResponseWriter, r *http.Request) {
+	data, err := h.service.GetPortfolioData(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get portfolio data", zap.Error(err))
+		http.Error(w, "Failed to load portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	if h.notModified(w, r, "bib", data) {
+		return
+	}
+
+	bib, err := citation.Cite(citation.BibTeX, data.Publications...)
+	if err != nil {
+		h.log.Error("Failed to render bibliography", zap.Error(err))
+		http.Error(w, "Failed to render bibliography", http.StatusInternalServerError)
+		return
+	}
+	format, _ := citation.Lookup(citation.BibTeX)
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": "publications.bib"}))
+	io.WriteString(w, bib)
+}
+
+var nonFilenameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resumeFilename builds a download name such as "jane-doe-resume.pdf" from the profile name
+func resumeFilename(name string) string {
+	slug := strings.Trim(nonFilenameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		return "resume.pdf"
+	}
+	return slug + "-resume.pdf"
+}
+
+// notModified sets the cache validators for data and writes a 304 when the client copy is current
+func (h *PortfolioHandler) notModified(w http.ResponseWriter, r *http.Request, variant string, data *model.PortfolioData) bool {
+	// A partial page must not be reused once the failed sections recover
+	if data.Partial() {
+		w.Header().Set("Cache-Control", "no-store")
+		return false
+	}
+
+	etag, err := utils.ComputeETag(variant+"-"+h.version, data)
+	if err != nil {
+		// Serving without an ETag is still correct, just less cache friendly
+		h.log.Warn("Failed to compute ETag", zap.Error(err))
+	}
+	return utils.CheckNotModified(w, r, etag, h.service.LastModified())
+}
@@ -30,8 +30,7 @@ func NewProfileHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *
 func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	profile, err := h.service.GetProfile(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get profile", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusNotFound, "Profile not found", err.Error())
+		writeError(w, r, h.log, "Failed to get profile", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Profile retrieved successfully", profile)
@@ -41,15 +40,13 @@ func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 	var req dto.ProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	profile, err := h.service.CreateProfile(r.Context(), &req)
 	if err != nil {
-		h.log.Error("Failed to create profile", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to create profile", err.Error())
+		writeError(w, r, h.log, "Failed to create profile", err)
 		return
 	}
 
@@ -61,21 +58,19 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid profile ID", err.Error())
+		writeError(w, r, h.log, "Invalid profile ID", invalidID())
 		return
 	}
 
 	var req dto.ProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	profile, err := h.service.UpdateProfile(r.Context(), id, &req)
 	if err != nil {
-		h.log.Error("Failed to update profile", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to update profile", err.Error())
+		writeError(w, r, h.log, "Failed to update profile", err)
 		return
 	}
 
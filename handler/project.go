@@ -30,8 +30,7 @@ func NewProjectHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *
 func (h *ProjectHandler) GetAllProjects(w http.ResponseWriter, r *http.Request) {
 	projects, err := h.service.GetAllProjects(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get projects", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusInternalServerError, "Failed to get projects", err.Error())
+		writeError(w, r, h.log, "Failed to get projects", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Projects retrieved successfully", projects)
@@ -42,14 +41,13 @@ func (h *ProjectHandler) GetProjectByID(w http.ResponseWriter, r *http.Request)
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid project ID", err.Error())
+		writeError(w, r, h.log, "Invalid project ID", invalidID())
 		return
 	}
 
 	project, err := h.service.GetProjectByID(r.Context(), id)
 	if err != nil {
-		h.log.Error("Failed to get project", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusNotFound, "Project not found", err.Error())
+		writeError(w, r, h.log, "Failed to get project", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Project retrieved successfully", project)
@@ -59,15 +57,13 @@ func (h *ProjectHandler) GetProjectByID(w http.ResponseWriter, r *http.Request)
 func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	var req dto.ProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	project, err := h.service.CreateProject(r.Context(), &req)
 	if err != nil {
-		h.log.Error("Failed to create project", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to create project", err.Error())
+		writeError(w, r, h.log, "Failed to create project", err)
 		return
 	}
 
@@ -79,21 +75,19 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid project ID", err.Error())
+		writeError(w, r, h.log, "Invalid project ID", invalidID())
 		return
 	}
 
 	var req dto.ProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	project, err := h.service.UpdateProject(r.Context(), id, &req)
 	if err != nil {
-		h.log.Error("Failed to update project", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to update project", err.Error())
+		writeError(w, r, h.log, "Failed to update project", err)
 		return
 	}
 
@@ -105,13 +99,12 @@ func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid project ID", err.Error())
+		writeError(w, r, h.log, "Invalid project ID", invalidID())
 		return
 	}
 
 	if err := h.service.DeleteProject(r.Context(), id); err != nil {
-		h.log.Error("Failed to delete project", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to delete project", err.Error())
+		writeError(w, r, h.log, "Failed to delete project", err)
 		return
 	}
 
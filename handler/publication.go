@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
+	"session-19/citation"
 	"session-19/dto"
 	"session-19/service"
 	"session-19/utils"
@@ -30,8 +33,7 @@ func NewPublicationHandler(svc service.PortfolioServiceInterface, log *zap.Logge
 func (h *PublicationHandler) GetAllPublications(w http.ResponseWriter, r *http.Request) {
 	pubs, err := h.service.GetAllPublications(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get publications", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusInternalServerError, "Failed to get publications", err.Error())
+		writeError(w, r, h.log, "Failed to get publications", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Publications retrieved successfully", pubs)
@@ -42,14 +44,13 @@ func (h *PublicationHandler) GetPublicationByID(w http.ResponseWriter, r *http.R
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid publication ID", err.Error())
+		writeError(w, r, h.log, "Invalid publication ID", invalidID())
 		return
 	}
 
 	pub, err := h.service.GetPublicationByID(r.Context(), id)
 	if err != nil {
-		h.log.Error("Failed to get publication", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusNotFound, "Publication not found", err.Error())
+		writeError(w, r, h.log, "Failed to get publication", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Publication retrieved successfully", pub)
@@ -59,15 +60,13 @@ func (h *PublicationHandler) GetPublicationByID(w http.ResponseWriter, r *http.R
 func (h *PublicationHandler) CreatePublication(w http.ResponseWriter, r *http.Request) {
 	var req dto.PublicationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	pub, err := h.service.CreatePublication(r.Context(), &req)
 	if err != nil {
-		h.log.Error("Failed to create publication", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to create publication", err.Error())
+		writeError(w, r, h.log, "Failed to create publication", err)
 		return
 	}
 
@@ -79,21 +78,19 @@ func (h *PublicationHandler) UpdatePublication(w http.ResponseWriter, r *http.Re
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid publication ID", err.Error())
+		writeError(w, r, h.log, "Invalid publication ID", invalidID())
 		return
 	}
 
 	var req dto.PublicationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	pub, err := h.service.UpdatePublication(r.Context(), id, &req)
 	if err != nil {
-		h.log.Error("Failed to update publication", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to update publication", err.Error())
+		writeError(w, r, h.log, "Failed to update publication", err)
 		return
 	}
 
@@ -105,15 +102,53 @@ func (h *PublicationHandler) DeletePublication(w http.ResponseWriter, r *http.Re
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid publication ID", err.Error())
+		writeError(w, r, h.log, "Invalid publication ID", invalidID())
 		return
 	}
 
 	if err := h.service.DeletePublication(r.Context(), id); err != nil {
-		h.log.Error("Failed to delete publication", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to delete publication", err.Error())
+		writeError(w, r, h.log, "Failed to delete publication", err)
 		return
 	}
 
 	utils.ResponseSuccess(w, http.StatusOK, "Publication deleted successfully", nil)
 }
+
+// CitePublication returns a publication's citation in the format named by ?format=,
+// BibTeX by default. With ?download=1 it is sent as a file named after the slug.
+func (h *PublicationHandler) CitePublication(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		writeError(w, r, h.log, "Invalid publication ID", invalidID())
+		return
+	}
+
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = citation.BibTeX
+	}
+	if err := service.ValidateCitationFormat(name); err != nil {
+		writeError(w, r, h.log, "Invalid citation format", err)
+		return
+	}
+
+	pub, err := h.service.GetPublicationByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to get publication", err)
+		return
+	}
+
+	out, err := citation.Cite(name, *pub)
+	if err != nil {
+		writeError(w, r, h.log, "Failed to format citation", err)
+		return
+	}
+
+	format, _ := citation.Lookup(name)
+	w.Header().Set("Content-Type", format.ContentType)
+	if download, _ := strconv.ParseBool(r.URL.Query().Get("download")); download {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": citation.Filename(*pub, format)}))
+	}
+	io.WriteString(w, out)
+}
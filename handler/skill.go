@@ -30,8 +30,7 @@ func NewSkillHandler(svc service.PortfolioServiceInterface, log *zap.Logger) *Sk
 func (h *SkillHandler) GetAllSkills(w http.ResponseWriter, r *http.Request) {
 	skills, err := h.service.GetAllSkills(r.Context())
 	if err != nil {
-		h.log.Error("Failed to get skills", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusInternalServerError, "Failed to get skills", err.Error())
+		writeError(w, r, h.log, "Failed to get skills", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Skills retrieved successfully", skills)
@@ -42,14 +41,13 @@ func (h *SkillHandler) GetSkillByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid skill ID", err.Error())
+		writeError(w, r, h.log, "Invalid skill ID", invalidID())
 		return
 	}
 
 	skill, err := h.service.GetSkillByID(r.Context(), id)
 	if err != nil {
-		h.log.Error("Failed to get skill", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusNotFound, "Skill not found", err.Error())
+		writeError(w, r, h.log, "Failed to get skill", err)
 		return
 	}
 	utils.ResponseSuccess(w, http.StatusOK, "Skill retrieved successfully", skill)
@@ -59,15 +57,13 @@ func (h *SkillHandler) GetSkillByID(w http.ResponseWriter, r *http.Request) {
 func (h *SkillHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
 	var req dto.SkillRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	skill, err := h.service.CreateSkill(r.Context(), &req)
 	if err != nil {
-		h.log.Error("Failed to create skill", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to create skill", err.Error())
+		writeError(w, r, h.log, "Failed to create skill", err)
 		return
 	}
 
@@ -79,21 +75,19 @@ func (h *SkillHandler) UpdateSkill(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid skill ID", err.Error())
+		writeError(w, r, h.log, "Invalid skill ID", invalidID())
 		return
 	}
 
 	var req dto.SkillRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.Error("Failed to decode request", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		writeError(w, r, h.log, "Invalid request body", malformedBody(err))
 		return
 	}
 
 	skill, err := h.service.UpdateSkill(r.Context(), id, &req)
 	if err != nil {
-		h.log.Error("Failed to update skill", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to update skill", err.Error())
+		writeError(w, r, h.log, "Failed to update skill", err)
 		return
 	}
 
@@ -105,13 +99,12 @@ func (h *SkillHandler) DeleteSkill(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Invalid skill ID", err.Error())
+		writeError(w, r, h.log, "Invalid skill ID", invalidID())
 		return
 	}
 
 	if err := h.service.DeleteSkill(r.Context(), id); err != nil {
-		h.log.Error("Failed to delete skill", zap.Error(err))
-		utils.ResponseBadRequest(w, http.StatusBadRequest, "Failed to delete skill", err.Error())
+		writeError(w, r, h.log, "Failed to delete skill", err)
 		return
 	}
 
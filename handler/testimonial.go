@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// maxTestimonialFormSize bounds a testimonial submission, which is text only
+const maxTestimonialFormSize = 64 << 10
+
+// testimonialPage is the data of the page a colleague submits a testimonial through
+type testimonialPage struct {
+	Profile model.Profile
+	Request *dto.TestimonialRequest
+	Error   string
+	// Closed is set when the link is unknown, used or expired
+	Closed    bool
+	Submitted bool
+}
+
+// RenderTestimonialForm renders the testimonial form of the invite named by {token}
+func (h *PortfolioHandler) RenderTestimonialForm(w http.ResponseWriter, r *http.Request) {
+	page := testimonialPage{Request: &dto.TestimonialRequest{}}
+	if _, err := h.service.GetOpenTestimonialInvite(r.Context(), chi.URLParam(r, "token")); err != nil {
+		h.testimonialError(w, r, page, err)
+		return
+	}
+	h.renderTestimonialPage(w, r, http.StatusOK, page)
+}
+
+// SubmitTestimonialForm stores a testimonial submitted through the invite named by {token}.
+// It waits in the moderation queue until the owner approves it.
+func (h *PortfolioHandler) SubmitTestimonialForm(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTestimonialFormSize)
+	req := &dto.TestimonialRequest{
+		Author:       r.FormValue("author"),
+		Role:         r.FormValue("role"),
+		Company:      r.FormValue("company"),
+		Quote:        r.FormValue("quote"),
+		AvatarURL:    r.FormValue("avatar_url"),
+		Relationship: r.FormValue("relationship"),
+	}
+
+	page := testimonialPage{Request: req}
+	if _, err := h.service.SubmitTestimonial(r.Context(), chi.URLParam(r, "token"), req); err != nil {
+		h.testimonialError(w, r, page, err)
+		return
+	}
+	page.Submitted = true
+	h.renderTestimonialPage(w, r, http.StatusOK, page)
+}
+
+// testimonialError answers a failed lookup or submission of a testimonial
+func (h *PortfolioHandler) testimonialError(w http.ResponseWriter, r *http.Request, page testimonialPage, err error) {
+	page.Error = errorMessage(err)
+	switch {
+	case errors.Is(err, service.ErrInviteClosed):
+		page.Closed = true
+		h.renderTestimonialPage(w, r, http.StatusNotFound, page)
+	case service.ErrorCode(err) == service.CodeValidation:
+		h.renderTestimonialPage(w, r, http.StatusBadRequest, page)
+	default:
+		h.log.Error("Failed to handle testimonial submission", zap.Error(err))
+		h.renderTestimonialPage(w, r, http.StatusInternalServerError, page)
+	}
+}
+
+// renderTestimonialPage renders the submission page, which is kept out of search results
+func (h *PortfolioHandler) renderTestimonialPage(w http.ResponseWriter, r *http.Request, status int, page testimonialPage) {
+	if profile, err := h.service.GetProfile(r.Context()); err == nil {
+		page.Profile = *profile
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.WriteHeader(status)
+	if err := h.tmpl.ExecuteTemplate(w, "testimonial.html", page); err != nil {
+		h.log.Error("Failed to render template", zap.Error(err), zap.String("template", "testimonial.html"))
+	}
+}
@@ -0,0 +1,410 @@
+package jsonresume
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"session-19/model"
+)
+
+// SchemaURL identifies the schema version documents are exported against
+const SchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// MaxSize bounds an imported document
+const MaxSize = 1 << 20
+
+// Decoding errors
+var (
+	ErrTooLarge = errors.New("JSON Resume document is too large (max 1MB)")
+	ErrInvalid  = errors.New("not a valid JSON Resume document")
+)
+
+// Resume is a JSON Resume document (https://jsonresume.org/schema). Only the
+// sections the portfolio can hold are mapped.
+type Resume struct {
+	Schema       string        `json:"$schema,omitempty"`
+	Basics       Basics        `json:"basics"`
+	Work         []Work        `json:"work,omitempty"`
+	Volunteer    []Volunteer   `json:"volunteer,omitempty"`
+	Education    []Education   `json:"education,omitempty"`
+	Awards       []Award       `json:"awards,omitempty"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+	Skills       []Skill       `json:"skills,omitempty"`
+	Projects     []Project     `json:"projects,omitempty"`
+	Publications []Publication `json:"publications,omitempty"`
+}
+
+// Basics holds the person's details
+type Basics struct {
+	Name     string           `json:"name,omitempty"`
+	Label    string           `json:"label,omitempty"`
+	Image    string           `json:"image,omitempty"`
+	Email    string           `json:"email,omitempty"`
+	Summary  string           `json:"summary,omitempty"`
+	Profiles []NetworkProfile `json:"profiles,omitempty"`
+}
+
+// NetworkProfile is an account on a social network
+type NetworkProfile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// Work is a job
+type Work struct {
+	Name      string `json:"name,omitempty"`
+	Position  string `json:"position,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+// Volunteer is an unpaid role
+type Volunteer struct {
+	Organization string `json:"organization,omitempty"`
+	Position     string `json:"position,omitempty"`
+	StartDate    string `json:"startDate,omitempty"`
+	EndDate      string `json:"endDate,omitempty"`
+	Summary      string `json:"summary,omitempty"`
+}
+
+// Education is a course of study
+type Education struct {
+	Institution string `json:"institution,omitempty"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+	Score       string `json:"score,omitempty"`
+}
+
+// Certificate is a professional certification
+type Certificate struct {
+	Name   string `json:"name,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Award is a prize or recognition
+type Award struct {
+	Title   string `json:"title,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// Skill is a named group of keywords, which the portfolio stores as a category
+type Skill struct {
+	Name     string   `json:"name,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Project is a piece of work
+type Project struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+// Publication is a published work
+type Publication struct {
+	Name        string `json:"name,omitempty"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+// Sections of the document an experience type is exported to
+const (
+	SectionWork      = "work"
+	SectionVolunteer = "volunteer"
+	SectionAwards    = "awards"
+)
+
+// Section returns the document section an experience of type t belongs to.
+// JSON Resume has no internships, so they are exported as work.
+func Section(t string) string {
+	switch t {
+	case "campus":
+		return SectionVolunteer
+	case "competition":
+		return SectionAwards
+	default:
+		return SectionWork
+	}
+}
+
+// Decode reads a JSON Resume document. Sections and fields the portfolio
+// cannot hold (languages, interests, ...) are ignored.
+func Decode(r io.Reader) (*Resume, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	var resume Resume
+	if err := json.Unmarshal(data, &resume); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return &resume, nil
+}
+
+// FromPortfolio builds a JSON Resume document from portfolio data
+func FromPortfolio(data *model.PortfolioData) *Resume {
+	p := data.Profile
+	r := &Resume{
+		Schema: SchemaURL,
+		Basics: Basics{
+			Name:    p.Name,
+			Label:   p.Title,
+			Image:   p.PhotoURL,
+			Email:   p.Email,
+			Summary: p.Description,
+		},
+	}
+	if p.LinkedInURL != "" {
+		r.Basics.Profiles = append(r.Basics.Profiles, NetworkProfile{Network: "LinkedIn", Username: username(p.LinkedInURL), URL: p.LinkedInURL})
+	}
+	if p.GithubURL != "" {
+		r.Basics.Profiles = append(r.Basics.Profiles, NetworkProfile{Network: "GitHub", Username: username(p.GithubURL), URL: p.GithubURL})
+	}
+
+	for _, e := range data.Experiences {
+		start, end := ParsePeriod(e.Period)
+		switch Section(e.Type) {
+		case SectionVolunteer:
+			r.Volunteer = append(r.Volunteer, Volunteer{Organization: e.Organization, Position: e.Title, StartDate: start, EndDate: end, Summary: e.Description})
+		case SectionAwards:
+			r.Awards = append(r.Awards, Award{Title: e.Title, Awarder: e.Organization, Date: start, Summary: e.Description})
+		default:
+			r.Work = append(r.Work, Work{Name: e.Organization, Position: e.Title, StartDate: start, EndDate: end, Summary: e.Description})
+		}
+	}
+
+	for _, e := range data.Education {
+		r.Education = append(r.Education, Education{
+			Institution: e.Institution, Area: e.Field, StudyType: e.Degree,
+			StartDate: e.StartDate, EndDate: e.EndDate, Score: e.GPA,
+		})
+	}
+
+	for _, c := range data.Certifications {
+		r.Certificates = append(r.Certificates, Certificate{Name: c.Name, Date: c.IssueDate, Issuer: c.Issuer, URL: c.VerificationURL})
+	}
+
+	for _, category := range sortedKeys(data.Skills) {
+		skills := data.Skills[category]
+		group := Skill{Name: category, Level: commonLevel(skills)}
+		for _, s := range skills {
+			group.Keywords = append(group.Keywords, s.Name)
+		}
+		r.Skills = append(r.Skills, group)
+	}
+
+	for _, p := range data.Projects {
+		link := p.ProjectURL
+		if link == "" {
+			link = p.GithubURL
+		}
+		r.Projects = append(r.Projects, Project{Name: p.Title, Description: p.Description, URL: link, Keywords: splitList(p.TechStack)})
+	}
+
+	for _, p := range data.Publications {
+		pub := Publication{Name: p.Title, Publisher: p.Journal, URL: p.Link(), Summary: p.Description}
+		if p.Year != 0 {
+			pub.ReleaseDate = strconv.Itoa(p.Year)
+		}
+		r.Publications = append(r.Publications, pub)
+	}
+
+	return r
+}
+
+// Portfolio maps the document onto portfolio entities without IDs. Fields the
+// schema does not carry (colours, images of projects, publication authors,
+// honours, credential IDs and expiry dates) are left empty for the importer to
+// fill from existing records or defaults.
+func (r *Resume) Portfolio() *model.PortfolioData {
+	b := r.Basics
+	data := &model.PortfolioData{
+		Profile: model.Profile{
+			Name:        strings.TrimSpace(b.Name),
+			Title:       strings.TrimSpace(b.Label),
+			Description: strings.TrimSpace(b.Summary),
+			PhotoURL:    strings.TrimSpace(b.Image),
+			Email:       strings.TrimSpace(b.Email),
+		},
+		Skills: make(map[string][]model.Skill),
+	}
+	for _, np := range b.Profiles {
+		switch strings.ToLower(strings.TrimSpace(np.Network)) {
+		case "linkedin":
+			data.Profile.LinkedInURL = strings.TrimSpace(np.URL)
+		case "github":
+			data.Profile.GithubURL = strings.TrimSpace(np.URL)
+		}
+	}
+
+	for _, w := range r.Work {
+		data.Experiences = append(data.Experiences, model.Experience{
+			Title: strings.TrimSpace(w.Position), Organization: strings.TrimSpace(w.Name),
+			Period: FormatPeriod(w.StartDate, w.EndDate, true), Description: strings.TrimSpace(w.Summary), Type: "work",
+		})
+	}
+	for _, v := range r.Volunteer {
+		data.Experiences = append(data.Experiences, model.Experience{
+			Title: strings.TrimSpace(v.Position), Organization: strings.TrimSpace(v.Organization),
+			Period: FormatPeriod(v.StartDate, v.EndDate, true), Description: strings.TrimSpace(v.Summary), Type: "campus",
+		})
+	}
+	for _, a := range r.Awards {
+		data.Experiences = append(data.Experiences, model.Experience{
+			Title: strings.TrimSpace(a.Title), Organization: strings.TrimSpace(a.Awarder),
+			Period: FormatPeriod(a.Date, a.Date, false), Description: strings.TrimSpace(a.Summary), Type: "competition",
+		})
+	}
+
+	for _, e := range r.Education {
+		data.Education = append(data.Education, model.Education{
+			Institution: strings.TrimSpace(e.Institution), Degree: strings.TrimSpace(e.StudyType), Field: strings.TrimSpace(e.Area),
+			StartDate: FullDate(e.StartDate), EndDate: FullDate(e.EndDate), GPA: strings.TrimSpace(e.Score),
+		})
+	}
+	for _, c := range r.Certificates {
+		data.Certifications = append(data.Certifications, model.Certification{
+			Name: strings.TrimSpace(c.Name), Issuer: strings.TrimSpace(c.Issuer),
+			IssueDate: FullDate(c.Date), VerificationURL: strings.TrimSpace(c.URL),
+		})
+	}
+
+	for _, group := range r.Skills {
+		category := strings.TrimSpace(group.Name)
+		level := normalizeLevel(group.Level)
+		for _, keyword := range group.Keywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				data.Skills[category] = append(data.Skills[category], model.Skill{Category: category, Name: keyword, Level: level})
+			}
+		}
+	}
+
+	for _, p := range r.Projects {
+		project := model.Project{
+			Title:       strings.TrimSpace(p.Name),
+			Description: strings.TrimSpace(p.Description),
+			TechStack:   strings.Join(trimAll(p.Keywords), ", "),
+		}
+		if link := strings.TrimSpace(p.URL); isGitHub(link) {
+			project.GithubURL = link
+		} else {
+			project.ProjectURL = link
+		}
+		data.Projects = append(data.Projects, project)
+	}
+
+	for _, p := range r.Publications {
+		data.Publications = append(data.Publications, model.Publication{
+			Title:          strings.TrimSpace(p.Name),
+			Journal:        strings.TrimSpace(p.Publisher),
+			Year:           year(p.ReleaseDate),
+			PublicationURL: strings.TrimSpace(p.URL),
+			Description:    strings.TrimSpace(p.Summary),
+		})
+	}
+
+	return data
+}
+
+// username takes the last path segment of a profile URL, e.g. "jane" for https://github.com/jane/
+func username(profileURL string) string {
+	u, err := url.Parse(profileURL)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(strings.TrimSuffix(u.Path, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+func isGitHub(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "github.com" || host == "www.github.com"
+}
+
+// commonLevel returns the level shared by every skill of a group, empty when they differ
+func commonLevel(skills []model.Skill) string {
+	if len(skills) == 0 {
+		return ""
+	}
+	level := skills[0].Level
+	for _, s := range skills[1:] {
+		if s.Level != level {
+			return ""
+		}
+	}
+	return level
+}
+
+// levelAliases maps free-form JSON Resume levels onto the portfolio's skill levels
+var levelAliases = map[string]string{
+	"beginner":     "beginner",
+	"novice":       "beginner",
+	"basic":        "beginner",
+	"intermediate": "intermediate",
+	"advanced":     "advanced",
+	"expert":       "advanced",
+	"master":       "advanced",
+}
+
+func normalizeLevel(level string) string {
+	return levelAliases[strings.ToLower(strings.TrimSpace(level))]
+}
+
+// year takes the year of an ISO 8601 date such as "2024" or "2024-05-01", 0 when there is none
+func year(date string) int {
+	date = strings.TrimSpace(date)
+	if len(date) < 4 {
+		return 0
+	}
+	y, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return y
+}
+
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return trimAll(strings.Split(s, ","))
+}
+
+func trimAll(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package jsonresume
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func samplePortfolio() *model.PortfolioData {
+	return &model.PortfolioData{
+		Profile: model.Profile{
+			Name: "Jane Doe", Title: "Backend Engineer", Email: "jane@example.com", Description: "Builds things.",
+			LinkedInURL: "https://linkedin.com/in/janedoe/", GithubURL: "https://github.com/jane",
+		},
+		Experiences: []model.Experience{
+			{Title: "Software Engineer", Organization: "XYZ", Period: "2022 - Present", Type: "work"},
+			{Title: "Intern", Organization: "ABC", Period: "Jun 2021 - Dec 2021", Type: "internship"},
+			{Title: "Lab Assistant", Organization: "Uni", Period: "2020 - 2021", Type: "campus"},
+			{Title: "1st Place", Organization: "Hackathon", Period: "2021", Type: "competition"},
+		},
+		Skills: map[string][]model.Skill{
+			"Languages": {{Category: "Languages", Name: "Go", Level: "advanced"}, {Category: "Languages", Name: "SQL", Level: "advanced"}},
+			"Tools":     {{Category: "Tools", Name: "Docker", Level: "beginner"}, {Category: "Tools", Name: "Git", Level: "advanced"}},
+		},
+		Projects: []model.Project{
+			{Title: "Site", Description: "My site", ProjectURL: "https://example.com", TechStack: "Go, PostgreSQL"},
+			{Title: "Lib", Description: "A library", GithubURL: "https://github.com/jane/lib"},
+		},
+		Publications: []model.Publication{{Title: "On Caching", Journal: "JSys", Year: 2024, PublicationURL: "https://doi.org/x"}},
+		Education: []model.Education{
+			{Institution: "University", Degree: "BSc", Field: "Computer Science", StartDate: "2016-09-01", EndDate: "2020-06-30", GPA: "3.8/4.0", Honors: "Cum laude"},
+		},
+		Certifications: []model.Certification{
+			{Name: "CKA", Issuer: "CNCF", CredentialID: "LF-123", IssueDate: "2024-03-15", VerificationURL: "https://cncf.io/verify/123"},
+		},
+	}
+}
+
+func TestFromPortfolio_MapsSections(t *testing.T) {
+	r := FromPortfolio(samplePortfolio())
+
+	assert.Equal(t, SchemaURL, r.Schema)
+	assert.Equal(t, "Backend Engineer", r.Basics.Label)
+	assert.Equal(t, []NetworkProfile{
+		{Network: "LinkedIn", Username: "janedoe", URL: "https://linkedin.com/in/janedoe/"},
+		{Network: "GitHub", Username: "jane", URL: "https://github.com/jane"},
+	}, r.Basics.Profiles)
+	assert.Equal(t, []Work{
+		{Name: "XYZ", Position: "Software Engineer", StartDate: "2022"},
+		{Name: "ABC", Position: "Intern", StartDate: "2021-06", EndDate: "2021-12"},
+	}, r.Work)
+	assert.Equal(t, []Volunteer{{Organization: "Uni", Position: "Lab Assistant", StartDate: "2020", EndDate: "2021"}}, r.Volunteer)
+	assert.Equal(t, []Award{{Title: "1st Place", Awarder: "Hackathon", Date: "2021"}}, r.Awards)
+	assert.Equal(t, []Skill{
+		{Name: "Languages", Level: "advanced", Keywords: []string{"Go", "SQL"}},
+		{Name: "Tools", Keywords: []string{"Docker", "Git"}},
+	}, r.Skills)
+	assert.Equal(t, []string{"Go", "PostgreSQL"}, r.Projects[0].Keywords)
+	assert.Equal(t, "https://github.com/jane/lib", r.Projects[1].URL)
+	assert.Equal(t, "2024", r.Publications[0].ReleaseDate)
+	assert.Equal(t, []Education{{Institution: "University", Area: "Computer Science", StudyType: "BSc",
+		StartDate: "2016-09-01", EndDate: "2020-06-30", Score: "3.8/4.0"}}, r.Education)
+	assert.Equal(t, []Certificate{{Name: "CKA", Date: "2024-03-15", Issuer: "CNCF", URL: "https://cncf.io/verify/123"}}, r.Certificates)
+}
+
+func TestRoundTrip_PreservesMappedFields(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(FromPortfolio(samplePortfolio())))
+
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+	data := decoded.Portfolio()
+
+	assert.Equal(t, "Jane Doe", data.Profile.Name)
+	assert.Equal(t, "https://github.com/jane", data.Profile.GithubURL)
+
+	periods := map[string]string{}
+	for _, e := range data.Experiences {
+		periods[e.Title] = e.Period
+	}
+	assert.Equal(t, map[string]string{
+		"Software Engineer": "2022 - Present",
+		"Intern":            "Jun 2021 - Dec 2021",
+		"Lab Assistant":     "2020 - 2021",
+		"1st Place":         "2021",
+	}, periods)
+
+	assert.Equal(t, "advanced", data.Skills["Languages"][0].Level)
+	assert.Empty(t, data.Skills["Tools"][0].Level, "mixed levels are not exported")
+	assert.Equal(t, "Go, PostgreSQL", data.Projects[0].TechStack)
+	assert.Equal(t, "https://example.com", data.Projects[0].ProjectURL)
+	assert.Equal(t, "https://github.com/jane/lib", data.Projects[1].GithubURL)
+	assert.Equal(t, 2024, data.Publications[0].Year)
+	assert.Equal(t, []model.Education{{Institution: "University", Degree: "BSc", Field: "Computer Science",
+		StartDate: "2016-09-01", EndDate: "2020-06-30", GPA: "3.8/4.0"}}, data.Education, "honours have no JSON Resume field")
+	assert.Equal(t, []model.Certification{{Name: "CKA", Issuer: "CNCF", IssueDate: "2024-03-15",
+		VerificationURL: "https://cncf.io/verify/123"}}, data.Certifications, "credential IDs have no JSON Resume field")
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		period, start, end string
+	}{
+		{"2022 - Present", "2022", ""},
+		{"2020 - 2021", "2020", "2021"},
+		{"2021", "2021", "2021"},
+		{"Jan 2022 – Mar 2023", "2022-01", "2023-03"},
+		{"January 2020 to Now", "2020-01", ""},
+		{"2021-06 - 2021-12", "2021-06", "2021-12"},
+		{"2019 s/d Sekarang", "2019", ""},
+		{"Summer 2020", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			start, end := ParsePeriod(tt.period)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+		})
+	}
+}
+
+func TestFullDate(t *testing.T) {
+	assert.Equal(t, "2020-01-01", FullDate("2020"))
+	assert.Equal(t, "2020-09-01", FullDate("2020-09"))
+	assert.Equal(t, "2020-09-15", FullDate(" 2020-09-15 "))
+	assert.Empty(t, FullDate("Fall 2020"))
+	assert.Empty(t, FullDate(""))
+}
+
+func TestDecode_NormalizesLevelsAndIgnoresUnknownSections(t *testing.T) {
+	doc := `{"basics":{"name":"Jane"},"languages":[{"language":"English"}],
+		"skills":[{"name":"Languages","level":"Master","keywords":["Go"," ", "Rust"]}]}`
+
+	r, err := Decode(strings.NewReader(doc))
+	require.NoError(t, err)
+	skills := r.Portfolio().Skills["Languages"]
+
+	require.Len(t, skills, 2)
+	assert.Equal(t, "advanced", skills[0].Level)
+	assert.Equal(t, "Rust", skills[1].Name)
+}
+
+func TestDecode_Rejects(t *testing.T) {
+	_, err := Decode(strings.NewReader(`{"basics": [`))
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = Decode(strings.NewReader(`{"basics":{"summary":"` + strings.Repeat("a", MaxSize) + `"}}`))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
@@ -0,0 +1,115 @@
+package jsonresume
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"session-19/model"
+)
+
+// periodSeparator splits "2020 - 2022", "Jan 2020 – Mar 2021" or "2020 to 2022".
+// A bare hyphen is only a separator with spaces around it, as ISO dates contain hyphens.
+var periodSeparator = regexp.MustCompile(`\s+(?:-|to|s/d)\s+|\s*[–—]\s*`)
+
+// ongoing are the words a period uses for "until now"
+var ongoing = map[string]bool{"present": true, "now": true, "current": true, "sekarang": true}
+
+// periodLayouts are the date forms accepted in a period, with the ISO 8601 layout each maps to
+var periodLayouts = []struct{ parse, iso string }{
+	{"2006", "2006"},
+	{"2006-01", "2006-01"},
+	{"2006-01-02", "2006-01"},
+	{"Jan 2006", "2006-01"},
+	{"January 2006", "2006-01"},
+	{"01/2006", "2006-01"},
+}
+
+// ParsePeriod turns a free-form period such as "Jan 2022 - Present" into ISO 8601
+// start and end dates. The end is empty for an ongoing period; a single date such
+// as "2021" is both start and end. Both are empty when the period is not understood.
+func ParsePeriod(period string) (start, end string) {
+	period = strings.TrimSpace(period)
+	if period == "" {
+		return "", ""
+	}
+
+	parts := periodSeparator.Split(period, 2)
+	start, ok := isoDate(parts[0])
+	if !ok {
+		return "", ""
+	}
+	if len(parts) == 1 {
+		return start, start
+	}
+	if ongoing[strings.ToLower(strings.TrimSpace(parts[1]))] {
+		return start, ""
+	}
+	if end, ok = isoDate(parts[1]); !ok {
+		return "", ""
+	}
+	return start, end
+}
+
+// FormatPeriod is the inverse of ParsePeriod. An empty end reads as "Present"
+// when ongoing is allowed; equal dates collapse to one.
+func FormatPeriod(start, end string, allowOngoing bool) string {
+	start, end = displayDate(start), displayDate(end)
+	switch {
+	case start == "":
+		return end
+	case end == "" && allowOngoing:
+		return start + " - Present"
+	case end == "" || end == start:
+		return start
+	default:
+		return start + " - " + end
+	}
+}
+
+// FullDate turns an ISO 8601 date of any precision ("2020", "2020-09" or
+// "2020-09-01") into a model.DateLayout date on the first of the missing
+// month or day, "" when it is not one
+func FullDate(date string) string {
+	date = strings.TrimSpace(date)
+	for _, layout := range []string{model.DateLayout, "2006-01", "2006"} {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t.Format(model.DateLayout)
+		}
+	}
+	return ""
+}
+
+func isoDate(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, l := range periodLayouts {
+		if t, err := time.Parse(l.parse, s); err == nil {
+			return t.Format(l.iso), true
+		}
+	}
+	return "", false
+}
+
+// displayDate renders an ISO 8601 date as "2022" or "Jan 2022"; text it cannot parse is kept as is
+func displayDate(date string) string {
+	date = strings.TrimSpace(date)
+	if t, err := time.Parse("2006", date); err == nil {
+		return t.Format("2006")
+	}
+	if len(date) >= len("2006-01") {
+		if t, err := time.Parse("2006-01", date[:len("2006-01")]); err == nil {
+			return t.Format("Jan 2006")
+		}
+	}
+	return date
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
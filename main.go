@@ -11,6 +11,7 @@ import (
 	"session-19/repository"
 	"session-19/router"
 	"session-19/service"
+	"session-19/storage"
 	"session-19/utils"
 )
 
@@ -35,10 +36,16 @@ func main() {
 		log.Fatal("Failed to parse templates:", err)
 	}
 
+	// Initialize media storage (local disk or S3-compatible, see STORAGE_DRIVER)
+	store, err := storage.New(storage.GetDefaultConfig())
+	if err != nil {
+		log.Fatal("Failed to initialize storage:", err)
+	}
+
 	// Initialize layers
 	repo := repository.NewRepository(db, logger)
-	svc := service.NewService(repo)
-	h := handler.NewHandler(svc, logger, tmpl)
+	svc := service.NewService(repo, store)
+	h := handler.NewHandler(svc, store, logger, tmpl)
 
 	// Create router
 	r := router.NewRouter(h, svc, logger)
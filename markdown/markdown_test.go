@@ -0,0 +1,42 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	out := string(HTML("Built with **Go**:\n\n- chi\n- [pgx](https://github.com/jackc/pgx)\n\n`go test`"))
+
+	assert.Contains(t, out, "<strong>Go</strong>")
+	assert.Contains(t, out, "<ul>\n<li>chi</li>")
+	assert.Contains(t, out, `<a href="https://github.com/jackc/pgx" rel="nofollow noopener" target="_blank">pgx</a>`)
+	assert.Contains(t, out, "<code>go test</code>")
+}
+
+func TestHTML_Sanitizes(t *testing.T) {
+	out := string(HTML(`<script>alert(1)</script><img src=x onerror=alert(1)>` +
+		"\n\n[click](javascript:alert(1)) <b onclick=\"x()\">bold</b> <a href=\"/x\" style=\"color:red\">rel</a>"))
+
+	assert.NotContains(t, out, "<script")
+	assert.NotContains(t, out, "<img")
+	assert.NotContains(t, out, "javascript:")
+	assert.NotContains(t, out, "onclick")
+	assert.NotContains(t, out, "style=")
+	assert.Contains(t, out, "<b>bold</b>")
+	assert.Contains(t, out, `<a href="/x" rel="nofollow">rel</a>`)
+}
+
+func TestHTML_PlainText(t *testing.T) {
+	assert.Equal(t, "<p>line one<br>\nline two &lt;3</p>\n", string(HTML("line one\nline two <3")))
+	assert.Empty(t, HTML("  \n"))
+}
+
+func TestText(t *testing.T) {
+	src := "# Results\n\nCut **latency** by *40%*, see <https://example.com>.\n\n- one\n- two\n\n```\ncode\n```"
+	assert.Equal(t, "Results\nCut latency by 40%, see https://example.com.\n• one\n• two\ncode", Text(src))
+	assert.Equal(t, "plain", Text("plain"))
+	assert.False(t, strings.Contains(Text("<em>x</em>"), "<"))
+}
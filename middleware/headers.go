@@ -0,0 +1,12 @@
+package middleware
+
+import "net/http"
+
+// NoSniff stops browsers from guessing a content type other than the one served,
+// so an uploaded file can never be rendered as HTML or script
+func NoSniff(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// BackupData is every record a backup archive holds
+type BackupData struct {
+	Profile      *Profile      `json:"profile"`
+	Experiences  []Experience  `json:"experiences"`
+	Skills       []Skill       `json:"skills"`
+	Projects     []Project     `json:"projects"`
+	Publications []Publication `json:"publications"`
+	// ProjectSlugRedirects and PublicationSlugRedirects are the slugs records used before
+	ProjectSlugRedirects     []SlugRedirect  `json:"project_slug_redirects"`
+	PublicationSlugRedirects []SlugRedirect  `json:"publication_slug_redirects"`
+	Education                []Education     `json:"education"`
+	Certifications           []Certification `json:"certifications"`
+	Testimonials             []Testimonial   `json:"testimonials"`
+	// TestimonialInvites keep their tokens, so links sent before a restore keep working
+	TestimonialInvites []TestimonialInvite `json:"testimonial_invites"`
+	Media              []Media             `json:"media"`
+	CVVersions         []CVVersion         `json:"cv_versions"`
+	Users              []BackupUser        `json:"users"`
+	SEO                *SEOSettings        `json:"seo"`
+}
+
+// SlugRedirect is an old slug that keeps pointing at the record with TargetID
+type SlugRedirect struct {
+	Slug      string    `json:"slug"`
+	TargetID  int64     `json:"target_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BackupUser is an admin user as backed up. Password hashes are never written to an archive.
+type BackupUser struct {
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Certification represents a professional certification or license
+type Certification struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer"`
+	CredentialID string `json:"credential_id"`
+	// IssueDate and ExpiryDate are dates in DateLayout; an empty ExpiryDate means it does not expire
+	IssueDate       string    `json:"issue_date"`
+	ExpiryDate      string    `json:"expiry_date"`
+	VerificationURL string    `json:"verification_url"`
+	Color           string    `json:"color"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Expired reports whether the certification's expiry date has passed
+func (c Certification) Expired() bool {
+	expiry, err := time.Parse(DateLayout, c.ExpiryDate)
+	if err != nil {
+		return false
+	}
+	// A certification is valid through its expiry date
+	return time.Now().After(expiry.AddDate(0, 0, 1))
+}
+
+// Issued formats the issue date as "Jan 2006", "" when unknown
+func (c Certification) Issued() string {
+	return MonthYear(c.IssueDate)
+}
+
+// Expires formats the expiry date as "Jan 2006", "" when it does not expire
+func (c Certification) Expires() string {
+	return MonthYear(c.ExpiryDate)
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CVVersion is an uploaded CV/résumé PDF. The active version is the one the profile links to.
+type CVVersion struct {
+	ID        int64     `json:"id"`
+	Filename  string    `json:"filename"`
+	URL       string    `json:"url"`
+	Size      int64     `json:"size"`
+	Hash      string    `json:"hash"`
+	Active    bool      `json:"active"`
+	Downloads int64     `json:"downloads"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HumanSize formats Size for display, e.g. "1.5 MB"
+func (cv CVVersion) HumanSize() string {
+	return humanSize(cv.Size)
+}
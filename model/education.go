@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// DateLayout is the form of the date fields of education and certifications, as sent by <input type="date">
+const DateLayout = "2006-01-02"
+
+// Education represents a degree or course of study
+type Education struct {
+	ID          int64  `json:"id"`
+	Institution string `json:"institution"`
+	Degree      string `json:"degree"`
+	// Field is the field of study, e.g. "Computer Science"
+	Field string `json:"field"`
+	// StartDate and EndDate are dates in DateLayout; an empty EndDate means still studying
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	// GPA is kept as written, with its scale, e.g. "3.85/4.00"
+	GPA         string    `json:"gpa"`
+	Honors      string    `json:"honors"`
+	Description string    `json:"description"`
+	Color       string    `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Period describes when the education took place, e.g. "Sep 2018 - Jun 2022" or "Sep 2022 - Present"
+func (e Education) Period() string {
+	start, end := MonthYear(e.StartDate), MonthYear(e.EndDate)
+	switch {
+	case start == "" && end == "":
+		return ""
+	case end == "":
+		return start + " - Present"
+	case start == "":
+		return end
+	}
+	return start + " - " + end
+}
+
+// MonthYear formats a date in DateLayout as "Jan 2006", "" for an empty or malformed date
+func MonthYear(date string) string {
+	t, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return ""
+	}
+	return t.Format("Jan 2006")
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImageVariant is a resized or re-encoded copy of an uploaded image
+type ImageVariant struct {
+	URL   string `json:"url"`
+	Width int    `json:"width"`
+	Type  string `json:"type"`
+}
+
+// ImageVariants are the responsive copies recorded alongside an image URL.
+// The first entry is the original upload.
+type ImageVariants []ImageVariant
+
+// Has reports whether any variant is of the given MIME type
+func (v ImageVariants) Has(mimeType string) bool {
+	return slices.ContainsFunc(v, func(variant ImageVariant) bool { return variant.Type == mimeType })
+}
+
+// Srcset formats the variants as an HTML srcset value, e.g. "/a_320w.webp 320w, /a.jpg 640w",
+// preferring mimeType and falling back to the original's format at widths without one.
+// It is empty when there are no variants.
+func (v ImageVariants) Srcset(mimeType string) string {
+	byWidth := map[int]ImageVariant{}
+	for _, variant := range v {
+		current, seen := byWidth[variant.Width]
+		if !seen || (variant.Type == mimeType && current.Type != mimeType) {
+			byWidth[variant.Width] = variant
+		}
+	}
+
+	widths := make([]int, 0, len(byWidth))
+	for width := range byWidth {
+		widths = append(widths, width)
+	}
+	sort.Ints(widths)
+
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		parts[i] = byWidth[width].URL + " " + strconv.Itoa(width) + "w"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// OriginalSrcset is the srcset in the original upload's format, the fallback
+// for browsers that skip the WebP source
+func (v ImageVariants) OriginalSrcset() string {
+	if len(v) == 0 {
+		return ""
+	}
+	return v.Srcset(v[0].Type)
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Media is an uploaded image in the media library
+type Media struct {
+	ID        int64         `json:"id"`
+	Filename  string        `json:"filename"`
+	URL       string        `json:"url"`
+	MimeType  string        `json:"mime_type"`
+	Size      int64         `json:"size"`
+	Width     int           `json:"width"`
+	Height    int           `json:"height"`
+	Hash      string        `json:"hash"`
+	AltText   string        `json:"alt_text"`
+	Variants  ImageVariants `json:"variants"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Thumbnail returns the URL of the smallest stored copy, for previews
+func (m Media) Thumbnail() string {
+	url, width := m.URL, m.Width
+	for _, v := range m.Variants {
+		if v.Type == m.MimeType && (width == 0 || v.Width < width) {
+			url, width = v.URL, v.Width
+		}
+	}
+	return url
+}
+
+// HumanSize formats Size for display, e.g. "1.5 MB"
+func (m Media) HumanSize() string {
+	return humanSize(m.Size)
+}
+
+// humanSize formats a byte count with a binary unit
+func humanSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
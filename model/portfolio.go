@@ -1,10 +1,49 @@
 package model
 
+// Portfolio section names used to report partial load failures
+const (
+	SectionProfile        = "profile"
+	SectionExperiences    = "experiences"
+	SectionSkills         = "skills"
+	SectionProjects       = "projects"
+	SectionPublications   = "publications"
+	SectionEducation      = "education"
+	SectionCertifications = "certifications"
+	SectionTestimonials   = "testimonials"
+	SectionSEO            = "seo"
+)
+
 // PortfolioData represents all data needed for the portfolio page
 type PortfolioData struct {
-	Profile      Profile            `json:"profile"`
-	Experiences  []Experience       `json:"experiences"`
-	Skills       map[string][]Skill `json:"skills"`
-	Projects     []Project          `json:"projects"`
-	Publications []Publication      `json:"publications"`
+	Profile        Profile            `json:"profile"`
+	Experiences    []Experience       `json:"experiences"`
+	Skills         map[string][]Skill `json:"skills"`
+	Projects       []Project          `json:"projects"`
+	Publications   []Publication      `json:"publications"`
+	Education      []Education        `json:"education"`
+	Certifications []Certification    `json:"certifications"`
+	Testimonials   []Testimonial      `json:"testimonials"`
+	SEO            SEOSettings        `json:"seo"`
+	Failures       []SectionFailure   `json:"failures,omitempty"`
+}
+
+// SectionFailure describes a portfolio section that could not be loaded
+type SectionFailure struct {
+	Section string `json:"section"`
+	Message string `json:"message"`
+}
+
+// Partial reports whether any section failed to load
+func (d *PortfolioData) Partial() bool {
+	return len(d.Failures) > 0
+}
+
+// SectionFailed reports whether the named section failed to load
+func (d *PortfolioData) SectionFailed(section string) bool {
+	for _, f := range d.Failures {
+		if f.Section == section {
+			return true
+		}
+	}
+	return false
 }
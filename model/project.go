@@ -4,14 +4,31 @@ import "time"
 
 // Project represents a portfolio project
 type Project struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ImageURL    string    `json:"image_url"`
-	ProjectURL  string    `json:"project_url"`
-	GithubURL   string    `json:"github_url"`
-	TechStack   string    `json:"tech_stack"`
-	Color       string    `json:"color"`
-	ProfileID   int64     `json:"profile_id"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            int64         `json:"id"`
+	Title         string        `json:"title"`
+	Description   string        `json:"description"`
+	ImageURL      string        `json:"image_url"`
+	ImageVariants ImageVariants `json:"image_variants"`
+	ProjectURL    string        `json:"project_url"`
+	GithubURL     string        `json:"github_url"`
+	TechStack     string        `json:"tech_stack"`
+	Color         string        `json:"color"`
+	ProfileID     int64         `json:"profile_id"`
+	// Slug names the project's detail page, /projects/{slug}
+	Slug string `json:"slug"`
+	// Content is the long-form case study shown on the detail page
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProjectsPath prefixes the project detail pages
+const ProjectsPath = "/projects/"
+
+// Path returns the URL path of the project's detail page, "" for a project without a slug
+func (p Project) Path() string {
+	if p.Slug == "" {
+		return ""
+	}
+	return ProjectsPath + p.Slug
 }
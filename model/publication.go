@@ -1,17 +1,141 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Venue types of a publication
+const (
+	VenueJournal    = "journal"
+	VenueConference = "conference"
+	VenuePreprint   = "preprint"
+)
+
+// VenueTypes lists the venue types, mirroring the CHECK constraint in migrations.sql
+var VenueTypes = []string{VenueJournal, VenueConference, VenuePreprint}
 
 // Publication represents academic or professional publications
 type Publication struct {
-	ID             int64     `json:"id"`
-	Title          string    `json:"title"`
-	Authors        string    `json:"authors"`
-	Journal        string    `json:"journal"`
-	Year           int       `json:"year"`
-	Description    string    `json:"description"`
-	ImageURL       string    `json:"image_url"`
-	PublicationURL string    `json:"publication_url"`
-	Color          string    `json:"color"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID      int64   `json:"id"`
+	Title   string  `json:"title"`
+	Authors Authors `json:"authors"`
+	// Journal names the venue: the journal, the conference proceedings or the preprint server
+	Journal   string `json:"journal"`
+	VenueType string `json:"venue_type"`
+	Volume    string `json:"volume"`
+	Pages     string `json:"pages"`
+	Year      int    `json:"year"`
+	// DOI is stored bare, e.g. "10.1000/xyz123"
+	DOI            string        `json:"doi"`
+	Description    string        `json:"description"`
+	ImageURL       string        `json:"image_url"`
+	ImageVariants  ImageVariants `json:"image_variants"`
+	PublicationURL string        `json:"publication_url"`
+	Color          string        `json:"color"`
+	// Slug names the publication's detail page, /publications/{slug}
+	Slug string `json:"slug"`
+	// Content is the long-form write-up shown on the detail page
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PublicationsPath prefixes the publication detail pages
+const PublicationsPath = "/publications/"
+
+// Path returns the URL path of the publication's detail page, "" for a publication without a slug
+func (p Publication) Path() string {
+	if p.Slug == "" {
+		return ""
+	}
+	return PublicationsPath + p.Slug
+}
+
+// DOIURL returns the doi.org link of the publication, "" without a DOI
+func (p Publication) DOIURL() string {
+	if p.DOI == "" {
+		return ""
+	}
+	return "https://doi.org/" + p.DOI
+}
+
+// Link returns where the publication can be read: its URL, or else its DOI link
+func (p Publication) Link() string {
+	if p.PublicationURL != "" {
+		return p.PublicationURL
+	}
+	return p.DOIURL()
+}
+
+// Author is one author of a publication
+type Author struct {
+	Name        string `json:"name"`
+	Affiliation string `json:"affiliation"`
+	// ORCID is the bare iD, e.g. "0000-0002-1825-0097"
+	ORCID string `json:"orcid"`
+	URL   string `json:"url"`
+	// IsMe marks the portfolio owner, who is highlighted in author lists
+	IsMe bool `json:"is_me"`
+}
+
+// ORCIDURL returns the author's ORCID record, "" without an ORCID iD
+func (a Author) ORCIDURL() string {
+	if a.ORCID == "" {
+		return ""
+	}
+	return "https://orcid.org/" + a.ORCID
+}
+
+// Link returns the author's page, falling back to the ORCID record
+func (a Author) Link() string {
+	if a.URL != "" {
+		return a.URL
+	}
+	return a.ORCIDURL()
+}
+
+// Authors lists the authors of a publication in byline order
+type Authors []Author
+
+// ParseAuthors splits a comma separated list of names, the form authors were stored in before they had details
+func ParseAuthors(names string) Authors {
+	var authors Authors
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			authors = append(authors, Author{Name: name})
+		}
+	}
+	return authors
+}
+
+// Names returns the author names in order
+func (a Authors) Names() []string {
+	names := make([]string, len(a))
+	for i, author := range a {
+		names[i] = author.Name
+	}
+	return names
+}
+
+// String joins the author names with commas
+func (a Authors) String() string {
+	return strings.Join(a.Names(), ", ")
+}
+
+// UnmarshalJSON reads a list of authors, or a comma separated string of names
+// as sent by clients and stored in backups from before authors had details
+func (a *Authors) UnmarshalJSON(data []byte) error {
+	var names string
+	if err := json.Unmarshal(data, &names); err == nil {
+		*a = ParseAuthors(names)
+		return nil
+	}
+	var authors []Author
+	if err := json.Unmarshal(data, &authors); err != nil {
+		return err
+	}
+	*a = authors
+	return nil
 }
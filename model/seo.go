@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// SEOSettings are the site-wide overrides edited from the admin SEO page.
+// Empty fields fall back to values derived from the profile.
+type SEOSettings struct {
+	SiteURL       string `json:"site_url"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	ImageURL      string `json:"image_url"`
+	TwitterHandle string `json:"twitter_handle"`
+	// Robots holds robots.txt rules added after the ones keeping crawlers out of /admin, /login and /api
+	Robots    string    `json:"robots"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
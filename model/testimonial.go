@@ -0,0 +1,70 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Testimonial statuses. Submissions through an invite start pending; only approved testimonials are shown.
+const (
+	TestimonialPending  = "pending"
+	TestimonialApproved = "approved"
+	TestimonialRejected = "rejected"
+)
+
+// TestimonialStatuses lists the testimonial statuses, mirroring the CHECK constraint in migrations.sql
+var TestimonialStatuses = []string{TestimonialPending, TestimonialApproved, TestimonialRejected}
+
+// Testimonial represents a recommendation written by a colleague
+type Testimonial struct {
+	ID      int64  `json:"id"`
+	Author  string `json:"author"`
+	Role    string `json:"role"`
+	Company string `json:"company"`
+	Quote   string `json:"quote"`
+	// AvatarURL is a root-relative or absolute URL of the author's photo
+	AvatarURL string `json:"avatar_url"`
+	// Relationship describes how the author knows the owner, e.g. "Managed Alvin directly"
+	Relationship string `json:"relationship"`
+	Status       string `json:"status"`
+	Color        string `json:"color"`
+	// InviteID is the invite the testimonial was submitted through, 0 when the owner added it
+	InviteID  int64     `json:"invite_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Initials returns the first letters of the author's first and last names, shown when there is no avatar
+func (t Testimonial) Initials() string {
+	words := strings.Fields(t.Author)
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return strings.ToUpper(string([]rune(words[0])[0]))
+	}
+	return strings.ToUpper(string([]rune(words[0])[0]) + string([]rune(words[len(words)-1])[0]))
+}
+
+// TestimonialInvitePath prefixes the links colleagues submit a testimonial through
+const TestimonialInvitePath = "/testimonials/submit/"
+
+// TestimonialInvite is a single-use link for submitting a testimonial
+type TestimonialInvite struct {
+	ID    int64  `json:"id"`
+	Token string `json:"token"`
+	// Note records who the link was sent to
+	Note      string     `json:"note"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Path returns the URL path of the invite's submission page
+func (i TestimonialInvite) Path() string {
+	return TestimonialInvitePath + i.Token
+}
+
+// Open reports whether the invite can still be used to submit a testimonial
+func (i TestimonialInvite) Open() bool {
+	return i.UsedAt == nil && time.Now().Before(i.ExpiresAt)
+}
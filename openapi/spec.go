@@ -0,0 +1,261 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"session-19/citation"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/service"
+	"session-19/utils"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the API request and response bodies
+const ContentType = "application/json"
+
+// route describes one /api/v1 endpoint.
+// Every route registered in router.ApiV1Routes must have an entry here; a test enforces it.
+type route struct {
+	method   string
+	path     string
+	id       string
+	summary  string
+	tag      string
+	request  any    // request body type, nil when the operation takes no body
+	response any    // type of the envelope data field, nil when there is none
+	status   int    // success status, defaults to 200
+	raw      string // content type of a response that is not wrapped in the envelope
+	notFound bool   // whether the operation can answer 404 without an {id} parameter
+	query    []Parameter
+}
+
+// idPath is the path parameter used by every /{id} route
+var idPath = Parameter{Name: "id", In: "path", Required: true, Description: "Resource ID", Schema: &Schema{Type: "integer", Format: "int64"}}
+
+// pathParamPattern finds {name} segments in a route path
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// routes lists every /api/v1 endpoint
+var routes = []route{
+	{method: http.MethodGet, path: "/portfolio", id: "getPortfolio", summary: "Get all portfolio data", tag: "Portfolio", response: model.PortfolioData{}},
+
+	{method: http.MethodGet, path: "/profile", id: "getProfile", summary: "Get the profile", tag: "Profile", response: model.Profile{}, notFound: true},
+	{method: http.MethodPost, path: "/profile", id: "createProfile", summary: "Create the profile", tag: "Profile", request: dto.ProfileRequest{}, response: model.Profile{}, status: http.StatusCreated},
+	{method: http.MethodPut, path: "/profile/{id}", id: "updateProfile", summary: "Update the profile", tag: "Profile", request: dto.ProfileRequest{}, response: model.Profile{}},
+
+	{method: http.MethodGet, path: "/experiences", id: "listExperiences", summary: "List experiences", tag: "Experiences", response: []model.Experience{}},
+	{method: http.MethodPost, path: "/experiences", id: "createExperience", summary: "Create an experience", tag: "Experiences", request: dto.ExperienceRequest{}, response: model.Experience{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/experiences/{id}", id: "getExperience", summary: "Get an experience", tag: "Experiences", response: model.Experience{}},
+	{method: http.MethodPut, path: "/experiences/{id}", id: "updateExperience", summary: "Update an experience", tag: "Experiences", request: dto.ExperienceRequest{}, response: model.Experience{}},
+	{method: http.MethodDelete, path: "/experiences/{id}", id: "deleteExperience", summary: "Delete an experience", tag: "Experiences"},
+
+	{method: http.MethodGet, path: "/skills", id: "listSkills", summary: "List skills", tag: "Skills", response: []model.Skill{}},
+	{method: http.MethodPost, path: "/skills", id: "createSkill", summary: "Create a skill", tag: "Skills", request: dto.SkillRequest{}, response: model.Skill{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/skills/{id}", id: "getSkill", summary: "Get a skill", tag: "Skills", response: model.Skill{}},
+	{method: http.MethodPut, path: "/skills/{id}", id: "updateSkill", summary: "Update a skill", tag: "Skills", request: dto.SkillRequest{}, response: model.Skill{}},
+	{method: http.MethodDelete, path: "/skills/{id}", id: "deleteSkill", summary: "Delete a skill", tag: "Skills"},
+
+	{method: http.MethodGet, path: "/projects", id: "listProjects", summary: "List projects", tag: "Projects", response: []model.Project{}},
+	{method: http.MethodPost, path: "/projects", id: "createProject", summary: "Create a project", tag: "Projects", request: dto.ProjectRequest{}, response: model.Project{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/projects/{id}", id: "getProject", summary: "Get a project", tag: "Projects", response: model.Project{}},
+	{method: http.MethodPut, path: "/projects/{id}", id: "updateProject", summary: "Update a project", tag: "Projects", request: dto.ProjectRequest{}, response: model.Project{}},
+	{method: http.MethodDelete, path: "/projects/{id}", id: "deleteProject", summary: "Delete a project", tag: "Projects"},
+
+	{method: http.MethodGet, path: "/publications", id: "listPublications", summary: "List publications", tag: "Publications", response: []model.Publication{}},
+	{method: http.MethodPost, path: "/publications", id: "createPublication", summary: "Create a publication", tag: "Publications", request: dto.PublicationRequest{}, response: model.Publication{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/publications/{id}", id: "getPublication", summary: "Get a publication", tag: "Publications", response: model.Publication{}},
+	{method: http.MethodPut, path: "/publications/{id}", id: "updatePublication", summary: "Update a publication", tag: "Publications", request: dto.PublicationRequest{}, response: model.Publication{}},
+	{method: http.MethodDelete, path: "/publications/{id}", id: "deletePublication", summary: "Delete a publication", tag: "Publications"},
+	{method: http.MethodGet, path: "/publications/{id}/cite", id: "citePublication", summary: "Cite a publication", tag: "Publications", raw: "text/plain", query: []Parameter{
+		{Name: "format", In: "query", Description: "Citation format: BibTeX (application/x-bibtex), RIS (application/x-research-info-systems), CSL-JSON (application/vnd.citationstyles.csl+json), or an APA or IEEE reference (text/plain)", Schema: &Schema{Type: "string", Enum: citation.Names()}},
+		{Name: "download", In: "query", Description: "Send the citation as an attachment named after the publication", Schema: &Schema{Type: "boolean"}},
+	}},
+
+	{method: http.MethodGet, path: "/education", id: "listEducation", summary: "List education", tag: "Education", response: []model.Education{}},
+	{method: http.MethodPost, path: "/education", id: "createEducation", summary: "Create an education entry", tag: "Education", request: dto.EducationRequest{}, response: model.Education{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/education/{id}", id: "getEducation", summary: "Get an education entry", tag: "Education", response: model.Education{}},
+	{method: http.MethodPut, path: "/education/{id}", id: "updateEducation", summary: "Update an education entry", tag: "Education", request: dto.EducationRequest{}, response: model.Education{}},
+	{method: http.MethodDelete, path: "/education/{id}", id: "deleteEducation", summary: "Delete an education entry", tag: "Education"},
+
+	{method: http.MethodGet, path: "/certifications", id: "listCertifications", summary: "List certifications", tag: "Certifications", response: []model.Certification{}},
+	{method: http.MethodPost, path: "/certifications", id: "createCertification", summary: "Create a certification", tag: "Certifications", request: dto.CertificationRequest{}, response: model.Certification{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/certifications/{id}", id: "getCertification", summary: "Get a certification", tag: "Certifications", response: model.Certification{}},
+	{method: http.MethodPut, path: "/certifications/{id}", id: "updateCertification", summary: "Update a certification", tag: "Certifications", request: dto.CertificationRequest{}, response: model.Certification{}},
+	{method: http.MethodDelete, path: "/certifications/{id}", id: "deleteCertification", summary: "Delete a certification", tag: "Certifications"},
+
+	{method: http.MethodGet, path: "/export/jsonresume", id: "exportJSONResume", summary: "Export the portfolio as a JSON Resume document", tag: "Export", raw: "application/json"},
+
+	{method: http.MethodPost, path: "/contact", id: "submitContact", summary: "Submit the contact form", tag: "Contact", request: dto.ContactRequest{}},
+
+	{method: http.MethodGet, path: "/cache/stats", id: "getCacheStats", summary: "Get portfolio cache counters", tag: "Monitoring", response: service.CacheStats{}, notFound: true},
+
+	{method: http.MethodGet, path: "/openapi.json", id: "getOpenAPI", summary: "Get this OpenAPI document", tag: "Docs", raw: ContentType},
+	{method: http.MethodGet, path: "/docs", id: "getDocs", summary: "Interactive API documentation", tag: "Docs", raw: "text/html"},
+}
+
+// tags describes the operation groups in display order
+var tags = []Tag{
+	{Name: "Portfolio", Description: "Aggregated data for the public portfolio page"},
+	{Name: "Profile", Description: "The portfolio owner's profile"},
+	{Name: "Experiences", Description: "Work, internship, campus and competition experience"},
+	{Name: "Skills", Description: "Skills grouped by category"},
+	{Name: "Projects", Description: "Portfolio projects"},
+	{Name: "Publications", Description: "Academic and professional publications"},
+	{Name: "Education", Description: "Degrees and studies"},
+	{Name: "Certifications", Description: "Professional certifications and their credentials"},
+	{Name: "Export", Description: "The portfolio in other formats"},
+	{Name: "Contact", Description: "Contact form"},
+	{Name: "Monitoring", Description: "Operational counters"},
+	{Name: "Docs", Description: "This documentation"},
+}
+
+var (
+	specOnce sync.Once
+	spec     *Document
+)
+
+// Spec returns the OpenAPI document for /api/v1. It is built once and must not be modified.
+func Spec() *Document {
+	specOnce.Do(func() {
+		spec = build()
+	})
+	return spec
+}
+
+// Operations returns the method and path of every documented operation, e.g. "GET /projects/{id}"
+func Operations() []string {
+	ops := make([]string, 0, len(routes))
+	for _, rt := range routes {
+		ops = append(ops, rt.method+" "+rt.path)
+	}
+	return ops
+}
+
+// build generates the document from the route table
+func build() *Document {
+	reg := newSchemaRegistry()
+	envelope := reg.ref(utils.Reponse{})
+	errorResponse := Response{
+		Description: "Error. Clients that accept application/problem+json get RFC 9457 problem details instead of the envelope.",
+		Content:     errorContent(reg, envelope),
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Portfolio API",
+			Version:     "1.0.0",
+			Description: "JSON API behind the portfolio site. Every JSON response is wrapped in the Reponse envelope; `data` carries the payload on success; on failure `code` holds a stable error code and `errors` the details, per field for validation errors. Send `Accept: application/problem+json` to receive RFC 9457 problem details instead.",
+		},
+		Servers: []Server{{URL: "/api/v1"}},
+		Tags:    tags,
+		Paths:   make(map[string]PathItem),
+	}
+
+	for _, rt := range routes {
+		op := &Operation{
+			OperationID: rt.id,
+			Summary:     rt.summary,
+			Tags:        []string{rt.tag},
+			Parameters:  append(pathParams(rt.path), rt.query...),
+			Responses:   make(map[string]Response),
+		}
+
+		if rt.request != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(reg.ref(rt.request))}
+			op.Responses["400"] = errorResponse
+		}
+
+		status := rt.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		switch {
+		case rt.raw != "":
+			op.Responses[statusKey(status)] = Response{
+				Description: http.StatusText(status),
+				Content:     map[string]MediaType{rt.raw: {Schema: &Schema{}}},
+			}
+		default:
+			data := envelope
+			if rt.response != nil {
+				data = &Schema{AllOf: []*Schema{envelope, {
+					Type:       "object",
+					Properties: map[string]*Schema{"data": reg.ref(rt.response)},
+				}}}
+			}
+			op.Responses[statusKey(status)] = Response{Description: http.StatusText(status), Content: jsonContent(data)}
+			op.Responses["500"] = errorResponse
+		}
+
+		if strings.Contains(rt.path, "{id}") {
+			op.Responses["400"] = errorResponse
+			op.Responses["404"] = errorResponse
+		}
+		if rt.notFound {
+			op.Responses["404"] = errorResponse
+		}
+
+		item, ok := doc.Paths[rt.path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	doc.Components.Schemas = reg.schemas
+	return doc
+}
+
+// errorContent describes an error response in both representations: the JSON envelope
+// with a stable code, and problem details for clients that accept application/problem+json
+func errorContent(reg *schemaRegistry, envelope *Schema) map[string]MediaType {
+	codes := &Schema{Type: "string", Enum: service.ErrorCodes, Description: "Stable, machine-readable error code"}
+	fields := &Schema{Type: "array", Items: reg.ref(service.FieldError{}), Description: "Rejected fields, present for validation errors"}
+
+	problem := reg.ref(utils.Problem{})
+	problemSchema := reg.schemas["Problem"]
+	problemSchema.Properties["code"] = codes
+	problemSchema.Properties["errors"] = fields
+	problemSchema.Required = []string{"type", "title", "status", "code"}
+
+	failure := &Schema{AllOf: []*Schema{envelope, {
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":   codes,
+			"errors": {OneOf: []*Schema{fields, {Type: "string", Description: "Error detail"}}},
+		},
+	}}}
+
+	return map[string]MediaType{
+		ContentType:              {Schema: failure},
+		utils.ProblemContentType: {Schema: problem},
+	}
+}
+
+// pathParams returns the parameters for the {name} segments of path
+func pathParams(path string) []Parameter {
+	var params []Parameter
+	for _, m := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		if m[1] == idPath.Name {
+			params = append(params, idPath)
+			continue
+		}
+		params = append(params, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	return params
+}
+
+// jsonContent wraps a schema as application/json content
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{ContentType: {Schema: s}}
+}
+
+// statusKey formats a status code as a responses map key
+func statusKey(status int) string {
+	return strconv.Itoa(status)
+}
@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"session-19/database"
+	"session-19/model"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// BackupRepositoryInterface defines the interface for backing up and restoring all content
+type BackupRepositoryInterface interface {
+	// Snapshot reads every record from a single consistent view of the database
+	Snapshot(ctx context.Context) (*model.BackupData, error)
+	// Replace swaps all content for data in one transaction and returns the emails of
+	// users it created. Content keeps its IDs; users are matched by email and keep their passwords.
+	Replace(ctx context.Context, data *model.BackupData) ([]string, error)
+}
+
+// BackupRepository implements BackupRepositoryInterface
+type BackupRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewBackupRepository creates a new backup repository
+func NewBackupRepository(db database.PgxIface, log *zap.Logger) BackupRepositoryInterface {
+	return &BackupRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// contentTables lists the tables Replace empties, children before the tables they reference
+var contentTables = []string{"project_slug_redirects", "publication_slug_redirects", "projects", "publications", "experiences", "skills", "education", "certifications",
+	"testimonials", "testimonial_invites", "media", "cv_versions", "seo_settings", "profile"}
+
+// keyedTables are the content tables without a serial id, whose rows keep their own keys
+var keyedTables = map[string]bool{"project_slug_redirects": true, "publication_slug_redirects": true, "seo_settings": true}
+
+// Snapshot reads every record from a single consistent view of the database
+func (r *BackupRepository) Snapshot(ctx context.Context) (*model.BackupData, error) {
+	data := &model.BackupData{}
+	err := database.WithTx(ctx, r.db, func(tx database.PgxIface) error {
+		// Every read below sees the same snapshot, so the archive cannot mix two states
+		if _, err := tx.Exec(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY`); err != nil {
+			return err
+		}
+
+		profile, err := NewProfileRepository(tx, r.log).GetProfile(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to read profile: %w", err)
+		}
+		data.Profile = profile
+
+		if data.Experiences, err = NewExperienceRepository(tx, r.log).GetAllExperiences(ctx); err != nil {
+			return fmt.Errorf("failed to read experiences: %w", err)
+		}
+		if data.Skills, err = NewSkillRepository(tx, r.log).GetAllSkills(ctx); err != nil {
+			return fmt.Errorf("failed to read skills: %w", err)
+		}
+		if data.Projects, err = NewProjectRepository(tx, r.log).GetAllProjects(ctx); err != nil {
+			return fmt.Errorf("failed to read projects: %w", err)
+		}
+		if data.Publications, err = NewPublicationRepository(tx, r.log).GetAllPublications(ctx); err != nil {
+			return fmt.Errorf("failed to read publications: %w", err)
+		}
+		if data.Education, err = NewEducationRepository(tx, r.log).GetAllEducation(ctx); err != nil {
+			return fmt.Errorf("failed to read education: %w", err)
+		}
+		if data.Certifications, err = NewCertificationRepository(tx, r.log).GetAllCertifications(ctx); err != nil {
+			return fmt.Errorf("failed to read certifications: %w", err)
+		}
+		testimonials := NewTestimonialRepository(tx, r.log)
+		if data.Testimonials, err = testimonials.GetAllTestimonials(ctx); err != nil {
+			return fmt.Errorf("failed to read testimonials: %w", err)
+		}
+		if data.TestimonialInvites, err = testimonials.GetAllTestimonialInvites(ctx); err != nil {
+			return fmt.Errorf("failed to read testimonial invites: %w", err)
+		}
+		if data.Media, err = NewMediaRepository(tx, r.log).GetAllMedia(ctx); err != nil {
+			return fmt.Errorf("failed to read media: %w", err)
+		}
+		if data.CVVersions, err = NewCVRepository(tx, r.log).GetAllCVVersions(ctx); err != nil {
+			return fmt.Errorf("failed to read CV versions: %w", err)
+		}
+		if data.ProjectSlugRedirects, err = r.slugRedirects(ctx, tx, "project_slug_redirects", "project_id"); err != nil {
+			return fmt.Errorf("failed to read project slug redirects: %w", err)
+		}
+		if data.PublicationSlugRedirects, err = r.slugRedirects(ctx, tx, "publication_slug_redirects", "publication_id"); err != nil {
+			return fmt.Errorf("failed to read publication slug redirects: %w", err)
+		}
+		seo, err := NewSEORepository(tx, r.log).GetSEOSettings(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to read SEO settings: %w", err)
+		}
+		data.SEO = seo
+		if data.Users, err = r.users(ctx, tx); err != nil {
+			return fmt.Errorf("failed to read users: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("Failed to snapshot database", zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// users reads every user without the password hash
+func (r *BackupRepository) users(ctx context.Context, db database.PgxIface) ([]model.BackupUser, error) {
+	query := `SELECT email, name, COALESCE(role, 'admin'), created_at, updated_at FROM users ORDER BY id`
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []model.BackupUser
+	for rows.Next() {
+		var u model.BackupUser
+		if err := rows.Scan(&u.Email, &u.Name, &u.Role, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// slugRedirects reads the redirects of table, whose column holds the record they point at
+func (r *BackupRepository) slugRedirects(ctx context.Context, db database.PgxIface, table, column string) ([]model.SlugRedirect, error) {
+	query := `SELECT slug, ` + column + `, created_at FROM ` + table + ` ORDER BY slug`
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var redirects []model.SlugRedirect
+	for rows.Next() {
+		var sr model.SlugRedirect
+		if err := rows.Scan(&sr.Slug, &sr.TargetID, &sr.CreatedAt); err != nil {
+			return nil, err
+		}
+		redirects = append(redirects, sr)
+	}
+	return redirects, rows.Err()
+}
+
+// Replace swaps all content for data in one transaction
+func (r *BackupRepository) Replace(ctx context.Context, data *model.BackupData) ([]string, error) {
+	var created []string
+	err := database.WithTx(ctx, r.db, func(tx database.PgxIface) error {
+		for _, table := range contentTables {
+			if _, err := tx.Exec(ctx, `DELETE FROM `+table); err != nil {
+				return fmt.Errorf("failed to clear %s: %w", table, err)
+			}
+		}
+
+		if p := data.Profile; p != nil {
+			query := `INSERT INTO profile (id, name, title, description, photo_url, email, linkedin_url, github_url, cv_url, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+			if _, err := tx.Exec(ctx, query, p.ID, p.Name, p.Title, p.Description, p.PhotoURL, p.Email,
+				p.LinkedInURL, p.GithubURL, p.CVURL, p.CreatedAt, p.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to restore profile: %w", err)
+			}
+		}
+
+		for _, e := range data.Experiences {
+			query := `INSERT INTO experiences (id, title, organization, period, description, type, color, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			if _, err := tx.Exec(ctx, query, e.ID, e.Title, e.Organization, e.Period, e.Description,
+				e.Type, e.Color, e.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore experience %d: %w", e.ID, err)
+			}
+		}
+
+		for _, s := range data.Skills {
+			query := `INSERT INTO skills (id, category, name, level, color) VALUES ($1, $2, $3, $4, $5)`
+			if _, err := tx.Exec(ctx, query, s.ID, s.Category, s.Name, s.Level, s.Color); err != nil {
+				return fmt.Errorf("failed to restore skill %d: %w", s.ID, err)
+			}
+		}
+
+		for _, p := range data.Projects {
+			query := `INSERT INTO projects (id, title, description, image_url, image_variants, project_url, github_url, tech_stack, color, profile_id, slug, content, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, COALESCE($5, '[]'::jsonb), $6, $7, $8, $9, NULLIF($10, 0), NULLIF($11, ''), $12, $13, $14)`
+			if _, err := tx.Exec(ctx, query, p.ID, p.Title, p.Description, p.ImageURL, p.ImageVariants,
+				p.ProjectURL, p.GithubURL, p.TechStack, p.Color, p.ProfileID, p.Slug, p.Content, p.CreatedAt, updatedAt(p.UpdatedAt, p.CreatedAt)); err != nil {
+				return fmt.Errorf("failed to restore project %d: %w", p.ID, err)
+			}
+		}
+
+		for _, p := range data.Publications {
+			query := `INSERT INTO publications (id, title, authors, journal, year, description, image_url, image_variants, publication_url, color, slug, content, created_at, updated_at,
+				venue_type, volume, pages, doi)
+				VALUES ($1, $2, COALESCE($3, '[]'::jsonb), $4, NULLIF($5, 0), $6, $7, COALESCE($8, '[]'::jsonb), $9, $10, NULLIF($11, ''), $12, $13, $14,
+				COALESCE(NULLIF($15, ''), 'journal'), $16, $17, NULLIF($18, ''))`
+			if _, err := tx.Exec(ctx, query, p.ID, p.Title, p.Authors, p.Journal, p.Year, p.Description,
+				p.ImageURL, p.ImageVariants, p.PublicationURL, p.Color, p.Slug, p.Content, p.CreatedAt, updatedAt(p.UpdatedAt, p.CreatedAt),
+				p.VenueType, p.Volume, p.Pages, p.DOI); err != nil {
+				return fmt.Errorf("failed to restore publication %d: %w", p.ID, err)
+			}
+		}
+
+		for _, sr := range data.ProjectSlugRedirects {
+			query := `INSERT INTO project_slug_redirects (slug, project_id, created_at) VALUES ($1, $2, $3)`
+			if _, err := tx.Exec(ctx, query, sr.Slug, sr.TargetID, sr.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore project slug redirect %s: %w", sr.Slug, err)
+			}
+		}
+
+		for _, sr := range data.PublicationSlugRedirects {
+			query := `INSERT INTO publication_slug_redirects (slug, publication_id, created_at) VALUES ($1, $2, $3)`
+			if _, err := tx.Exec(ctx, query, sr.Slug, sr.TargetID, sr.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore publication slug redirect %s: %w", sr.Slug, err)
+			}
+		}
+
+		for _, e := range data.Education {
+			query := `INSERT INTO education (id, institution, degree, field, start_date, end_date, gpa, honors, description, color, created_at)
+				VALUES ($1, $2, $3, $4, NULLIF($5, '')::date, NULLIF($6, '')::date, $7, $8, $9, $10, $11)`
+			if _, err := tx.Exec(ctx, query, e.ID, e.Institution, e.Degree, e.Field, e.StartDate, e.EndDate,
+				e.GPA, e.Honors, e.Description, e.Color, e.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore education %d: %w", e.ID, err)
+			}
+		}
+
+		for _, c := range data.Certifications {
+			query := `INSERT INTO certifications (id, name, issuer, credential_id, issue_date, expiry_date, verification_url, color, created_at)
+				VALUES ($1, $2, $3, $4, NULLIF($5, '')::date, NULLIF($6, '')::date, $7, $8, $9)`
+			if _, err := tx.Exec(ctx, query, c.ID, c.Name, c.Issuer, c.CredentialID, c.IssueDate, c.ExpiryDate,
+				c.VerificationURL, c.Color, c.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore certification %d: %w", c.ID, err)
+			}
+		}
+
+		// Invites go first, testimonials reference the one they were submitted through
+		for _, i := range data.TestimonialInvites {
+			query := `INSERT INTO testimonial_invites (id, token, note, expires_at, used_at, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6)`
+			if _, err := tx.Exec(ctx, query, i.ID, i.Token, i.Note, i.ExpiresAt, i.UsedAt, i.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore testimonial invite %d: %w", i.ID, err)
+			}
+		}
+
+		for _, t := range data.Testimonials {
+			query := `INSERT INTO testimonials (id, author, role, company, quote, avatar_url, relationship, status, color, invite_id, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), $11)`
+			if _, err := tx.Exec(ctx, query, t.ID, t.Author, t.Role, t.Company, t.Quote, t.AvatarURL,
+				t.Relationship, t.Status, t.Color, t.InviteID, t.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore testimonial %d: %w", t.ID, err)
+			}
+		}
+
+		for _, m := range data.Media {
+			query := `INSERT INTO media (id, filename, url, mime_type, size, width, height, hash, alt_text, variants, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, '[]'::jsonb), $11)`
+			if _, err := tx.Exec(ctx, query, m.ID, m.Filename, m.URL, m.MimeType, m.Size, m.Width, m.Height,
+				m.Hash, m.AltText, m.Variants, m.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore media %d: %w", m.ID, err)
+			}
+		}
+
+		for _, cv := range data.CVVersions {
+			query := `INSERT INTO cv_versions (id, filename, url, size, hash, active, downloads, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			if _, err := tx.Exec(ctx, query, cv.ID, cv.Filename, cv.URL, cv.Size, cv.Hash, cv.Active,
+				cv.Downloads, cv.CreatedAt); err != nil {
+				return fmt.Errorf("failed to restore CV version %d: %w", cv.ID, err)
+			}
+		}
+
+		if seo := data.SEO; seo != nil {
+			query := `INSERT INTO seo_settings (id, site_url, title, description, image_url, twitter_handle, robots, updated_at)
+				VALUES (1, $1, $2, $3, $4, $5, $6, $7)`
+			if _, err := tx.Exec(ctx, query, seo.SiteURL, seo.Title, seo.Description, seo.ImageURL,
+				seo.TwitterHandle, seo.Robots, seo.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to restore SEO settings: %w", err)
+			}
+		}
+
+		// Rows were inserted with explicit IDs, so move each sequence past them
+		for _, table := range contentTables {
+			if keyedTables[table] {
+				continue
+			}
+			query := `SELECT setval(pg_get_serial_sequence('` + table + `', 'id'), COALESCE((SELECT MAX(id) FROM ` + table + `), 0) + 1, false)`
+			if _, err := tx.Exec(ctx, query); err != nil {
+				return fmt.Errorf("failed to reset %s sequence: %w", table, err)
+			}
+		}
+
+		var err error
+		created, err = r.restoreUsers(ctx, tx, data.Users)
+		return err
+	})
+	if err != nil {
+		r.log.Error("Failed to restore backup", zap.Error(err))
+		return nil, err
+	}
+	return created, nil
+}
+
+// restoreUsers updates users that exist and creates the others. Archives carry no
+// password hashes, so created users get an empty one that never matches a
+// password; they cannot log in until a password is set.
+func (r *BackupRepository) restoreUsers(ctx context.Context, tx database.PgxIface, users []model.BackupUser) ([]string, error) {
+	var created []string
+	for _, u := range users {
+		tag, err := tx.Exec(ctx, `UPDATE users SET name = $1, role = $2, updated_at = $3 WHERE email = $4`,
+			u.Name, u.Role, u.UpdatedAt, u.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore user %s: %w", u.Email, err)
+		}
+		if tag.RowsAffected() > 0 {
+			continue
+		}
+
+		query := `INSERT INTO users (email, password, name, role, created_at, updated_at) VALUES ($1, '', $2, $3, $4, $5)`
+		if _, err := tx.Exec(ctx, query, u.Email, u.Name, u.Role, u.CreatedAt, u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to restore user %s: %w", u.Email, err)
+		}
+		created = append(created, u.Email)
+	}
+	return created, nil
+}
+
+// updatedAt returns the update time of a restored row; archives written before
+// projects and publications tracked updates have none, so creation stands in
+func updatedAt(updated, created time.Time) time.Time {
+	if updated.IsZero() {
+		return created
+	}
+	return updated
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestBackupRepository creates a backup repository whose transaction runs on the returned MockDB
+func newTestBackupRepository() (BackupRepositoryInterface, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	mockDB.On("Begin", mock.Anything).Return(database.NewMockTx(mockDB), nil).Once()
+	// WithTx always defers a rollback; after a commit it is a no-op
+	mockDB.On("Rollback", mock.Anything).Return(nil).Once()
+	return NewBackupRepository(mockDB, zap.NewNop()), mockDB
+}
+
+// ==================== Backup Repository Tests ====================
+
+func TestBackupRepository_Replace_KeepsExistingUsersAndCreatesOthers(t *testing.T) {
+	repo, mockDB := newTestBackupRepository()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data := &model.BackupData{
+		Profile: &model.Profile{ID: 1, Name: "Jane", Email: "jane@example.com"},
+		Skills:  []model.Skill{{ID: 7, Category: "Languages", Name: "Go", Level: "advanced", Color: "black"}},
+		Users: []model.BackupUser{
+			{Email: "admin@example.com", Name: "Admin", Role: "admin", CreatedAt: now, UpdatedAt: now},
+			{Email: "new@example.com", Name: "New", Role: "admin", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"Admin", "admin", now, "admin@example.com"}).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"New", "admin", now, "new@example.com"}).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"new@example.com", "New", "admin", now, now}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(7), "Languages", "Go", "advanced", "black"}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	// Deletes, the profile insert and the sequence resets
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("OK"), nil)
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	created, err := repo.Replace(ctx, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"new@example.com"}, created)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBackupRepository_Replace_RollsBackOnError(t *testing.T) {
+	repo, mockDB := newTestBackupRepository()
+	ctx := context.Background()
+
+	data := &model.BackupData{Skills: []model.Skill{{ID: 7, Category: "Languages", Name: "Go"}}}
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(7), "Languages", "Go", "", ""}).
+		Return(pgconn.CommandTag{}, errors.New("duplicate key")).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	created, err := repo.Replace(ctx, data)
+
+	assert.ErrorContains(t, err, "failed to restore skill 7")
+	assert.Nil(t, created)
+	mockDB.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestBackupRepository_Replace_RestoresEducationAndCertifications(t *testing.T) {
+	repo, mockDB := newTestBackupRepository()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data := &model.BackupData{
+		Education: []model.Education{{ID: 4, Institution: "University", Degree: "BSc", StartDate: "2018-09-01",
+			Color: "lime", CreatedAt: now}},
+		Certifications: []model.Certification{{ID: 5, Name: "CKA", Issuer: "CNCF", IssueDate: "2024-01-01",
+			Color: "orange", CreatedAt: now}},
+	}
+
+	mockDB.On("Exec", ctx, `DELETE FROM education`, []any(nil)).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+	mockDB.On("Exec", ctx, `DELETE FROM certifications`, []any(nil)).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"),
+		[]any{int64(4), "University", "BSc", "", "2018-09-01", "", "", "", "", "lime", now}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"),
+		[]any{int64(5), "CKA", "CNCF", "", "2024-01-01", "", "", "orange", now}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	for _, table := range []string{"education", "certifications"} {
+		mockDB.On("Exec", ctx, mock.MatchedBy(func(q string) bool {
+			return strings.Contains(q, "setval(pg_get_serial_sequence('"+table+"'")
+		}), []any(nil)).Return(pgconn.NewCommandTag("SELECT 1"), nil).Once()
+	}
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("OK"), nil)
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	_, err := repo.Replace(ctx, data)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBackupRepository_Replace_RestoresTestimonialsAfterTheirInvites(t *testing.T) {
+	repo, mockDB := newTestBackupRepository()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	used := now.Add(time.Hour)
+
+	data := &model.BackupData{
+		TestimonialInvites: []model.TestimonialInvite{{ID: 3, Token: "token", Note: "Jane", ExpiresAt: now, UsedAt: &used, CreatedAt: now}},
+		Testimonials: []model.Testimonial{{ID: 8, Author: "Jane", Quote: "Great.", Status: model.TestimonialApproved,
+			Color: "yellow", InviteID: 3, CreatedAt: now}},
+	}
+
+	var order []string
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(3), "token", "Jane", now, &used, now}).
+		Run(func(mock.Arguments) { order = append(order, "invite") }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"),
+		[]any{int64(8), "Jane", "", "", "Great.", "", "", model.TestimonialApproved, "yellow", int64(3), now}).
+		Run(func(mock.Arguments) { order = append(order, "testimonial") }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("OK"), nil)
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	_, err := repo.Replace(ctx, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"invite", "testimonial"}, order)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBackupRepository_Replace_RestoresSEOAndSlugRedirects(t *testing.T) {
+	repo, mockDB := newTestBackupRepository()
+	ctx := context.Background()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data := &model.BackupData{
+		Projects:                 []model.Project{{ID: 3, Title: "Site", Slug: "site", CreatedAt: now}},
+		ProjectSlugRedirects:     []model.SlugRedirect{{Slug: "old-site", TargetID: 3, CreatedAt: now}},
+		PublicationSlugRedirects: []model.SlugRedirect{{Slug: "old-paper", TargetID: 5, CreatedAt: now}},
+		SEO:                      &model.SEOSettings{SiteURL: "https://example.com", Title: "Jane", UpdatedAt: now},
+	}
+
+	var order []string
+	mockDB.On("Exec", ctx, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "INSERT INTO projects") }), mock.Anything).
+		Run(func(mock.Arguments) { order = append(order, "project") }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"old-site", int64(3), now}).
+		Run(func(mock.Arguments) { order = append(order, "redirect") }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"old-paper", int64(5), now}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"https://example.com", "Jane", "", "", "", "", now}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("OK"), nil)
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	_, err := repo.Replace(ctx, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"project", "redirect"}, order)
+	mockDB.AssertExpectations(t)
+	// Tables keyed by slug or a fixed id have no sequence to move
+	for _, call := range mockDB.Calls {
+		if call.Method == "Exec" {
+			query := call.Arguments.String(1)
+			assert.False(t, strings.Contains(query, "setval") &&
+				(strings.Contains(query, "seo_settings") || strings.Contains(query, "slug_redirects")), query)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// CertificationRepositoryInterface defines the interface for certification repository
+type CertificationRepositoryInterface interface {
+	GetAllCertifications(ctx context.Context) ([]model.Certification, error)
+	GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error)
+	CreateCertification(ctx context.Context, cert *model.Certification) error
+	UpdateCertification(ctx context.Context, cert *model.Certification) error
+	DeleteCertification(ctx context.Context, id int64) error
+}
+
+// CertificationRepository implements CertificationRepositoryInterface
+type CertificationRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewCertificationRepository creates a new certification repository
+func NewCertificationRepository(db database.PgxIface, log *zap.Logger) CertificationRepositoryInterface {
+	return &CertificationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetAllCertifications retrieves all certifications, most recently issued first
+func (r *CertificationRepository) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	query := `SELECT ` + certificationColumns + ` FROM certifications ORDER BY issue_date DESC NULLS LAST, created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Failed to get certifications", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certifications []model.Certification
+	for rows.Next() {
+		cert, err := scanCertification(rows)
+		if err != nil {
+			r.log.Error("Failed to scan certification", zap.Error(err))
+			continue
+		}
+		certifications = append(certifications, *cert)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate certifications", zap.Error(err))
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// GetCertificationByID retrieves a certification by ID
+func (r *CertificationRepository) GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error) {
+	query := `SELECT ` + certificationColumns + ` FROM certifications WHERE id = $1`
+
+	cert, err := scanCertification(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		r.log.Error("Failed to get certification by ID", zap.Error(err), zap.Int64("id", id))
+		return nil, err
+	}
+	return cert, nil
+}
+
+// CreateCertification creates a new certification
+func (r *CertificationRepository) CreateCertification(ctx context.Context, cert *model.Certification) error {
+	query := `INSERT INTO certifications (name, issuer, credential_id, issue_date, expiry_date, verification_url, color) 
+		VALUES ($1, $2, $3, NULLIF($4, '')::date, NULLIF($5, '')::date, $6, $7) RETURNING id, created_at`
+
+	row := r.db.QueryRow(ctx, query, cert.Name, cert.Issuer, cert.CredentialID, cert.IssueDate, cert.ExpiryDate,
+		cert.VerificationURL, cert.Color)
+
+	err := row.Scan(&cert.ID, &cert.CreatedAt)
+	if err != nil {
+		r.log.Error("Failed to create certification", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateCertification updates a certification
+func (r *CertificationRepository) UpdateCertification(ctx context.Context, cert *model.Certification) error {
+	query := `UPDATE certifications SET name = $1, issuer = $2, credential_id = $3, issue_date = NULLIF($4, '')::date, 
+		expiry_date = NULLIF($5, '')::date, verification_url = $6, color = $7 WHERE id = $8`
+
+	tag, err := r.db.Exec(ctx, query, cert.Name, cert.Issuer, cert.CredentialID, cert.IssueDate, cert.ExpiryDate,
+		cert.VerificationURL, cert.Color, cert.ID)
+	if err != nil {
+		r.log.Error("Failed to update certification", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteCertification deletes a certification
+func (r *CertificationRepository) DeleteCertification(ctx context.Context, id int64) error {
+	query := `DELETE FROM certifications WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete certification", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// certificationColumns lists the columns scanCertification reads; dates are read in model.DateLayout
+const certificationColumns = `id, name, issuer, COALESCE(credential_id, ''), 
+	COALESCE(to_char(issue_date, 'YYYY-MM-DD'), ''), COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''), 
+	COALESCE(verification_url, ''), COALESCE(color, 'orange'), created_at`
+
+func scanCertification(row pgx.Row) (*model.Certification, error) {
+	var cert model.Certification
+	err := row.Scan(&cert.ID, &cert.Name, &cert.Issuer, &cert.CredentialID, &cert.IssueDate, &cert.ExpiryDate,
+		&cert.VerificationURL, &cert.Color, &cert.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestCertificationRepository creates a new test certification repository
+func newTestCertificationRepository() (*CertificationRepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	logger := zap.NewNop()
+	repo := NewCertificationRepository(mockDB, logger)
+	return repo.(*CertificationRepository), mockDB
+}
+
+// ==================== Certification Repository Tests ====================
+
+func TestCertificationRepository_GetAllCertifications_Success(t *testing.T) {
+	repo, mockDB := newTestCertificationRepository()
+	ctx := context.Background()
+
+	mockRows := database.NewMockRows([][]any{
+		{int64(1), "AWS Solutions Architect", "Amazon Web Services", "ABC-123", "2024-03-01", "2027-03-01", "https://verify.example/ABC-123", "orange", time.Now()},
+		{int64(2), "CKA", "CNCF", "", "2019-05-01", "2022-05-01", "", "cyan", time.Now()},
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		data := mockRows.Data[mockRows.CurrentIndex]
+		*dest[0].(*int64) = data[0].(int64)
+		for i := 1; i <= 7; i++ {
+			*dest[i].(*string) = data[i].(string)
+		}
+		*dest[8].(*time.Time) = data[8].(time.Time)
+	}).Return(nil)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil)
+
+	mockDB.On("Query", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRows, nil).Once()
+
+	certifications, err := repo.GetAllCertifications(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, certifications, 2)
+	assert.Equal(t, "ABC-123", certifications[0].CredentialID)
+	assert.Equal(t, "2027-03-01", certifications[0].ExpiryDate)
+	assert.True(t, certifications[1].Expired())
+	mockDB.AssertExpectations(t)
+}
+
+func TestCertificationRepository_GetCertificationByID_NotFound(t *testing.T) {
+	repo, mockDB := newTestCertificationRepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), []any{int64(999)}).Return(mockRow).Once()
+
+	cert, err := repo.GetCertificationByID(ctx, 999)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, cert)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestCertificationRepository_CreateCertification_Success(t *testing.T) {
+	repo, mockDB := newTestCertificationRepository()
+	ctx := context.Background()
+
+	cert := &model.Certification{Name: "CKA", Issuer: "CNCF", IssueDate: "2024-05-01", Color: "orange"}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 1
+		*dest[1].(*time.Time) = time.Now()
+	}).Return(nil).Once()
+
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"),
+		[]any{"CKA", "CNCF", "", "2024-05-01", "", "", "orange"}).Return(mockRow).Once()
+
+	err := repo.CreateCertification(ctx, cert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cert.ID)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestCertificationRepository_UpdateCertification_NotFound(t *testing.T) {
+	repo, mockDB := newTestCertificationRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+
+	err := repo.UpdateCertification(ctx, &model.Certification{ID: 999, Name: "CKA", Issuer: "CNCF"})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCertificationRepository_DeleteCertification_Error(t *testing.T) {
+	repo, mockDB := newTestCertificationRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, errors.New("delete failed")).Once()
+
+	err := repo.DeleteCertification(ctx, 1)
+
+	assert.Error(t, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// CVRepositoryInterface defines the interface for CV version repository
+type CVRepositoryInterface interface {
+	GetAllCVVersions(ctx context.Context) ([]model.CVVersion, error)
+	GetCVVersionByID(ctx context.Context, id int64) (*model.CVVersion, error)
+	GetActiveCVVersion(ctx context.Context) (*model.CVVersion, error)
+	CreateCVVersion(ctx context.Context, cv *model.CVVersion) error
+	ActivateCVVersion(ctx context.Context, id int64) error
+	DeactivateCVVersions(ctx context.Context) error
+	IncrementCVDownloads(ctx context.Context, id int64) error
+	DeleteCVVersion(ctx context.Context, id int64) error
+}
+
+// CVRepository implements CVRepositoryInterface
+type CVRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewCVRepository creates a new CV version repository
+func NewCVRepository(db database.PgxIface, log *zap.Logger) CVRepositoryInterface {
+	return &CVRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const cvColumns = `id, filename, url, size, hash, active, downloads, created_at`
+
+// scanCVVersion scans a row selected with cvColumns
+func scanCVVersion(row pgx.Row, cv *model.CVVersion) error {
+	return row.Scan(&cv.ID, &cv.Filename, &cv.URL, &cv.Size, &cv.Hash, &cv.Active, &cv.Downloads, &cv.CreatedAt)
+}
+
+// GetAllCVVersions retrieves the CV history, newest first
+func (r *CVRepository) GetAllCVVersions(ctx context.Context) ([]model.CVVersion, error) {
+	query := `SELECT ` + cvColumns + ` FROM cv_versions ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Failed to get CV versions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []model.CVVersion
+	for rows.Next() {
+		var cv model.CVVersion
+		if err := scanCVVersion(rows, &cv); err != nil {
+			r.log.Error("Failed to scan CV version", zap.Error(err))
+			continue
+		}
+		versions = append(versions, cv)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate CV versions", zap.Error(err))
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetCVVersionByID retrieves a CV version by ID
+func (r *CVRepository) GetCVVersionByID(ctx context.Context, id int64) (*model.CVVersion, error) {
+	query := `SELECT ` + cvColumns + ` FROM cv_versions WHERE id = $1`
+
+	var cv model.CVVersion
+	if err := scanCVVersion(r.db.QueryRow(ctx, query, id), &cv); err != nil {
+		r.log.Error("Failed to get CV version by ID", zap.Error(err), zap.Int64("id", id))
+		return nil, err
+	}
+	return &cv, nil
+}
+
+// GetActiveCVVersion retrieves the active CV version
+func (r *CVRepository) GetActiveCVVersion(ctx context.Context) (*model.CVVersion, error) {
+	query := `SELECT ` + cvColumns + ` FROM cv_versions WHERE active ORDER BY id DESC LIMIT 1`
+
+	var cv model.CVVersion
+	if err := scanCVVersion(r.db.QueryRow(ctx, query), &cv); err != nil {
+		return nil, err
+	}
+	return &cv, nil
+}
+
+// CreateCVVersion records a new, inactive CV version
+func (r *CVRepository) CreateCVVersion(ctx context.Context, cv *model.CVVersion) error {
+	query := `INSERT INTO cv_versions (filename, url, size, hash) 
+		VALUES ($1, $2, $3, $4) RETURNING id, active, downloads, created_at`
+
+	row := r.db.QueryRow(ctx, query, cv.Filename, cv.URL, cv.Size, cv.Hash)
+	if err := row.Scan(&cv.ID, &cv.Active, &cv.Downloads, &cv.CreatedAt); err != nil {
+		r.log.Error("Failed to create CV version", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ActivateCVVersion makes id the only active version. A single statement switches
+// both rows, so readers never see zero or two active versions.
+func (r *CVRepository) ActivateCVVersion(ctx context.Context, id int64) error {
+	query := `UPDATE cv_versions SET active = (id = $1) 
+		WHERE (active OR id = $1) AND EXISTS (SELECT 1 FROM cv_versions WHERE id = $1)`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to activate CV version", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeactivateCVVersions leaves no version active
+func (r *CVRepository) DeactivateCVVersions(ctx context.Context) error {
+	query := `UPDATE cv_versions SET active = false WHERE active`
+
+	if _, err := r.db.Exec(ctx, query); err != nil {
+		r.log.Error("Failed to deactivate CV versions", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// IncrementCVDownloads counts a download of a CV version
+func (r *CVRepository) IncrementCVDownloads(ctx context.Context, id int64) error {
+	query := `UPDATE cv_versions SET downloads = downloads + 1 WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to count CV download", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteCVVersion deletes a CV version
+func (r *CVRepository) DeleteCVVersion(ctx context.Context, id int64) error {
+	query := `DELETE FROM cv_versions WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete CV version", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestCVRepository creates a new test CV version repository
+func newTestCVRepository() (*CVRepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	logger := zap.NewNop()
+	repo := NewCVRepository(mockDB, logger)
+	return repo.(*CVRepository), mockDB
+}
+
+// ==================== CV Repository Tests ====================
+
+func TestCVRepository_GetAllCVVersions_Success(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	mockRows := database.NewMockRows([][]any{
+		{int64(2), "cv_2024.pdf", "/public/assets/uploads/cv/2_cv_2024.pdf", int64(2048), "bb", true, int64(7), now},
+		{int64(1), "cv_2023.pdf", "/public/assets/uploads/cv/1_cv_2023.pdf", int64(1024), "aa", false, int64(40), now},
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		data := mockRows.Data[mockRows.CurrentIndex]
+		*dest[0].(*int64) = data[0].(int64)
+		*dest[1].(*string) = data[1].(string)
+		*dest[2].(*string) = data[2].(string)
+		*dest[3].(*int64) = data[3].(int64)
+		*dest[4].(*string) = data[4].(string)
+		*dest[5].(*bool) = data[5].(bool)
+		*dest[6].(*int64) = data[6].(int64)
+		*dest[7].(*time.Time) = data[7].(time.Time)
+	}).Return(nil)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil)
+
+	mockDB.On("Query", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRows, nil).Once()
+
+	versions, err := repo.GetAllCVVersions(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.True(t, versions[0].Active)
+	assert.Equal(t, int64(40), versions[1].Downloads)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCVRepository_GetActiveCVVersion_None(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	cv, err := repo.GetActiveCVVersion(ctx)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, cv)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCVRepository_CreateCVVersion_Success(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	cv := &model.CVVersion{Filename: "cv.pdf", URL: "/public/assets/uploads/cv/1_cv.pdf", Size: 1024, Hash: "aa"}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 3
+		*dest[1].(*bool) = false
+		*dest[2].(*int64) = 0
+		*dest[3].(*time.Time) = now
+	}).Return(nil).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	err := repo.CreateCVVersion(ctx, cv)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), cv.ID)
+	assert.Equal(t, now, cv.CreatedAt)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestCVRepository_ActivateCVVersion(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(2)}).Return(pgconn.NewCommandTag("UPDATE 2"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(9)}).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+
+	assert.NoError(t, repo.ActivateCVVersion(ctx, 2))
+	assert.ErrorIs(t, repo.ActivateCVVersion(ctx, 9), pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestCVRepository_DeactivateCVVersions(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, "UPDATE cv_versions SET active = false WHERE active", []any(nil)).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+
+	assert.NoError(t, repo.DeactivateCVVersions(ctx))
+	mockDB.AssertExpectations(t)
+}
+
+func TestCVRepository_IncrementCVDownloads_Error(t *testing.T) {
+	repo, mockDB := newTestCVRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, errors.New("update failed")).Once()
+
+	err := repo.IncrementCVDownloads(ctx, 1)
+
+	assert.Error(t, err)
+	mockDB.AssertExpectations(t)
+}
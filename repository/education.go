@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// EducationRepositoryInterface defines the interface for education repository
+type EducationRepositoryInterface interface {
+	GetAllEducation(ctx context.Context) ([]model.Education, error)
+	GetEducationByID(ctx context.Context, id int64) (*model.Education, error)
+	CreateEducation(ctx context.Context, edu *model.Education) error
+	UpdateEducation(ctx context.Context, edu *model.Education) error
+	DeleteEducation(ctx context.Context, id int64) error
+}
+
+// EducationRepository implements EducationRepositoryInterface
+type EducationRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewEducationRepository creates a new education repository
+func NewEducationRepository(db database.PgxIface, log *zap.Logger) EducationRepositoryInterface {
+	return &EducationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetAllEducation retrieves all education, most recent first
+func (r *EducationRepository) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	query := `SELECT ` + educationColumns + ` FROM education ORDER BY start_date DESC NULLS LAST, created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Failed to get education", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var education []model.Education
+	for rows.Next() {
+		edu, err := scanEducation(rows)
+		if err != nil {
+			r.log.Error("Failed to scan education", zap.Error(err))
+			continue
+		}
+		education = append(education, *edu)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate education", zap.Error(err))
+		return nil, err
+	}
+	return education, nil
+}
+
+// GetEducationByID retrieves an education by ID
+func (r *EducationRepository) GetEducationByID(ctx context.Context, id int64) (*model.Education, error) {
+	query := `SELECT ` + educationColumns + ` FROM education WHERE id = $1`
+
+	edu, err := scanEducation(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		r.log.Error("Failed to get education by ID", zap.Error(err), zap.Int64("id", id))
+		return nil, err
+	}
+	return edu, nil
+}
+
+// CreateEducation creates a new education
+func (r *EducationRepository) CreateEducation(ctx context.Context, edu *model.Education) error {
+	query := `INSERT INTO education (institution, degree, field, start_date, end_date, gpa, honors, description, color) 
+		VALUES ($1, $2, $3, NULLIF($4, '')::date, NULLIF($5, '')::date, $6, $7, $8, $9) RETURNING id, created_at`
+
+	row := r.db.QueryRow(ctx, query, edu.Institution, edu.Degree, edu.Field, edu.StartDate, edu.EndDate,
+		edu.GPA, edu.Honors, edu.Description, edu.Color)
+
+	err := row.Scan(&edu.ID, &edu.CreatedAt)
+	if err != nil {
+		r.log.Error("Failed to create education", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateEducation updates an education
+func (r *EducationRepository) UpdateEducation(ctx context.Context, edu *model.Education) error {
+	query := `UPDATE education SET institution = $1, degree = $2, field = $3, start_date = NULLIF($4, '')::date, 
+		end_date = NULLIF($5, '')::date, gpa = $6, honors = $7, description = $8, color = $9 WHERE id = $10`
+
+	tag, err := r.db.Exec(ctx, query, edu.Institution, edu.Degree, edu.Field, edu.StartDate, edu.EndDate,
+		edu.GPA, edu.Honors, edu.Description, edu.Color, edu.ID)
+	if err != nil {
+		r.log.Error("Failed to update education", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteEducation deletes an education
+func (r *EducationRepository) DeleteEducation(ctx context.Context, id int64) error {
+	query := `DELETE FROM education WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete education", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// educationColumns lists the columns scanEducation reads; dates are read in model.DateLayout
+const educationColumns = `id, institution, COALESCE(degree, ''), COALESCE(field, ''), 
+	COALESCE(to_char(start_date, 'YYYY-MM-DD'), ''), COALESCE(to_char(end_date, 'YYYY-MM-DD'), ''), 
+	COALESCE(gpa, ''), COALESCE(honors, ''), COALESCE(description, ''), COALESCE(color, 'lime'), created_at`
+
+func scanEducation(row pgx.Row) (*model.Education, error) {
+	var edu model.Education
+	err := row.Scan(&edu.ID, &edu.Institution, &edu.Degree, &edu.Field, &edu.StartDate, &edu.EndDate,
+		&edu.GPA, &edu.Honors, &edu.Description, &edu.Color, &edu.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &edu, nil
+}
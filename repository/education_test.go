@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestEducationRepository creates a new test education repository
+func newTestEducationRepository() (*EducationRepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	logger := zap.NewNop()
+	repo := NewEducationRepository(mockDB, logger)
+	return repo.(*EducationRepository), mockDB
+}
+
+// ==================== Education Repository Tests ====================
+
+func TestEducationRepository_GetAllEducation_Success(t *testing.T) {
+	repo, mockDB := newTestEducationRepository()
+	ctx := context.Background()
+
+	mockRows := database.NewMockRows([][]any{
+		{int64(1), "State University", "M.Sc.", "Computer Science", "2022-09-01", "", "", "", "", "lime", time.Now()},
+		{int64(2), "State University", "B.Sc.", "Informatics", "2018-09-01", "2022-06-30", "3.85/4.00", "Cum laude", "Thesis on caching", "cyan", time.Now()},
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		data := mockRows.Data[mockRows.CurrentIndex]
+		*dest[0].(*int64) = data[0].(int64)
+		for i := 1; i <= 9; i++ {
+			*dest[i].(*string) = data[i].(string)
+		}
+		*dest[10].(*time.Time) = data[10].(time.Time)
+	}).Return(nil)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil)
+
+	mockDB.On("Query", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRows, nil).Once()
+
+	education, err := repo.GetAllEducation(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, education, 2)
+	assert.Equal(t, "M.Sc.", education[0].Degree)
+	assert.Equal(t, "Sep 2022 - Present", education[0].Period())
+	assert.Equal(t, "3.85/4.00", education[1].GPA)
+	assert.Equal(t, "Sep 2018 - Jun 2022", education[1].Period())
+	mockDB.AssertExpectations(t)
+}
+
+func TestEducationRepository_GetEducationByID_NotFound(t *testing.T) {
+	repo, mockDB := newTestEducationRepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), []any{int64(999)}).Return(mockRow).Once()
+
+	edu, err := repo.GetEducationByID(ctx, 999)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, edu)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestEducationRepository_CreateEducation_Success(t *testing.T) {
+	repo, mockDB := newTestEducationRepository()
+	ctx := context.Background()
+
+	edu := &model.Education{Institution: "State University", Degree: "B.Sc.", StartDate: "2018-09-01", Color: "lime"}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 1
+		*dest[1].(*time.Time) = time.Now()
+	}).Return(nil).Once()
+
+	// An empty end date is passed as "" and stored as NULL by the query
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"),
+		[]any{"State University", "B.Sc.", "", "2018-09-01", "", "", "", "", "lime"}).Return(mockRow).Once()
+
+	err := repo.CreateEducation(ctx, edu)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), edu.ID)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestEducationRepository_UpdateEducation_NotFound(t *testing.T) {
+	repo, mockDB := newTestEducationRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+
+	err := repo.UpdateEducation(ctx, &model.Education{ID: 999, Institution: "State University"})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestEducationRepository_DeleteEducation_Error(t *testing.T) {
+	repo, mockDB := newTestEducationRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, errors.New("delete failed")).Once()
+
+	err := repo.DeleteEducation(ctx, 1)
+
+	assert.Error(t, err)
+	mockDB.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"session-19/database"
 	"session-19/model"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +55,10 @@ func (r *ExperienceRepository) GetAllExperiences(ctx context.Context) ([]model.E
 		}
 		experiences = append(experiences, exp)
 	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate experiences", zap.Error(err))
+		return nil, err
+	}
 	return experiences, nil
 }
 
@@ -94,22 +99,28 @@ func (r *ExperienceRepository) UpdateExperience(ctx context.Context, exp *model.
 	query := `UPDATE experiences SET title = $1, organization = $2, period = $3, 
 		description = $4, type = $5, color = $6 WHERE id = $7`
 
-	_, err := r.db.Exec(ctx, query, exp.Title, exp.Organization, exp.Period,
+	tag, err := r.db.Exec(ctx, query, exp.Title, exp.Organization, exp.Period,
 		exp.Description, exp.Type, exp.Color, exp.ID)
 	if err != nil {
 		r.log.Error("Failed to update experience", zap.Error(err))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
 
 // DeleteExperience deletes an experience
 func (r *ExperienceRepository) DeleteExperience(ctx context.Context, id int64) error {
 	query := `DELETE FROM experiences WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	tag, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.log.Error("Failed to delete experience", zap.Error(err), zap.Int64("id", id))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
@@ -175,7 +175,7 @@ func TestExperienceRepository_UpdateExperience_Success(t *testing.T) {
 		Organization: "Tech Corp",
 	}
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
 
 	err := repo.UpdateExperience(ctx, exp)
 
@@ -205,7 +205,7 @@ func TestExperienceRepository_DeleteExperience_Success(t *testing.T) {
 	repo, mockDB := newTestExperienceRepository()
 	ctx := context.Background()
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
 
 	err := repo.DeleteExperience(ctx, 1)
 
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// MediaRepositoryInterface defines the interface for media library repository
+type MediaRepositoryInterface interface {
+	GetAllMedia(ctx context.Context) ([]model.Media, error)
+	GetMediaByID(ctx context.Context, id int64) (*model.Media, error)
+	GetMediaByHash(ctx context.Context, hash string) (*model.Media, error)
+	CreateMedia(ctx context.Context, media *model.Media) error
+	UpdateMedia(ctx context.Context, media *model.Media) error
+	DeleteMedia(ctx context.Context, id int64) error
+}
+
+// MediaRepository implements MediaRepositoryInterface
+type MediaRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewMediaRepository creates a new media repository
+func NewMediaRepository(db database.PgxIface, log *zap.Logger) MediaRepositoryInterface {
+	return &MediaRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+const mediaColumns = `id, filename, url, mime_type, size, width, height, hash, 
+	COALESCE(alt_text, ''), variants, created_at`
+
+// scanMedia scans a row selected with mediaColumns
+func scanMedia(row pgx.Row, m *model.Media) error {
+	return row.Scan(&m.ID, &m.Filename, &m.URL, &m.MimeType, &m.Size, &m.Width, &m.Height,
+		&m.Hash, &m.AltText, &m.Variants, &m.CreatedAt)
+}
+
+// GetAllMedia retrieves all media, newest first
+func (r *MediaRepository) GetAllMedia(ctx context.Context) ([]model.Media, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Failed to get media", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var media []model.Media
+	for rows.Next() {
+		var m model.Media
+		if err := scanMedia(rows, &m); err != nil {
+			r.log.Error("Failed to scan media", zap.Error(err))
+			continue
+		}
+		media = append(media, m)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate media", zap.Error(err))
+		return nil, err
+	}
+	return media, nil
+}
+
+// GetMediaByID retrieves a media item by ID
+func (r *MediaRepository) GetMediaByID(ctx context.Context, id int64) (*model.Media, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media WHERE id = $1`
+
+	var m model.Media
+	if err := scanMedia(r.db.QueryRow(ctx, query, id), &m); err != nil {
+		r.log.Error("Failed to get media by ID", zap.Error(err), zap.Int64("id", id))
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMediaByHash retrieves a media item by the SHA-256 of its content
+func (r *MediaRepository) GetMediaByHash(ctx context.Context, hash string) (*model.Media, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media WHERE hash = $1`
+
+	var m model.Media
+	if err := scanMedia(r.db.QueryRow(ctx, query, hash), &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateMedia creates a new media item
+func (r *MediaRepository) CreateMedia(ctx context.Context, media *model.Media) error {
+	query := `INSERT INTO media (filename, url, mime_type, size, width, height, hash, alt_text, variants) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, '[]'::jsonb)) RETURNING id, created_at`
+
+	row := r.db.QueryRow(ctx, query, media.Filename, media.URL, media.MimeType, media.Size,
+		media.Width, media.Height, media.Hash, media.AltText, media.Variants)
+
+	if err := row.Scan(&media.ID, &media.CreatedAt); err != nil {
+		r.log.Error("Failed to create media", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateMedia updates the editable fields of a media item
+func (r *MediaRepository) UpdateMedia(ctx context.Context, media *model.Media) error {
+	query := `UPDATE media SET alt_text = $1 WHERE id = $2`
+
+	tag, err := r.db.Exec(ctx, query, media.AltText, media.ID)
+	if err != nil {
+		r.log.Error("Failed to update media", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteMedia deletes a media item
+func (r *MediaRepository) DeleteMedia(ctx context.Context, id int64) error {
+	query := `DELETE FROM media WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete media", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
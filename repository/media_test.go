@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestMediaRepository creates a new test media repository
+func newTestMediaRepository() (*MediaRepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	logger := zap.NewNop()
+	repo := NewMediaRepository(mockDB, logger)
+	return repo.(*MediaRepository), mockDB
+}
+
+// fillMedia scans a media row in mediaColumns order into dest
+func fillMedia(dest []any, data []any) {
+	*dest[0].(*int64) = data[0].(int64)
+	*dest[1].(*string) = data[1].(string)
+	*dest[2].(*string) = data[2].(string)
+	*dest[3].(*string) = data[3].(string)
+	*dest[4].(*int64) = data[4].(int64)
+	*dest[5].(*int) = data[5].(int)
+	*dest[6].(*int) = data[6].(int)
+	*dest[7].(*string) = data[7].(string)
+	*dest[8].(*string) = data[8].(string)
+	*dest[9].(*model.ImageVariants) = data[9].(model.ImageVariants)
+	*dest[10].(*time.Time) = data[10].(time.Time)
+}
+
+// ==================== Media Repository Tests ====================
+
+func TestMediaRepository_GetAllMedia_Success(t *testing.T) {
+	repo, mockDB := newTestMediaRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	mockRows := database.NewMockRows([][]any{
+		{int64(2), "cover.png", "/public/assets/uploads/media/2_cover.png", "image/png", int64(2048), 800, 600, "bb", "Cover", model.ImageVariants{{URL: "/public/assets/uploads/media/2_cover_320w.png", Width: 320, Type: "image/png"}}, now},
+		{int64(1), "me.jpg", "/public/assets/uploads/media/1_me.jpg", "image/jpeg", int64(1024), 400, 400, "aa", "", model.ImageVariants(nil), now},
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		fillMedia(args.Get(0).([]any), mockRows.Data[mockRows.CurrentIndex])
+	}).Return(nil)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil)
+
+	mockDB.On("Query", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRows, nil).Once()
+
+	media, err := repo.GetAllMedia(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, media, 2)
+	assert.Equal(t, "cover.png", media[0].Filename)
+	assert.Equal(t, 800, media[0].Width)
+	assert.Equal(t, "Cover", media[0].AltText)
+	assert.Equal(t, "me.jpg", media[1].Filename)
+	mockDB.AssertExpectations(t)
+}
+
+func TestMediaRepository_GetMediaByHash_NotFound(t *testing.T) {
+	repo, mockDB := newTestMediaRepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), []any{"cc"}).Return(mockRow).Once()
+
+	media, err := repo.GetMediaByHash(ctx, "cc")
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, media)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestMediaRepository_CreateMedia_Success(t *testing.T) {
+	repo, mockDB := newTestMediaRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	media := &model.Media{
+		Filename: "cover.png",
+		URL:      "/public/assets/uploads/media/2_cover.png",
+		MimeType: "image/png",
+		Size:     2048,
+		Hash:     "bb",
+	}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 2
+		*dest[1].(*time.Time) = now
+	}).Return(nil).Once()
+
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	err := repo.CreateMedia(ctx, media)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), media.ID)
+	assert.Equal(t, now, media.CreatedAt)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestMediaRepository_UpdateMedia_NotFound(t *testing.T) {
+	repo, mockDB := newTestMediaRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+
+	err := repo.UpdateMedia(ctx, &model.Media{ID: 9, AltText: "Alt"})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestMediaRepository_DeleteMedia(t *testing.T) {
+	repo, mockDB := newTestMediaRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(1)}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(2)}).Return(pgconn.CommandTag{}, errors.New("delete failed")).Once()
+
+	assert.NoError(t, repo.DeleteMedia(ctx, 1))
+	assert.Error(t, repo.DeleteMedia(ctx, 2))
+	mockDB.AssertExpectations(t)
+}
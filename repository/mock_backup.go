@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"session-19/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBackupRepository is a mock implementation of BackupRepositoryInterface using testify/mock
+type MockBackupRepository struct {
+	mock.Mock
+}
+
+func (m *MockBackupRepository) Snapshot(ctx context.Context) (*model.BackupData, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BackupData), args.Error(1)
+}
+
+func (m *MockBackupRepository) Replace(ctx context.Context, data *model.BackupData) ([]string, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
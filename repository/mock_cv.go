@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"session-19/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCVRepository is a mock implementation of CVRepositoryInterface using testify/mock
+type MockCVRepository struct {
+	mock.Mock
+}
+
+func (m *MockCVRepository) GetAllCVVersions(ctx context.Context) ([]model.CVVersion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CVVersion), args.Error(1)
+}
+
+func (m *MockCVRepository) GetCVVersionByID(ctx context.Context, id int64) (*model.CVVersion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CVVersion), args.Error(1)
+}
+
+func (m *MockCVRepository) GetActiveCVVersion(ctx context.Context) (*model.CVVersion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CVVersion), args.Error(1)
+}
+
+func (m *MockCVRepository) CreateCVVersion(ctx context.Context, cv *model.CVVersion) error {
+	args := m.Called(ctx, cv)
+	return args.Error(0)
+}
+
+func (m *MockCVRepository) ActivateCVVersion(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCVRepository) DeactivateCVVersions(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockCVRepository) IncrementCVDownloads(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCVRepository) DeleteCVVersion(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
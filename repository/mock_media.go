@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"session-19/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMediaRepository is a mock implementation of MediaRepositoryInterface using testify/mock
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) GetAllMedia(ctx context.Context) ([]model.Media, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetMediaByID(ctx context.Context, id int64) (*model.Media, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetMediaByHash(ctx context.Context, hash string) (*model.Media, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) CreateMedia(ctx context.Context, media *model.Media) error {
+	args := m.Called(ctx, media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) UpdateMedia(ctx context.Context, media *model.Media) error {
+	args := m.Called(ctx, media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) DeleteMedia(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -31,6 +31,11 @@ func (m *MockPortfolioRepository) UpdateProfile(ctx context.Context, profile *mo
 	return args.Error(0)
 }
 
+func (m *MockPortfolioRepository) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	args := m.Called(ctx, cvURL)
+	return args.Error(0)
+}
+
 // Experience operations
 func (m *MockPortfolioRepository) GetAllExperiences(ctx context.Context) ([]model.Experience, error) {
 	args := m.Called(ctx)
@@ -120,6 +125,19 @@ func (m *MockPortfolioRepository) GetProjectByID(ctx context.Context, id int64)
 	return args.Get(0).(*model.Project), args.Error(1)
 }
 
+func (m *MockPortfolioRepository) GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Project), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) ProjectSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockPortfolioRepository) CreateProject(ctx context.Context, project *model.Project) error {
 	args := m.Called(ctx, project)
 	return args.Error(0)
@@ -152,6 +170,19 @@ func (m *MockPortfolioRepository) GetPublicationByID(ctx context.Context, id int
 	return args.Get(0).(*model.Publication), args.Error(1)
 }
 
+func (m *MockPortfolioRepository) GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Publication), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) PublicationSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	args := m.Called(ctx, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockPortfolioRepository) CreatePublication(ctx context.Context, pub *model.Publication) error {
 	args := m.Called(ctx, pub)
 	return args.Error(0)
@@ -167,6 +198,160 @@ func (m *MockPortfolioRepository) DeletePublication(ctx context.Context, id int6
 	return args.Error(0)
 }
 
+// Education operations
+func (m *MockPortfolioRepository) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Education), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetEducationByID(ctx context.Context, id int64) (*model.Education, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Education), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) CreateEducation(ctx context.Context, edu *model.Education) error {
+	args := m.Called(ctx, edu)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateEducation(ctx context.Context, edu *model.Education) error {
+	args := m.Called(ctx, edu)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) DeleteEducation(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Certification operations
+func (m *MockPortfolioRepository) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Certification), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Certification), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) CreateCertification(ctx context.Context, cert *model.Certification) error {
+	args := m.Called(ctx, cert)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateCertification(ctx context.Context, cert *model.Certification) error {
+	args := m.Called(ctx, cert)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) DeleteCertification(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Testimonial operations
+func (m *MockPortfolioRepository) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Testimonial), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Testimonial), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Testimonial), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) CreateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	args := m.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) UpdateTestimonialStatus(ctx context.Context, id int64, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) DeleteTestimonial(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.TestimonialInvite), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetTestimonialInviteByToken(ctx context.Context, token string) (*model.TestimonialInvite, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TestimonialInvite), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) CreateTestimonialInvite(ctx context.Context, invite *model.TestimonialInvite) error {
+	args := m.Called(ctx, invite)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) DeleteTestimonialInvite(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) SubmitTestimonial(ctx context.Context, token string, t *model.Testimonial) error {
+	args := m.Called(ctx, token, t)
+	return args.Error(0)
+}
+
+// SEO operations
+func (m *MockPortfolioRepository) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SEOSettings), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) UpdateSEOSettings(ctx context.Context, settings *model.SEOSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
 // Portfolio operations
 func (m *MockPortfolioRepository) GetPortfolioData(ctx context.Context) (*model.PortfolioData, error) {
 	args := m.Called(ctx)
@@ -175,3 +360,12 @@ func (m *MockPortfolioRepository) GetPortfolioData(ctx context.Context) (*model.
 	}
 	return args.Get(0).(*model.PortfolioData), args.Error(1)
 }
+
+// UpdateInTransaction returns the configured error, or else runs fn against the mock itself
+func (m *MockPortfolioRepository) UpdateInTransaction(ctx context.Context, fn func(tx PortfolioRepositoryInterface) error) error {
+	args := m.Called(ctx, fn)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
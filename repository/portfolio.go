@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"session-19/database"
 	"session-19/model"
+	"sync"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +17,7 @@ type PortfolioRepositoryInterface interface {
 	GetProfile(ctx context.Context) (*model.Profile, error)
 	CreateProfile(ctx context.Context, profile *model.Profile) error
 	UpdateProfile(ctx context.Context, profile *model.Profile) error
+	UpdateProfileCVURL(ctx context.Context, cvURL string) error
 
 	// Experience operations
 	GetAllExperiences(ctx context.Context) ([]model.Experience, error)
@@ -33,6 +37,8 @@ type PortfolioRepositoryInterface interface {
 	// Project operations
 	GetAllProjects(ctx context.Context) ([]model.Project, error)
 	GetProjectByID(ctx context.Context, id int64) (*model.Project, error)
+	GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error)
+	ProjectSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error)
 	CreateProject(ctx context.Context, project *model.Project) error
 	UpdateProject(ctx context.Context, project *model.Project) error
 	DeleteProject(ctx context.Context, id int64) error
@@ -40,33 +46,85 @@ type PortfolioRepositoryInterface interface {
 	// Publication operations
 	GetAllPublications(ctx context.Context) ([]model.Publication, error)
 	GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error)
+	GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error)
+	PublicationSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error)
 	CreatePublication(ctx context.Context, pub *model.Publication) error
 	UpdatePublication(ctx context.Context, pub *model.Publication) error
 	DeletePublication(ctx context.Context, id int64) error
 
+	// Education operations
+	GetAllEducation(ctx context.Context) ([]model.Education, error)
+	GetEducationByID(ctx context.Context, id int64) (*model.Education, error)
+	CreateEducation(ctx context.Context, edu *model.Education) error
+	UpdateEducation(ctx context.Context, edu *model.Education) error
+	DeleteEducation(ctx context.Context, id int64) error
+
+	// Certification operations
+	GetAllCertifications(ctx context.Context) ([]model.Certification, error)
+	GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error)
+	CreateCertification(ctx context.Context, cert *model.Certification) error
+	UpdateCertification(ctx context.Context, cert *model.Certification) error
+	DeleteCertification(ctx context.Context, id int64) error
+
+	// Testimonial operations
+	GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error)
+	CreateTestimonial(ctx context.Context, t *model.Testimonial) error
+	UpdateTestimonial(ctx context.Context, t *model.Testimonial) error
+	UpdateTestimonialStatus(ctx context.Context, id int64, status string) error
+	DeleteTestimonial(ctx context.Context, id int64) error
+	GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error)
+	GetTestimonialInviteByToken(ctx context.Context, token string) (*model.TestimonialInvite, error)
+	CreateTestimonialInvite(ctx context.Context, invite *model.TestimonialInvite) error
+	DeleteTestimonialInvite(ctx context.Context, id int64) error
+	SubmitTestimonial(ctx context.Context, token string, t *model.Testimonial) error
+
+	// SEO settings
+	GetSEOSettings(ctx context.Context) (*model.SEOSettings, error)
+	UpdateSEOSettings(ctx context.Context, settings *model.SEOSettings) error
+
 	// Full portfolio data
 	GetPortfolioData(ctx context.Context) (*model.PortfolioData, error)
+
+	// UpdateInTransaction runs fn against a repository bound to a single transaction,
+	// committing when fn returns nil and rolling back otherwise
+	UpdateInTransaction(ctx context.Context, fn func(tx PortfolioRepositoryInterface) error) error
 }
 
 // PortfolioRepository implements PortfolioRepositoryInterface by aggregating other repositories
 type PortfolioRepository struct {
-	profileRepo     ProfileRepositoryInterface
-	experienceRepo  ExperienceRepositoryInterface
-	skillRepo       SkillRepositoryInterface
-	projectRepo     ProjectRepositoryInterface
-	publicationRepo PublicationRepositoryInterface
-	log             *zap.Logger
+	profileRepo       ProfileRepositoryInterface
+	experienceRepo    ExperienceRepositoryInterface
+	skillRepo         SkillRepositoryInterface
+	projectRepo       ProjectRepositoryInterface
+	publicationRepo   PublicationRepositoryInterface
+	educationRepo     EducationRepositoryInterface
+	certificationRepo CertificationRepositoryInterface
+	testimonialRepo   TestimonialRepositoryInterface
+	seoRepo           SEORepositoryInterface
+	db                database.PgxIface
+	log               *zap.Logger
+
+	// sequential loads GetPortfolioData sections one at a time,
+	// as a transaction's single connection cannot run queries concurrently
+	sequential bool
 }
 
 // NewPortfolioRepository creates a new portfolio repository
 func NewPortfolioRepository(db database.PgxIface, log *zap.Logger) PortfolioRepositoryInterface {
 	return &PortfolioRepository{
-		profileRepo:     NewProfileRepository(db, log),
-		experienceRepo:  NewExperienceRepository(db, log),
-		skillRepo:       NewSkillRepository(db, log),
-		projectRepo:     NewProjectRepository(db, log),
-		publicationRepo: NewPublicationRepository(db, log),
-		log:             log,
+		profileRepo:       NewProfileRepository(db, log),
+		experienceRepo:    NewExperienceRepository(db, log),
+		skillRepo:         NewSkillRepository(db, log),
+		projectRepo:       NewProjectRepository(db, log),
+		publicationRepo:   NewPublicationRepository(db, log),
+		educationRepo:     NewEducationRepository(db, log),
+		certificationRepo: NewCertificationRepository(db, log),
+		testimonialRepo:   NewTestimonialRepository(db, log),
+		seoRepo:           NewSEORepository(db, log),
+		db:                db,
+		log:               log,
 	}
 }
 
@@ -85,6 +143,11 @@ func (r *PortfolioRepository) UpdateProfile(ctx context.Context, profile *model.
 	return r.profileRepo.UpdateProfile(ctx, profile)
 }
 
+// UpdateProfileCVURL points the main profile at a CV
+func (r *PortfolioRepository) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	return r.profileRepo.UpdateProfileCVURL(ctx, cvURL)
+}
+
 // GetAllExperiences retrieves all experiences
 func (r *PortfolioRepository) GetAllExperiences(ctx context.Context) ([]model.Experience, error) {
 	return r.experienceRepo.GetAllExperiences(ctx)
@@ -150,6 +213,16 @@ func (r *PortfolioRepository) GetProjectByID(ctx context.Context, id int64) (*mo
 	return r.projectRepo.GetProjectByID(ctx, id)
 }
 
+// GetProjectBySlug retrieves a project by its current or a previous slug
+func (r *PortfolioRepository) GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error) {
+	return r.projectRepo.GetProjectBySlug(ctx, slug)
+}
+
+// ProjectSlugTaken reports whether slug names, or used to name, another project
+func (r *PortfolioRepository) ProjectSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	return r.projectRepo.ProjectSlugTaken(ctx, slug, excludeID)
+}
+
 // CreateProject creates a new project
 func (r *PortfolioRepository) CreateProject(ctx context.Context, project *model.Project) error {
 	return r.projectRepo.CreateProject(ctx, project)
@@ -175,6 +248,16 @@ func (r *PortfolioRepository) GetPublicationByID(ctx context.Context, id int64)
 	return r.publicationRepo.GetPublicationByID(ctx, id)
 }
 
+// GetPublicationBySlug retrieves a publication by its current or a previous slug
+func (r *PortfolioRepository) GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error) {
+	return r.publicationRepo.GetPublicationBySlug(ctx, slug)
+}
+
+// PublicationSlugTaken reports whether slug names, or used to name, another publication
+func (r *PortfolioRepository) PublicationSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	return r.publicationRepo.PublicationSlugTaken(ctx, slug, excludeID)
+}
+
 // CreatePublication creates a new publication
 func (r *PortfolioRepository) CreatePublication(ctx context.Context, pub *model.Publication) error {
 	return r.publicationRepo.CreatePublication(ctx, pub)
@@ -190,58 +273,282 @@ func (r *PortfolioRepository) DeletePublication(ctx context.Context, id int64) e
 	return r.publicationRepo.DeletePublication(ctx, id)
 }
 
-// GetPortfolioData retrieves all portfolio data in one call
+// GetAllEducation retrieves all education
+func (r *PortfolioRepository) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	return r.educationRepo.GetAllEducation(ctx)
+}
+
+// GetEducationByID retrieves an education by ID
+func (r *PortfolioRepository) GetEducationByID(ctx context.Context, id int64) (*model.Education, error) {
+	return r.educationRepo.GetEducationByID(ctx, id)
+}
+
+// CreateEducation creates a new education
+func (r *PortfolioRepository) CreateEducation(ctx context.Context, edu *model.Education) error {
+	return r.educationRepo.CreateEducation(ctx, edu)
+}
+
+// UpdateEducation updates an education
+func (r *PortfolioRepository) UpdateEducation(ctx context.Context, edu *model.Education) error {
+	return r.educationRepo.UpdateEducation(ctx, edu)
+}
+
+// DeleteEducation deletes an education
+func (r *PortfolioRepository) DeleteEducation(ctx context.Context, id int64) error {
+	return r.educationRepo.DeleteEducation(ctx, id)
+}
+
+// GetAllCertifications retrieves all certifications
+func (r *PortfolioRepository) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	return r.certificationRepo.GetAllCertifications(ctx)
+}
+
+// GetCertificationByID retrieves a certification by ID
+func (r *PortfolioRepository) GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error) {
+	return r.certificationRepo.GetCertificationByID(ctx, id)
+}
+
+// CreateCertification creates a new certification
+func (r *PortfolioRepository) CreateCertification(ctx context.Context, cert *model.Certification) error {
+	return r.certificationRepo.CreateCertification(ctx, cert)
+}
+
+// UpdateCertification updates a certification
+func (r *PortfolioRepository) UpdateCertification(ctx context.Context, cert *model.Certification) error {
+	return r.certificationRepo.UpdateCertification(ctx, cert)
+}
+
+// DeleteCertification deletes a certification
+func (r *PortfolioRepository) DeleteCertification(ctx context.Context, id int64) error {
+	return r.certificationRepo.DeleteCertification(ctx, id)
+}
+
+// GetAllTestimonials retrieves all testimonials
+func (r *PortfolioRepository) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	return r.testimonialRepo.GetAllTestimonials(ctx)
+}
+
+// GetApprovedTestimonials retrieves the approved testimonials
+func (r *PortfolioRepository) GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	return r.testimonialRepo.GetApprovedTestimonials(ctx)
+}
+
+// GetTestimonialByID retrieves a testimonial by ID
+func (r *PortfolioRepository) GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error) {
+	return r.testimonialRepo.GetTestimonialByID(ctx, id)
+}
+
+// CreateTestimonial creates a new testimonial
+func (r *PortfolioRepository) CreateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	return r.testimonialRepo.CreateTestimonial(ctx, t)
+}
+
+// UpdateTestimonial updates a testimonial
+func (r *PortfolioRepository) UpdateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	return r.testimonialRepo.UpdateTestimonial(ctx, t)
+}
+
+// UpdateTestimonialStatus approves or rejects a testimonial
+func (r *PortfolioRepository) UpdateTestimonialStatus(ctx context.Context, id int64, status string) error {
+	return r.testimonialRepo.UpdateTestimonialStatus(ctx, id, status)
+}
+
+// DeleteTestimonial deletes a testimonial
+func (r *PortfolioRepository) DeleteTestimonial(ctx context.Context, id int64) error {
+	return r.testimonialRepo.DeleteTestimonial(ctx, id)
+}
+
+// GetAllTestimonialInvites retrieves all testimonial invites
+func (r *PortfolioRepository) GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error) {
+	return r.testimonialRepo.GetAllTestimonialInvites(ctx)
+}
+
+// GetTestimonialInviteByToken retrieves a testimonial invite by its token
+func (r *PortfolioRepository) GetTestimonialInviteByToken(ctx context.Context, token string) (*model.TestimonialInvite, error) {
+	return r.testimonialRepo.GetTestimonialInviteByToken(ctx, token)
+}
+
+// CreateTestimonialInvite creates a new testimonial invite
+func (r *PortfolioRepository) CreateTestimonialInvite(ctx context.Context, invite *model.TestimonialInvite) error {
+	return r.testimonialRepo.CreateTestimonialInvite(ctx, invite)
+}
+
+// DeleteTestimonialInvite deletes a testimonial invite
+func (r *PortfolioRepository) DeleteTestimonialInvite(ctx context.Context, id int64) error {
+	return r.testimonialRepo.DeleteTestimonialInvite(ctx, id)
+}
+
+// SubmitTestimonial stores a testimonial submitted through an invite
+func (r *PortfolioRepository) SubmitTestimonial(ctx context.Context, token string, t *model.Testimonial) error {
+	return r.testimonialRepo.SubmitTestimonial(ctx, token, t)
+}
+
+// GetSEOSettings retrieves the SEO settings
+func (r *PortfolioRepository) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	return r.seoRepo.GetSEOSettings(ctx)
+}
+
+// UpdateSEOSettings creates or replaces the SEO settings
+func (r *PortfolioRepository) UpdateSEOSettings(ctx context.Context, settings *model.SEOSettings) error {
+	return r.seoRepo.UpdateSEOSettings(ctx, settings)
+}
+
+// sectionLoader loads one portfolio section into the shared PortfolioData
+type sectionLoader struct {
+	section string
+	load    func(ctx context.Context) error
+}
+
+// GetPortfolioData retrieves all portfolio data in one call.
+// Sections are fetched concurrently; a section that fails is left empty and
+// reported in Failures so callers can still render the others.
 func (r *PortfolioRepository) GetPortfolioData(ctx context.Context) (*model.PortfolioData, error) {
-	data := &model.PortfolioData{}
-
-	// Get profile
-	profile, err := r.GetProfile(ctx)
-	if err != nil {
-		r.log.Warn("No profile found, using empty profile", zap.Error(err))
-		data.Profile = model.Profile{}
-	} else {
-		data.Profile = *profile
+	data := &model.PortfolioData{
+		Experiences:    []model.Experience{},
+		Skills:         make(map[string][]model.Skill),
+		Projects:       []model.Project{},
+		Publications:   []model.Publication{},
+		Education:      []model.Education{},
+		Certifications: []model.Certification{},
+		Testimonials:   []model.Testimonial{},
 	}
 
-	// Get experiences
-	experiences, err := r.GetAllExperiences(ctx)
-	if err != nil {
-		r.log.Warn("Failed to get experiences", zap.Error(err))
-		data.Experiences = []model.Experience{}
-	} else {
-		data.Experiences = experiences
+	// Each loader writes a distinct field of data, so they need no locking
+	loaders := []sectionLoader{
+		{model.SectionProfile, func(ctx context.Context) error {
+			profile, err := r.GetProfile(ctx)
+			if errors.Is(err, pgx.ErrNoRows) {
+				r.log.Warn("No profile found, using empty profile")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			data.Profile = *profile
+			return nil
+		}},
+		{model.SectionExperiences, func(ctx context.Context) error {
+			experiences, err := r.GetAllExperiences(ctx)
+			if err != nil {
+				return err
+			}
+			if experiences != nil {
+				data.Experiences = experiences
+			}
+			return nil
+		}},
+		{model.SectionSkills, func(ctx context.Context) error {
+			skills, err := r.GetAllSkills(ctx)
+			if err != nil {
+				return err
+			}
+			// Group by category
+			for _, skill := range skills {
+				data.Skills[skill.Category] = append(data.Skills[skill.Category], skill)
+			}
+			return nil
+		}},
+		{model.SectionProjects, func(ctx context.Context) error {
+			projects, err := r.GetAllProjects(ctx)
+			if err != nil {
+				return err
+			}
+			if projects != nil {
+				data.Projects = projects
+			}
+			return nil
+		}},
+		{model.SectionPublications, func(ctx context.Context) error {
+			publications, err := r.GetAllPublications(ctx)
+			if err != nil {
+				return err
+			}
+			if publications != nil {
+				data.Publications = publications
+			}
+			return nil
+		}},
+		{model.SectionEducation, func(ctx context.Context) error {
+			education, err := r.GetAllEducation(ctx)
+			if err != nil {
+				return err
+			}
+			if education != nil {
+				data.Education = education
+			}
+			return nil
+		}},
+		{model.SectionCertifications, func(ctx context.Context) error {
+			certifications, err := r.GetAllCertifications(ctx)
+			if err != nil {
+				return err
+			}
+			if certifications != nil {
+				data.Certifications = certifications
+			}
+			return nil
+		}},
+		{model.SectionTestimonials, func(ctx context.Context) error {
+			testimonials, err := r.GetApprovedTestimonials(ctx)
+			if err != nil {
+				return err
+			}
+			if testimonials != nil {
+				data.Testimonials = testimonials
+			}
+			return nil
+		}},
+		{model.SectionSEO, func(ctx context.Context) error {
+			settings, err := r.GetSEOSettings(ctx)
+			// Until the settings are saved, every field uses its default
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			data.SEO = *settings
+			return nil
+		}},
 	}
 
-	// Get skills and group by category
-	skills, err := r.GetAllSkills(ctx)
-	if err != nil {
-		r.log.Warn("Failed to get skills", zap.Error(err))
-		data.Skills = make(map[string][]model.Skill)
-	} else {
-		skillMap := make(map[string][]model.Skill)
-		for _, skill := range skills {
-			skillMap[skill.Category] = append(skillMap[skill.Category], skill)
+	errs := make([]error, len(loaders))
+	var wg sync.WaitGroup
+	for i, loader := range loaders {
+		if r.sequential {
+			errs[i] = loader.load(ctx)
+			continue
 		}
-		data.Skills = skillMap
+		wg.Go(func() {
+			errs[i] = loader.load(ctx)
+		})
 	}
+	wg.Wait()
 
-	// Get projects
-	projects, err := r.GetAllProjects(ctx)
-	if err != nil {
-		r.log.Warn("Failed to get projects", zap.Error(err))
-		data.Projects = []model.Project{}
-	} else {
-		data.Projects = projects
+	// A cancelled request is not a partial result, it is no result at all
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// Get publications
-	publications, err := r.GetAllPublications(ctx)
-	if err != nil {
-		r.log.Warn("Failed to get publications", zap.Error(err))
-		data.Publications = []model.Publication{}
-	} else {
-		data.Publications = publications
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		r.log.Warn("Failed to load portfolio section", zap.String("section", loaders[i].section), zap.Error(err))
+		data.Failures = append(data.Failures, model.SectionFailure{
+			Section: loaders[i].section,
+			Message: "Failed to load " + loaders[i].section,
+		})
 	}
 
 	return data, nil
 }
+
+// UpdateInTransaction runs fn against a repository bound to a single transaction
+func (r *PortfolioRepository) UpdateInTransaction(ctx context.Context, fn func(tx PortfolioRepositoryInterface) error) error {
+	return database.WithTx(ctx, r.db, func(tx database.PgxIface) error {
+		txRepo := NewPortfolioRepository(tx, r.log).(*PortfolioRepository)
+		txRepo.sequential = true
+		return fn(txRepo)
+	})
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// stubSections implements the read side of every section repository.
+// The embedded interfaces are nil; only the Get methods are used by GetPortfolioData.
+type stubSections struct {
+	ProfileRepositoryInterface
+	ExperienceRepositoryInterface
+	SkillRepositoryInterface
+	ProjectRepositoryInterface
+	PublicationRepositoryInterface
+	EducationRepositoryInterface
+	CertificationRepositoryInterface
+	TestimonialRepositoryInterface
+	SEORepositoryInterface
+
+	profileErr, experiencesErr, skillsErr, projectsErr, publicationsErr, educationErr, certificationsErr, testimonialsErr, seoErr error
+	// before runs at the start of every section load
+	before func(ctx context.Context)
+}
+
+func (s *stubSections) enter(ctx context.Context) {
+	if s.before != nil {
+		s.before(ctx)
+	}
+}
+
+func (s *stubSections) GetProfile(ctx context.Context) (*model.Profile, error) {
+	s.enter(ctx)
+	if s.profileErr != nil {
+		return nil, s.profileErr
+	}
+	return &model.Profile{ID: 1, Name: "John Doe"}, nil
+}
+
+func (s *stubSections) GetAllExperiences(ctx context.Context) ([]model.Experience, error) {
+	s.enter(ctx)
+	return []model.Experience{{ID: 1, Title: "Engineer"}}, s.experiencesErr
+}
+
+func (s *stubSections) GetAllSkills(ctx context.Context) ([]model.Skill, error) {
+	s.enter(ctx)
+	return []model.Skill{{ID: 1, Category: "Languages", Name: "Go"}, {ID: 2, Category: "Languages", Name: "SQL"}}, s.skillsErr
+}
+
+func (s *stubSections) GetAllProjects(ctx context.Context) ([]model.Project, error) {
+	s.enter(ctx)
+	if s.projectsErr != nil {
+		return nil, s.projectsErr
+	}
+	return []model.Project{{ID: 1, Title: "Portfolio"}}, nil
+}
+
+func (s *stubSections) GetAllPublications(ctx context.Context) ([]model.Publication, error) {
+	s.enter(ctx)
+	return nil, s.publicationsErr
+}
+
+func (s *stubSections) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	s.enter(ctx)
+	if s.educationErr != nil {
+		return nil, s.educationErr
+	}
+	return []model.Education{{ID: 1, Institution: "State University", StartDate: "2018-09-01"}}, nil
+}
+
+func (s *stubSections) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	s.enter(ctx)
+	return nil, s.certificationsErr
+}
+
+func (s *stubSections) GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	s.enter(ctx)
+	if s.testimonialsErr != nil {
+		return nil, s.testimonialsErr
+	}
+	return []model.Testimonial{{ID: 1, Author: "Jane Smith", Status: model.TestimonialApproved}}, nil
+}
+
+func (s *stubSections) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	s.enter(ctx)
+	if s.seoErr != nil {
+		return nil, s.seoErr
+	}
+	return &model.SEOSettings{SiteURL: "https://example.com"}, nil
+}
+
+// newTestPortfolioRepository creates a portfolio repository over stub section repositories
+func newTestPortfolioRepository(stub *stubSections) *PortfolioRepository {
+	return &PortfolioRepository{
+		profileRepo:       stub,
+		experienceRepo:    stub,
+		skillRepo:         stub,
+		projectRepo:       stub,
+		publicationRepo:   stub,
+		educationRepo:     stub,
+		certificationRepo: stub,
+		testimonialRepo:   stub,
+		seoRepo:           stub,
+		log:               zap.NewNop(),
+	}
+}
+
+// ==================== Portfolio Repository Tests ====================
+
+func TestPortfolioRepository_GetPortfolioData_Success(t *testing.T) {
+	repo := newTestPortfolioRepository(&stubSections{})
+
+	data, err := repo.GetPortfolioData(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, data.Partial())
+	assert.Equal(t, "John Doe", data.Profile.Name)
+	assert.Len(t, data.Experiences, 1)
+	assert.Len(t, data.Skills["Languages"], 2)
+	assert.Len(t, data.Projects, 1)
+	assert.NotNil(t, data.Publications)
+	assert.Empty(t, data.Publications)
+	assert.Len(t, data.Education, 1)
+	assert.NotNil(t, data.Certifications)
+	assert.Empty(t, data.Certifications)
+	assert.Len(t, data.Testimonials, 1)
+	assert.Equal(t, "https://example.com", data.SEO.SiteURL)
+}
+
+func TestPortfolioRepository_GetPortfolioData_PartialFailure(t *testing.T) {
+	repo := newTestPortfolioRepository(&stubSections{
+		projectsErr:       errors.New("relation \"projects\" does not exist"),
+		skillsErr:         errors.New("connection reset"),
+		certificationsErr: errors.New("relation \"certifications\" does not exist"),
+		testimonialsErr:   errors.New("relation \"testimonials\" does not exist"),
+	})
+
+	data, err := repo.GetPortfolioData(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, data.Partial())
+	assert.Equal(t, []model.SectionFailure{
+		{Section: model.SectionSkills, Message: "Failed to load skills"},
+		{Section: model.SectionProjects, Message: "Failed to load projects"},
+		{Section: model.SectionCertifications, Message: "Failed to load certifications"},
+		{Section: model.SectionTestimonials, Message: "Failed to load testimonials"},
+	}, data.Failures)
+	assert.True(t, data.SectionFailed(model.SectionProjects))
+	assert.False(t, data.SectionFailed(model.SectionExperiences))
+	assert.Empty(t, data.Projects)
+	assert.Len(t, data.Experiences, 1)
+	assert.Len(t, data.Education, 1)
+	assert.NotNil(t, data.Testimonials)
+	assert.Empty(t, data.Testimonials)
+}
+
+func TestPortfolioRepository_GetPortfolioData_MissingProfileIsNotAFailure(t *testing.T) {
+	repo := newTestPortfolioRepository(&stubSections{profileErr: pgx.ErrNoRows})
+
+	data, err := repo.GetPortfolioData(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, data.Partial())
+	assert.Equal(t, model.Profile{}, data.Profile)
+}
+
+func TestPortfolioRepository_GetPortfolioData_MissingSEOSettingsIsNotAFailure(t *testing.T) {
+	repo := newTestPortfolioRepository(&stubSections{seoErr: pgx.ErrNoRows})
+
+	data, err := repo.GetPortfolioData(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, data.Partial())
+	assert.Equal(t, model.SEOSettings{}, data.SEO)
+}
+
+func TestPortfolioRepository_GetPortfolioData_LoadsSectionsConcurrently(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(9)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	repo := newTestPortfolioRepository(&stubSections{
+		before: func(ctx context.Context) {
+			started.Done()
+			// Sequential loading would never get past the first section
+			select {
+			case <-allStarted:
+			case <-time.After(time.Second):
+			}
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = repo.GetPortfolioData(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-allStarted:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("sections were not loaded concurrently")
+	}
+	<-done
+}
+
+func TestPortfolioRepository_GetPortfolioData_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	repo := newTestPortfolioRepository(&stubSections{
+		before: func(context.Context) { cancel() },
+	})
+
+	data, err := repo.GetPortfolioData(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, data)
+}
+
+// ==================== Transaction Tests ====================
+
+func newTestTxRepository() (PortfolioRepositoryInterface, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	mockDB.On("Begin", mock.Anything).Return(database.NewMockTx(mockDB), nil).Once()
+	// WithTx always defers a rollback; after a commit it is a no-op
+	mockDB.On("Rollback", mock.Anything).Return(nil).Once()
+	return NewPortfolioRepository(mockDB, zap.NewNop()), mockDB
+}
+
+func TestPortfolioRepository_UpdateInTransaction_Commits(t *testing.T) {
+	repo, mockDB := newTestTxRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(1)}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(2)}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	err := repo.UpdateInTransaction(ctx, func(tx PortfolioRepositoryInterface) error {
+		if err := tx.DeleteSkill(ctx, 1); err != nil {
+			return err
+		}
+		return tx.DeleteProject(ctx, 2)
+	})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestPortfolioRepository_UpdateInTransaction_RollsBackOnError(t *testing.T) {
+	repo, mockDB := newTestTxRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{int64(1)}).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+
+	err := repo.UpdateInTransaction(ctx, func(tx PortfolioRepositoryInterface) error {
+		return tx.DeleteSkill(ctx, 1)
+	})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertNotCalled(t, "Commit", mock.Anything)
+	mockDB.AssertExpectations(t)
+}
+
+func TestPortfolioRepository_UpdateInTransaction_BeginFails(t *testing.T) {
+	mockDB := new(database.MockDB)
+	mockDB.On("Begin", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	repo := NewPortfolioRepository(mockDB, zap.NewNop())
+
+	called := false
+	err := repo.UpdateInTransaction(context.Background(), func(PortfolioRepositoryInterface) error {
+		called = true
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.False(t, called)
+	mockDB.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"session-19/database"
 	"session-19/model"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -13,6 +14,7 @@ type ProfileRepositoryInterface interface {
 	GetProfile(ctx context.Context) (*model.Profile, error)
 	CreateProfile(ctx context.Context, profile *model.Profile) error
 	UpdateProfile(ctx context.Context, profile *model.Profile) error
+	UpdateProfileCVURL(ctx context.Context, cvURL string) error
 }
 
 // ProfileRepository implements ProfileRepositoryInterface
@@ -69,11 +71,30 @@ func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *model.Pr
 		email = $5, linkedin_url = $6, github_url = $7, cv_url = $8, updated_at = CURRENT_TIMESTAMP 
 		WHERE id = $9`
 
-	_, err := r.db.Exec(ctx, query, profile.Name, profile.Title, profile.Description,
+	tag, err := r.db.Exec(ctx, query, profile.Name, profile.Title, profile.Description,
 		profile.PhotoURL, profile.Email, profile.LinkedInURL, profile.GithubURL, profile.CVURL, profile.ID)
 	if err != nil {
 		r.log.Error("Failed to update profile", zap.Error(err))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateProfileCVURL points the main profile at a CV
+func (r *ProfileRepository) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	query := `UPDATE profile SET cv_url = $1, updated_at = CURRENT_TIMESTAMP 
+		WHERE id = (SELECT id FROM profile LIMIT 1)`
+
+	tag, err := r.db.Exec(ctx, query, cvURL)
+	if err != nil {
+		r.log.Error("Failed to update profile CV URL", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
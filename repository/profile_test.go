@@ -132,7 +132,7 @@ func TestProfileRepository_UpdateProfile_Success(t *testing.T) {
 		Email: "john.updated@example.com",
 	}
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
 
 	err := repo.UpdateProfile(ctx, profile)
 
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"session-19/database"
 	"session-19/model"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -12,6 +14,8 @@ import (
 type ProjectRepositoryInterface interface {
 	GetAllProjects(ctx context.Context) ([]model.Project, error)
 	GetProjectByID(ctx context.Context, id int64) (*model.Project, error)
+	GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error)
+	ProjectSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error)
 	CreateProject(ctx context.Context, project *model.Project) error
 	UpdateProject(ctx context.Context, project *model.Project) error
 	DeleteProject(ctx context.Context, id int64) error
@@ -33,10 +37,7 @@ func NewProjectRepository(db database.PgxIface, log *zap.Logger) ProjectReposito
 
 // GetAllProjects retrieves all projects
 func (r *ProjectRepository) GetAllProjects(ctx context.Context) ([]model.Project, error) {
-	query := `SELECT id, title, COALESCE(description, ''), COALESCE(image_url, ''), 
-		COALESCE(project_url, ''), COALESCE(github_url, ''), COALESCE(tech_stack, ''), 
-		COALESCE(color, 'cyan'), COALESCE(profile_id, 0), created_at 
-		FROM projects ORDER BY created_at DESC`
+	query := `SELECT ` + projectColumns + ` FROM projects ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
@@ -47,45 +48,71 @@ func (r *ProjectRepository) GetAllProjects(ctx context.Context) ([]model.Project
 
 	var projects []model.Project
 	for rows.Next() {
-		var p model.Project
-		err := rows.Scan(&p.ID, &p.Title, &p.Description, &p.ImageURL, &p.ProjectURL,
-			&p.GithubURL, &p.TechStack, &p.Color, &p.ProfileID, &p.CreatedAt)
+		p, err := scanProject(rows)
 		if err != nil {
 			r.log.Error("Failed to scan project", zap.Error(err))
 			continue
 		}
-		projects = append(projects, p)
+		projects = append(projects, *p)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate projects", zap.Error(err))
+		return nil, err
 	}
 	return projects, nil
 }
 
 // GetProjectByID retrieves a project by ID
 func (r *ProjectRepository) GetProjectByID(ctx context.Context, id int64) (*model.Project, error) {
-	query := `SELECT id, title, COALESCE(description, ''), COALESCE(image_url, ''), 
-		COALESCE(project_url, ''), COALESCE(github_url, ''), COALESCE(tech_stack, ''), 
-		COALESCE(color, 'cyan'), COALESCE(profile_id, 0), created_at 
-		FROM projects WHERE id = $1`
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
 
-	row := r.db.QueryRow(ctx, query, id)
-	var p model.Project
-	err := row.Scan(&p.ID, &p.Title, &p.Description, &p.ImageURL, &p.ProjectURL,
-		&p.GithubURL, &p.TechStack, &p.Color, &p.ProfileID, &p.CreatedAt)
+	p, err := scanProject(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		r.log.Error("Failed to get project by ID", zap.Error(err), zap.Int64("id", id))
 		return nil, err
 	}
-	return &p, nil
+	return p, nil
+}
+
+// GetProjectBySlug retrieves the project a slug names now or named before it was changed;
+// callers compare the returned Slug to tell the two apart
+func (r *ProjectRepository) GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = COALESCE(
+		(SELECT id FROM projects WHERE slug = $1),
+		(SELECT project_id FROM project_slug_redirects WHERE slug = $1))`
+
+	p, err := scanProject(r.db.QueryRow(ctx, query, slug))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.log.Error("Failed to get project by slug", zap.Error(err), zap.String("slug", slug))
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// ProjectSlugTaken reports whether slug names, or used to name, a project other than excludeID
+func (r *ProjectRepository) ProjectSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM projects WHERE slug = $1 AND id <> $2)
+		OR EXISTS (SELECT 1 FROM project_slug_redirects WHERE slug = $1 AND project_id <> $2)`
+
+	var taken bool
+	if err := r.db.QueryRow(ctx, query, slug, excludeID).Scan(&taken); err != nil {
+		r.log.Error("Failed to check project slug", zap.Error(err), zap.String("slug", slug))
+		return false, err
+	}
+	return taken, nil
 }
 
 // CreateProject creates a new project
 func (r *ProjectRepository) CreateProject(ctx context.Context, project *model.Project) error {
-	query := `INSERT INTO projects (title, description, image_url, project_url, github_url, tech_stack, color, profile_id) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+	query := `INSERT INTO projects (title, description, image_url, image_variants, project_url, github_url, tech_stack, color, profile_id, slug, content) 
+		VALUES ($1, $2, $3, COALESCE($4, '[]'::jsonb), $5, $6, $7, $8, $9, NULLIF($10, ''), $11) RETURNING id, created_at, updated_at`
 
-	row := r.db.QueryRow(ctx, query, project.Title, project.Description, project.ImageURL,
-		project.ProjectURL, project.GithubURL, project.TechStack, project.Color, project.ProfileID)
+	row := r.db.QueryRow(ctx, query, project.Title, project.Description, project.ImageURL, project.ImageVariants,
+		project.ProjectURL, project.GithubURL, project.TechStack, project.Color, project.ProfileID, project.Slug, project.Content)
 
-	err := row.Scan(&project.ID, &project.CreatedAt)
+	err := row.Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt)
 	if err != nil {
 		r.log.Error("Failed to create project", zap.Error(err))
 		return err
@@ -93,27 +120,75 @@ func (r *ProjectRepository) CreateProject(ctx context.Context, project *model.Pr
 	return nil
 }
 
-// UpdateProject updates a project
+// UpdateProject updates a project. An empty Slug keeps the current one and is filled in;
+// a changed slug leaves a redirect from the old one.
 func (r *ProjectRepository) UpdateProject(ctx context.Context, project *model.Project) error {
-	query := `UPDATE projects SET title = $1, description = $2, image_url = $3, project_url = $4, 
-		github_url = $5, tech_stack = $6, color = $7, profile_id = $8 WHERE id = $9`
+	err := database.WithTx(ctx, r.db, func(tx database.PgxIface) error {
+		var oldSlug string
+		err := tx.QueryRow(ctx, `SELECT COALESCE(slug, '') FROM projects WHERE id = $1 FOR UPDATE`, project.ID).Scan(&oldSlug)
+		if err != nil {
+			return err
+		}
+		if project.Slug == "" {
+			project.Slug = oldSlug
+		}
 
-	_, err := r.db.Exec(ctx, query, project.Title, project.Description, project.ImageURL,
-		project.ProjectURL, project.GithubURL, project.TechStack, project.Color, project.ProfileID, project.ID)
-	if err != nil {
-		r.log.Error("Failed to update project", zap.Error(err))
+		query := `UPDATE projects SET title = $1, description = $2, image_url = $3, 
+			image_variants = COALESCE($4, '[]'::jsonb), project_url = $5, github_url = $6, tech_stack = $7, 
+			color = $8, profile_id = $9, slug = NULLIF($10, ''), content = $11, 
+			updated_at = CURRENT_TIMESTAMP WHERE id = $12`
+		if _, err := tx.Exec(ctx, query, project.Title, project.Description, project.ImageURL, project.ImageVariants,
+			project.ProjectURL, project.GithubURL, project.TechStack, project.Color, project.ProfileID,
+			project.Slug, project.Content, project.ID); err != nil {
+			return err
+		}
+
+		if project.Slug == oldSlug {
+			return nil
+		}
+		// The new slug stops redirecting anywhere else, the old one now redirects here
+		if _, err := tx.Exec(ctx, `DELETE FROM project_slug_redirects WHERE slug = $1`, project.Slug); err != nil {
+			return err
+		}
+		if oldSlug == "" {
+			return nil
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO project_slug_redirects (slug, project_id) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET project_id = EXCLUDED.project_id`, oldSlug, project.ID)
 		return err
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log.Error("Failed to update project", zap.Error(err))
 	}
-	return nil
+	return err
 }
 
 // DeleteProject deletes a project
 func (r *ProjectRepository) DeleteProject(ctx context.Context, id int64) error {
 	query := `DELETE FROM projects WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	tag, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.log.Error("Failed to delete project", zap.Error(err), zap.Int64("id", id))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
+
+// projectColumns lists the columns scanProject reads
+const projectColumns = `id, title, COALESCE(description, ''), COALESCE(image_url, ''), 
+	image_variants, COALESCE(project_url, ''), COALESCE(github_url, ''), COALESCE(tech_stack, ''), 
+	COALESCE(color, 'cyan'), COALESCE(profile_id, 0), created_at, COALESCE(slug, ''), COALESCE(content, ''), 
+	COALESCE(updated_at, created_at)`
+
+func scanProject(row pgx.Row) (*model.Project, error) {
+	var p model.Project
+	err := row.Scan(&p.ID, &p.Title, &p.Description, &p.ImageURL, &p.ImageVariants, &p.ProjectURL,
+		&p.GithubURL, &p.TechStack, &p.Color, &p.ProfileID, &p.CreatedAt, &p.Slug, &p.Content, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
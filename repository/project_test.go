@@ -5,9 +5,11 @@ import (
 	"errors"
 	"session-19/database"
 	"session-19/model"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,8 +32,8 @@ func TestProjectRepository_GetAllProjects_Success(t *testing.T) {
 
 	now := time.Now()
 	mockRows := database.NewMockRows([][]any{
-		{int64(1), "Project 1", "Description 1", "/image1.jpg", "https://project1.com", "https://github.com/project1", "Go, React", "cyan", int64(1), now},
-		{int64(2), "Project 2", "Description 2", "/image2.jpg", "https://project2.com", "https://github.com/project2", "Python, Vue", "blue", int64(1), now},
+		{int64(1), "Project 1", "Description 1", "/image1.jpg", model.ImageVariants{{URL: "/image1_320w.jpg", Width: 320, Type: "image/jpeg"}}, "https://project1.com", "https://github.com/project1", "Go, React", "cyan", int64(1), now},
+		{int64(2), "Project 2", "Description 2", "/image2.jpg", model.ImageVariants(nil), "https://project2.com", "https://github.com/project2", "Python, Vue", "blue", int64(1), now},
 	})
 	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 		dest := args.Get(0).([]any)
@@ -40,12 +42,13 @@ func TestProjectRepository_GetAllProjects_Success(t *testing.T) {
 		*dest[1].(*string) = data[1].(string)
 		*dest[2].(*string) = data[2].(string)
 		*dest[3].(*string) = data[3].(string)
-		*dest[4].(*string) = data[4].(string)
+		*dest[4].(*model.ImageVariants) = data[4].(model.ImageVariants)
 		*dest[5].(*string) = data[5].(string)
 		*dest[6].(*string) = data[6].(string)
 		*dest[7].(*string) = data[7].(string)
-		*dest[8].(*int64) = data[8].(int64)
-		*dest[9].(*time.Time) = data[9].(time.Time)
+		*dest[8].(*string) = data[8].(string)
+		*dest[9].(*int64) = data[9].(int64)
+		*dest[10].(*time.Time) = data[10].(time.Time)
 	}).Return(nil)
 	mockRows.On("Close").Return()
 	mockRows.On("Err").Return(nil)
@@ -86,12 +89,13 @@ func TestProjectRepository_GetProjectByID_Success(t *testing.T) {
 		*dest[1].(*string) = "Project 1"
 		*dest[2].(*string) = "Description 1"
 		*dest[3].(*string) = "/image1.jpg"
-		*dest[4].(*string) = "https://project1.com"
-		*dest[5].(*string) = "https://github.com/project1"
-		*dest[6].(*string) = "Go, React"
-		*dest[7].(*string) = "cyan"
-		*dest[8].(*int64) = 1
-		*dest[9].(*time.Time) = now
+		*dest[4].(*model.ImageVariants) = model.ImageVariants{{URL: "/image1_320w.jpg", Width: 320, Type: "image/jpeg"}}
+		*dest[5].(*string) = "https://project1.com"
+		*dest[6].(*string) = "https://github.com/project1"
+		*dest[7].(*string) = "Go, React"
+		*dest[8].(*string) = "cyan"
+		*dest[9].(*int64) = 1
+		*dest[10].(*time.Time) = now
 	}).Return(nil).Once()
 
 	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
@@ -101,6 +105,7 @@ func TestProjectRepository_GetProjectByID_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, project)
 	assert.Equal(t, "Project 1", project.Title)
+	assert.Equal(t, "/image1_320w.jpg 320w", project.ImageVariants.Srcset("image/jpeg"))
 	mockDB.AssertExpectations(t)
 	mockRow.AssertExpectations(t)
 }
@@ -176,7 +181,20 @@ func TestProjectRepository_CreateProject_Error(t *testing.T) {
 	mockRow.AssertExpectations(t)
 }
 
-func TestProjectRepository_UpdateProject_Success(t *testing.T) {
+// expectSlugUpdate expects an update transaction that finds the row with oldSlug,
+// or that fails with scanErr
+func expectSlugUpdate(mockDB *database.MockDB, oldSlug string, scanErr error) {
+	mockDB.On("Begin", mock.Anything).Return(database.NewMockTx(mockDB), nil).Once()
+	mockDB.On("Rollback", mock.Anything).Return(nil).Once()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).([]any)[0].(*string) = oldSlug
+	}).Return(scanErr).Once()
+	mockDB.On("QueryRow", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+}
+
+func TestProjectRepository_UpdateProject_KeepsSlug(t *testing.T) {
 	repo, mockDB := newTestProjectRepository()
 	ctx := context.Background()
 
@@ -186,7 +204,29 @@ func TestProjectRepository_UpdateProject_Success(t *testing.T) {
 		Description: "Updated Description",
 	}
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	expectSlugUpdate(mockDB, "portfolio", nil)
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	mockDB.On("Commit", ctx).Return(nil).Once()
+
+	err := repo.UpdateProject(ctx, project)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "portfolio", project.Slug)
+	mockDB.AssertExpectations(t)
+}
+
+func TestProjectRepository_UpdateProject_ChangedSlugRedirects(t *testing.T) {
+	repo, mockDB := newTestProjectRepository()
+	ctx := context.Background()
+
+	project := &model.Project{ID: 1, Title: "New Name", Description: "Description", Slug: "new-name"}
+
+	expectSlugUpdate(mockDB, "old-name", nil)
+	mockDB.On("Exec", ctx, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE") }), mock.Anything).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"new-name"}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"old-name", int64(1)}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Commit", ctx).Return(nil).Once()
 
 	err := repo.UpdateProject(ctx, project)
 
@@ -194,6 +234,18 @@ func TestProjectRepository_UpdateProject_Success(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestProjectRepository_UpdateProject_NotFound(t *testing.T) {
+	repo, mockDB := newTestProjectRepository()
+	ctx := context.Background()
+
+	expectSlugUpdate(mockDB, "", pgx.ErrNoRows)
+
+	err := repo.UpdateProject(ctx, &model.Project{ID: 999, Title: "Missing"})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
 func TestProjectRepository_UpdateProject_Error(t *testing.T) {
 	repo, mockDB := newTestProjectRepository()
 	ctx := context.Background()
@@ -204,6 +256,7 @@ func TestProjectRepository_UpdateProject_Error(t *testing.T) {
 		Description: "Updated Description",
 	}
 
+	expectSlugUpdate(mockDB, "portfolio", nil)
 	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, errors.New("update failed")).Once()
 
 	err := repo.UpdateProject(ctx, project)
@@ -212,11 +265,28 @@ func TestProjectRepository_UpdateProject_Error(t *testing.T) {
 	mockDB.AssertExpectations(t)
 }
 
+func TestProjectRepository_ProjectSlugTaken(t *testing.T) {
+	repo, mockDB := newTestProjectRepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).([]any)[0].(*bool) = true
+	}).Return(nil).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), []any{"portfolio", int64(3)}).Return(mockRow).Once()
+
+	taken, err := repo.ProjectSlugTaken(ctx, "portfolio", 3)
+
+	assert.NoError(t, err)
+	assert.True(t, taken)
+	mockDB.AssertExpectations(t)
+}
+
 func TestProjectRepository_DeleteProject_Success(t *testing.T) {
 	repo, mockDB := newTestProjectRepository()
 	ctx := context.Background()
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
 
 	err := repo.DeleteProject(ctx, 1)
 
@@ -235,3 +305,15 @@ func TestProjectRepository_DeleteProject_Error(t *testing.T) {
 	assert.Error(t, err)
 	mockDB.AssertExpectations(t)
 }
+
+func TestProjectRepository_DeleteProject_NotFound(t *testing.T) {
+	repo, mockDB := newTestProjectRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+
+	err := repo.DeleteProject(ctx, 999)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
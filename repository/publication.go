@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"session-19/database"
 	"session-19/model"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -12,6 +14,8 @@ import (
 type PublicationRepositoryInterface interface {
 	GetAllPublications(ctx context.Context) ([]model.Publication, error)
 	GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error)
+	GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error)
+	PublicationSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error)
 	CreatePublication(ctx context.Context, pub *model.Publication) error
 	UpdatePublication(ctx context.Context, pub *model.Publication) error
 	DeletePublication(ctx context.Context, id int64) error
@@ -33,9 +37,7 @@ func NewPublicationRepository(db database.PgxIface, log *zap.Logger) Publication
 
 // GetAllPublications retrieves all publications
 func (r *PublicationRepository) GetAllPublications(ctx context.Context) ([]model.Publication, error) {
-	query := `SELECT id, title, COALESCE(authors, ''), COALESCE(journal, ''), COALESCE(year, 0), 
-		COALESCE(description, ''), COALESCE(image_url, ''), COALESCE(publication_url, ''), 
-		COALESCE(color, 'red'), created_at FROM publications ORDER BY year DESC, created_at DESC`
+	query := `SELECT ` + publicationColumns + ` FROM publications ORDER BY year DESC, created_at DESC`
 
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
@@ -46,44 +48,74 @@ func (r *PublicationRepository) GetAllPublications(ctx context.Context) ([]model
 
 	var publications []model.Publication
 	for rows.Next() {
-		var p model.Publication
-		err := rows.Scan(&p.ID, &p.Title, &p.Authors, &p.Journal, &p.Year,
-			&p.Description, &p.ImageURL, &p.PublicationURL, &p.Color, &p.CreatedAt)
+		p, err := scanPublication(rows)
 		if err != nil {
 			r.log.Error("Failed to scan publication", zap.Error(err))
 			continue
 		}
-		publications = append(publications, p)
+		publications = append(publications, *p)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate publications", zap.Error(err))
+		return nil, err
 	}
 	return publications, nil
 }
 
 // GetPublicationByID retrieves a publication by ID
 func (r *PublicationRepository) GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error) {
-	query := `SELECT id, title, COALESCE(authors, ''), COALESCE(journal, ''), COALESCE(year, 0), 
-		COALESCE(description, ''), COALESCE(image_url, ''), COALESCE(publication_url, ''), 
-		COALESCE(color, 'red'), created_at FROM publications WHERE id = $1`
+	query := `SELECT ` + publicationColumns + ` FROM publications WHERE id = $1`
 
-	row := r.db.QueryRow(ctx, query, id)
-	var p model.Publication
-	err := row.Scan(&p.ID, &p.Title, &p.Authors, &p.Journal, &p.Year,
-		&p.Description, &p.ImageURL, &p.PublicationURL, &p.Color, &p.CreatedAt)
+	p, err := scanPublication(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		r.log.Error("Failed to get publication by ID", zap.Error(err), zap.Int64("id", id))
 		return nil, err
 	}
-	return &p, nil
+	return p, nil
+}
+
+// GetPublicationBySlug retrieves the publication a slug names now or named before it was changed;
+// callers compare the returned Slug to tell the two apart
+func (r *PublicationRepository) GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error) {
+	query := `SELECT ` + publicationColumns + ` FROM publications WHERE id = COALESCE(
+		(SELECT id FROM publications WHERE slug = $1),
+		(SELECT publication_id FROM publication_slug_redirects WHERE slug = $1))`
+
+	p, err := scanPublication(r.db.QueryRow(ctx, query, slug))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.log.Error("Failed to get publication by slug", zap.Error(err), zap.String("slug", slug))
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// PublicationSlugTaken reports whether slug names, or used to name, a publication other than excludeID
+func (r *PublicationRepository) PublicationSlugTaken(ctx context.Context, slug string, excludeID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM publications WHERE slug = $1 AND id <> $2)
+		OR EXISTS (SELECT 1 FROM publication_slug_redirects WHERE slug = $1 AND publication_id <> $2)`
+
+	var taken bool
+	if err := r.db.QueryRow(ctx, query, slug, excludeID).Scan(&taken); err != nil {
+		r.log.Error("Failed to check publication slug", zap.Error(err), zap.String("slug", slug))
+		return false, err
+	}
+	return taken, nil
 }
 
 // CreatePublication creates a new publication
 func (r *PublicationRepository) CreatePublication(ctx context.Context, pub *model.Publication) error {
-	query := `INSERT INTO publications (title, authors, journal, year, description, image_url, publication_url, color) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+	query := `INSERT INTO publications (title, authors, journal, year, description, image_url, image_variants, publication_url, color, slug, content, 
+		venue_type, volume, pages, doi) 
+		VALUES ($1, COALESCE($2, '[]'::jsonb), $3, $4, $5, $6, COALESCE($7, '[]'::jsonb), $8, $9, NULLIF($10, ''), $11, $12, $13, $14, NULLIF($15, '')) 
+		RETURNING id, created_at, updated_at`
 
 	row := r.db.QueryRow(ctx, query, pub.Title, pub.Authors, pub.Journal, pub.Year,
-		pub.Description, pub.ImageURL, pub.PublicationURL, pub.Color)
+		pub.Description, pub.ImageURL, pub.ImageVariants, pub.PublicationURL, pub.Color, pub.Slug, pub.Content,
+		pub.VenueType, pub.Volume, pub.Pages, pub.DOI)
 
-	err := row.Scan(&pub.ID, &pub.CreatedAt)
+	err := row.Scan(&pub.ID, &pub.CreatedAt, &pub.UpdatedAt)
 	if err != nil {
 		r.log.Error("Failed to create publication", zap.Error(err))
 		return err
@@ -91,27 +123,77 @@ func (r *PublicationRepository) CreatePublication(ctx context.Context, pub *mode
 	return nil
 }
 
-// UpdatePublication updates a publication
+// UpdatePublication updates a publication. An empty Slug keeps the current one and is filled in;
+// a changed slug leaves a redirect from the old one.
 func (r *PublicationRepository) UpdatePublication(ctx context.Context, pub *model.Publication) error {
-	query := `UPDATE publications SET title = $1, authors = $2, journal = $3, year = $4, 
-		description = $5, image_url = $6, publication_url = $7, color = $8 WHERE id = $9`
+	err := database.WithTx(ctx, r.db, func(tx database.PgxIface) error {
+		var oldSlug string
+		err := tx.QueryRow(ctx, `SELECT COALESCE(slug, '') FROM publications WHERE id = $1 FOR UPDATE`, pub.ID).Scan(&oldSlug)
+		if err != nil {
+			return err
+		}
+		if pub.Slug == "" {
+			pub.Slug = oldSlug
+		}
 
-	_, err := r.db.Exec(ctx, query, pub.Title, pub.Authors, pub.Journal, pub.Year,
-		pub.Description, pub.ImageURL, pub.PublicationURL, pub.Color, pub.ID)
-	if err != nil {
-		r.log.Error("Failed to update publication", zap.Error(err))
+		query := `UPDATE publications SET title = $1, authors = COALESCE($2, '[]'::jsonb), journal = $3, year = $4, 
+			description = $5, image_url = $6, image_variants = COALESCE($7, '[]'::jsonb), 
+			publication_url = $8, color = $9, slug = NULLIF($10, ''), content = $11, 
+			venue_type = $12, volume = $13, pages = $14, doi = NULLIF($15, ''), 
+			updated_at = CURRENT_TIMESTAMP WHERE id = $16`
+		if _, err := tx.Exec(ctx, query, pub.Title, pub.Authors, pub.Journal, pub.Year,
+			pub.Description, pub.ImageURL, pub.ImageVariants, pub.PublicationURL, pub.Color,
+			pub.Slug, pub.Content, pub.VenueType, pub.Volume, pub.Pages, pub.DOI, pub.ID); err != nil {
+			return err
+		}
+
+		if pub.Slug == oldSlug {
+			return nil
+		}
+		// The new slug stops redirecting anywhere else, the old one now redirects here
+		if _, err := tx.Exec(ctx, `DELETE FROM publication_slug_redirects WHERE slug = $1`, pub.Slug); err != nil {
+			return err
+		}
+		if oldSlug == "" {
+			return nil
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO publication_slug_redirects (slug, publication_id) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET publication_id = EXCLUDED.publication_id`, oldSlug, pub.ID)
 		return err
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log.Error("Failed to update publication", zap.Error(err))
 	}
-	return nil
+	return err
 }
 
 // DeletePublication deletes a publication
 func (r *PublicationRepository) DeletePublication(ctx context.Context, id int64) error {
 	query := `DELETE FROM publications WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	tag, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.log.Error("Failed to delete publication", zap.Error(err), zap.Int64("id", id))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
+
+// publicationColumns lists the columns scanPublication reads
+const publicationColumns = `id, title, authors, COALESCE(journal, ''), COALESCE(year, 0), 
+	COALESCE(description, ''), COALESCE(image_url, ''), image_variants, COALESCE(publication_url, ''), 
+	COALESCE(color, 'red'), created_at, COALESCE(slug, ''), COALESCE(content, ''), 
+	COALESCE(updated_at, created_at), venue_type, COALESCE(volume, ''), COALESCE(pages, ''), COALESCE(doi, '')`
+
+func scanPublication(row pgx.Row) (*model.Publication, error) {
+	var p model.Publication
+	err := row.Scan(&p.ID, &p.Title, &p.Authors, &p.Journal, &p.Year,
+		&p.Description, &p.ImageURL, &p.ImageVariants, &p.PublicationURL, &p.Color, &p.CreatedAt, &p.Slug, &p.Content, &p.UpdatedAt,
+		&p.VenueType, &p.Volume, &p.Pages, &p.DOI)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
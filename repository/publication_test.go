@@ -5,6 +5,7 @@ import (
 	"errors"
 	"session-19/database"
 	"session-19/model"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,22 +31,23 @@ func TestPublicationRepository_GetAllPublications_Success(t *testing.T) {
 
 	now := time.Now()
 	mockRows := database.NewMockRows([][]any{
-		{int64(1), "Publication 1", "Author 1, Author 2", "Journal 1", 2024, "Description 1", "/image1.jpg", "https://pub1.com", "red", now},
-		{int64(2), "Publication 2", "Author 3", "Journal 2", 2023, "Description 2", "/image2.jpg", "https://pub2.com", "blue", now},
+		{int64(1), "Publication 1", model.Authors{{Name: "Author 1", IsMe: true}, {Name: "Author 2"}}, "Journal 1", 2024, "Description 1", "/image1.jpg", model.ImageVariants(nil), "https://pub1.com", "red", now},
+		{int64(2), "Publication 2", model.Authors{{Name: "Author 3"}}, "Journal 2", 2023, "Description 2", "/image2.jpg", model.ImageVariants(nil), "https://pub2.com", "blue", now},
 	})
 	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 		dest := args.Get(0).([]any)
 		data := mockRows.Data[mockRows.CurrentIndex]
 		*dest[0].(*int64) = data[0].(int64)
 		*dest[1].(*string) = data[1].(string)
-		*dest[2].(*string) = data[2].(string)
+		*dest[2].(*model.Authors) = data[2].(model.Authors)
 		*dest[3].(*string) = data[3].(string)
 		*dest[4].(*int) = data[4].(int)
 		*dest[5].(*string) = data[5].(string)
 		*dest[6].(*string) = data[6].(string)
-		*dest[7].(*string) = data[7].(string)
+		*dest[7].(*model.ImageVariants) = data[7].(model.ImageVariants)
 		*dest[8].(*string) = data[8].(string)
-		*dest[9].(*time.Time) = data[9].(time.Time)
+		*dest[9].(*string) = data[9].(string)
+		*dest[10].(*time.Time) = data[10].(time.Time)
 	}).Return(nil)
 	mockRows.On("Close").Return()
 	mockRows.On("Err").Return(nil)
@@ -58,6 +60,8 @@ func TestPublicationRepository_GetAllPublications_Success(t *testing.T) {
 	assert.Len(t, publications, 2)
 	assert.Equal(t, "Publication 1", publications[0].Title)
 	assert.Equal(t, "Publication 2", publications[1].Title)
+	assert.Equal(t, "Author 1, Author 2", publications[0].Authors.String())
+	assert.True(t, publications[0].Authors[0].IsMe)
 	mockDB.AssertExpectations(t)
 }
 
@@ -84,14 +88,15 @@ func TestPublicationRepository_GetPublicationByID_Success(t *testing.T) {
 		dest := args.Get(0).([]any)
 		*dest[0].(*int64) = 1
 		*dest[1].(*string) = "Publication 1"
-		*dest[2].(*string) = "Author 1, Author 2"
+		*dest[2].(*model.Authors) = model.Authors{{Name: "Author 1"}, {Name: "Author 2", ORCID: "0000-0002-1825-0097"}}
 		*dest[3].(*string) = "Journal 1"
 		*dest[4].(*int) = 2024
 		*dest[5].(*string) = "Description 1"
 		*dest[6].(*string) = "/image1.jpg"
-		*dest[7].(*string) = "https://pub1.com"
-		*dest[8].(*string) = "red"
-		*dest[9].(*time.Time) = now
+		*dest[7].(*model.ImageVariants) = nil
+		*dest[8].(*string) = "https://pub1.com"
+		*dest[9].(*string) = "red"
+		*dest[10].(*time.Time) = now
 	}).Return(nil).Once()
 
 	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
@@ -102,6 +107,7 @@ func TestPublicationRepository_GetPublicationByID_Success(t *testing.T) {
 	assert.NotNil(t, publication)
 	assert.Equal(t, "Publication 1", publication.Title)
 	assert.Equal(t, 2024, publication.Year)
+	assert.Equal(t, "0000-0002-1825-0097", publication.Authors[1].ORCID)
 	mockDB.AssertExpectations(t)
 	mockRow.AssertExpectations(t)
 }
@@ -130,7 +136,7 @@ func TestPublicationRepository_CreatePublication_Success(t *testing.T) {
 	now := time.Now()
 	publication := &model.Publication{
 		Title:          "New Publication",
-		Authors:        "Author 1, Author 2",
+		Authors:        model.Authors{{Name: "Author 1"}, {Name: "Author 2"}},
 		Journal:        "Journal Name",
 		Year:           2024,
 		Description:    "Publication description",
@@ -162,7 +168,7 @@ func TestPublicationRepository_CreatePublication_Error(t *testing.T) {
 
 	publication := &model.Publication{
 		Title:   "New Publication",
-		Authors: "Author 1",
+		Authors: model.Authors{{Name: "Author 1"}},
 		Journal: "Journal Name",
 		Year:    2024,
 	}
@@ -179,20 +185,26 @@ func TestPublicationRepository_CreatePublication_Error(t *testing.T) {
 	mockRow.AssertExpectations(t)
 }
 
-func TestPublicationRepository_UpdatePublication_Success(t *testing.T) {
+func TestPublicationRepository_UpdatePublication_ChangedSlugRedirects(t *testing.T) {
 	repo, mockDB := newTestPublicationRepository()
 	ctx := context.Background()
 
 	publication := &model.Publication{
 		ID:          1,
 		Title:       "Updated Publication",
-		Authors:     "Author 1, Author 2",
+		Authors:     model.Authors{{Name: "Author 1"}, {Name: "Author 2"}},
 		Journal:     "Updated Journal",
 		Year:        2024,
 		Description: "Updated description",
+		Slug:        "updated-publication",
 	}
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	expectSlugUpdate(mockDB, "draft-publication", nil)
+	mockDB.On("Exec", ctx, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE") }), mock.Anything).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"updated-publication"}).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{"draft-publication", int64(1)}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	mockDB.On("Commit", ctx).Return(nil).Once()
 
 	err := repo.UpdatePublication(ctx, publication)
 
@@ -207,16 +219,18 @@ func TestPublicationRepository_UpdatePublication_Error(t *testing.T) {
 	publication := &model.Publication{
 		ID:      1,
 		Title:   "Updated Publication",
-		Authors: "Author 1",
+		Authors: model.Authors{{Name: "Author 1"}},
 		Journal: "Updated Journal",
 		Year:    2024,
 	}
 
+	expectSlugUpdate(mockDB, "updated-publication", nil)
 	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, errors.New("update failed")).Once()
 
 	err := repo.UpdatePublication(ctx, publication)
 
 	assert.Error(t, err)
+	assert.Equal(t, "updated-publication", publication.Slug)
 	mockDB.AssertExpectations(t)
 }
 
@@ -224,7 +238,7 @@ func TestPublicationRepository_DeletePublication_Success(t *testing.T) {
 	repo, mockDB := newTestPublicationRepository()
 	ctx := context.Background()
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
 
 	err := repo.DeletePublication(ctx, 1)
 
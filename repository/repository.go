@@ -10,6 +10,9 @@ import (
 type Repository struct {
 	PortfolioRepo PortfolioRepositoryInterface
 	UserRepo      UserRepositoryInterface
+	MediaRepo     MediaRepositoryInterface
+	CVRepo        CVRepositoryInterface
+	BackupRepo    BackupRepositoryInterface
 }
 
 // NewRepository creates a new repository with all sub-repositories
@@ -17,5 +20,8 @@ func NewRepository(db database.PgxIface, log *zap.Logger) Repository {
 	return Repository{
 		PortfolioRepo: NewPortfolioRepository(db, log),
 		UserRepo:      NewUserRepository(db, log),
+		MediaRepo:     NewMediaRepository(db, log),
+		CVRepo:        NewCVRepository(db, log),
+		BackupRepo:    NewBackupRepository(db, log),
 	}
 }
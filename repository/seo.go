@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// SEORepositoryInterface defines the interface for the SEO settings repository
+type SEORepositoryInterface interface {
+	GetSEOSettings(ctx context.Context) (*model.SEOSettings, error)
+	UpdateSEOSettings(ctx context.Context, settings *model.SEOSettings) error
+}
+
+// SEORepository implements SEORepositoryInterface
+type SEORepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewSEORepository creates a new SEO settings repository
+func NewSEORepository(db database.PgxIface, log *zap.Logger) SEORepositoryInterface {
+	return &SEORepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetSEOSettings retrieves the SEO settings; pgx.ErrNoRows means none were saved yet
+func (r *SEORepository) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	query := `SELECT COALESCE(site_url, ''), COALESCE(title, ''), COALESCE(description, ''), 
+		COALESCE(image_url, ''), COALESCE(twitter_handle, ''), COALESCE(robots, ''), updated_at 
+		FROM seo_settings WHERE id = 1`
+
+	var s model.SEOSettings
+	err := r.db.QueryRow(ctx, query).Scan(&s.SiteURL, &s.Title, &s.Description, &s.ImageURL, &s.TwitterHandle, &s.Robots, &s.UpdatedAt)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.log.Error("Failed to get SEO settings", zap.Error(err))
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateSEOSettings creates or replaces the SEO settings
+func (r *SEORepository) UpdateSEOSettings(ctx context.Context, settings *model.SEOSettings) error {
+	query := `INSERT INTO seo_settings (id, site_url, title, description, image_url, twitter_handle, robots) 
+		VALUES (1, $1, $2, $3, $4, $5, $6) 
+		ON CONFLICT (id) DO UPDATE SET site_url = EXCLUDED.site_url, title = EXCLUDED.title, 
+		description = EXCLUDED.description, image_url = EXCLUDED.image_url, 
+		twitter_handle = EXCLUDED.twitter_handle, robots = EXCLUDED.robots, updated_at = CURRENT_TIMESTAMP 
+		RETURNING updated_at`
+
+	row := r.db.QueryRow(ctx, query, settings.SiteURL, settings.Title, settings.Description,
+		settings.ImageURL, settings.TwitterHandle, settings.Robots)
+	if err := row.Scan(&settings.UpdatedAt); err != nil {
+		r.log.Error("Failed to save SEO settings", zap.Error(err))
+		return err
+	}
+	return nil
+}
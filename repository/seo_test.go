@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestSEORepository creates a new test SEO settings repository
+func newTestSEORepository() (*SEORepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	repo := NewSEORepository(mockDB, zap.NewNop())
+	return repo.(*SEORepository), mockDB
+}
+
+// ==================== SEO Repository Tests ====================
+
+func TestSEORepository_GetSEOSettings_Success(t *testing.T) {
+	repo, mockDB := newTestSEORepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*string) = "https://example.com"
+		*dest[1].(*string) = "John Doe - Engineer"
+		*dest[2].(*string) = "Portfolio of John"
+		*dest[3].(*string) = "/og.png"
+		*dest[4].(*string) = "@john"
+		*dest[5].(*string) = "Disallow: /drafts"
+		*dest[6].(*time.Time) = time.Now()
+	}).Return(nil).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	settings, err := repo.GetSEOSettings(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", settings.SiteURL)
+	assert.Equal(t, "@john", settings.TwitterHandle)
+	assert.Equal(t, "Disallow: /drafts", settings.Robots)
+	mockDB.AssertExpectations(t)
+}
+
+func TestSEORepository_GetSEOSettings_NotSaved(t *testing.T) {
+	repo, mockDB := newTestSEORepository()
+	ctx := context.Background()
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	settings, err := repo.GetSEOSettings(ctx)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, settings)
+}
+
+func TestSEORepository_UpdateSEOSettings(t *testing.T) {
+	repo, mockDB := newTestSEORepository()
+	ctx := context.Background()
+	saved := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).([]any)[0].(*time.Time) = saved
+	}).Return(nil).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"),
+		[]any{"https://example.com", "Title", "", "", "@john", ""}).Return(mockRow).Once()
+
+	settings := &model.SEOSettings{SiteURL: "https://example.com", Title: "Title", TwitterHandle: "@john"}
+	err := repo.UpdateSEOSettings(ctx, settings)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved, settings.UpdatedAt)
+	mockDB.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"session-19/database"
 	"session-19/model"
 
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +55,10 @@ func (r *SkillRepository) GetAllSkills(ctx context.Context) ([]model.Skill, erro
 		}
 		skills = append(skills, skill)
 	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate skills", zap.Error(err))
+		return nil, err
+	}
 	return skills, nil
 }
 
@@ -79,6 +84,10 @@ func (r *SkillRepository) GetSkillsByCategory(ctx context.Context, category stri
 		}
 		skills = append(skills, skill)
 	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate skills", zap.Error(err))
+		return nil, err
+	}
 	return skills, nil
 }
 
@@ -114,21 +123,27 @@ func (r *SkillRepository) CreateSkill(ctx context.Context, skill *model.Skill) e
 func (r *SkillRepository) UpdateSkill(ctx context.Context, skill *model.Skill) error {
 	query := `UPDATE skills SET category = $1, name = $2, level = $3, color = $4 WHERE id = $5`
 
-	_, err := r.db.Exec(ctx, query, skill.Category, skill.Name, skill.Level, skill.Color, skill.ID)
+	tag, err := r.db.Exec(ctx, query, skill.Category, skill.Name, skill.Level, skill.Color, skill.ID)
 	if err != nil {
 		r.log.Error("Failed to update skill", zap.Error(err))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
 
 // DeleteSkill deletes a skill
 func (r *SkillRepository) DeleteSkill(ctx context.Context, id int64) error {
 	query := `DELETE FROM skills WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
+	tag, err := r.db.Exec(ctx, query, id)
 	if err != nil {
 		r.log.Error("Failed to delete skill", zap.Error(err), zap.Int64("id", id))
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
 	return nil
 }
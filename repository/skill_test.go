@@ -208,7 +208,7 @@ func TestSkillRepository_UpdateSkill_Success(t *testing.T) {
 		Category: "Backend",
 	}
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
 
 	err := repo.UpdateSkill(ctx, skill)
 
@@ -238,7 +238,7 @@ func TestSkillRepository_DeleteSkill_Success(t *testing.T) {
 	repo, mockDB := newTestSkillRepository()
 	ctx := context.Background()
 
-	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
 
 	err := repo.DeleteSkill(ctx, 1)
 
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"session-19/database"
+	"session-19/model"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// TestimonialRepositoryInterface defines the interface for testimonial repository
+type TestimonialRepositoryInterface interface {
+	GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error)
+	CreateTestimonial(ctx context.Context, t *model.Testimonial) error
+	UpdateTestimonial(ctx context.Context, t *model.Testimonial) error
+	UpdateTestimonialStatus(ctx context.Context, id int64, status string) error
+	DeleteTestimonial(ctx context.Context, id int64) error
+
+	GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error)
+	GetTestimonialInviteByToken(ctx context.Context, token string) (*model.TestimonialInvite, error)
+	CreateTestimonialInvite(ctx context.Context, invite *model.TestimonialInvite) error
+	DeleteTestimonialInvite(ctx context.Context, id int64) error
+	SubmitTestimonial(ctx context.Context, token string, t *model.Testimonial) error
+}
+
+// TestimonialRepository implements TestimonialRepositoryInterface
+type TestimonialRepository struct {
+	db  database.PgxIface
+	log *zap.Logger
+}
+
+// NewTestimonialRepository creates a new testimonial repository
+func NewTestimonialRepository(db database.PgxIface, log *zap.Logger) TestimonialRepositoryInterface {
+	return &TestimonialRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetAllTestimonials retrieves all testimonials, the moderation queue first
+func (r *TestimonialRepository) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	query := `SELECT ` + testimonialColumns + ` FROM testimonials 
+		ORDER BY CASE status WHEN 'pending' THEN 0 WHEN 'approved' THEN 1 ELSE 2 END, created_at DESC`
+	return r.queryTestimonials(ctx, query)
+}
+
+// GetApprovedTestimonials retrieves the testimonials shown on the portfolio, newest first
+func (r *TestimonialRepository) GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	query := `SELECT ` + testimonialColumns + ` FROM testimonials WHERE status = $1 ORDER BY created_at DESC`
+	return r.queryTestimonials(ctx, query, model.TestimonialApproved)
+}
+
+func (r *TestimonialRepository) queryTestimonials(ctx context.Context, query string, args ...any) ([]model.Testimonial, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("Failed to get testimonials", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var testimonials []model.Testimonial
+	for rows.Next() {
+		t, err := scanTestimonial(rows)
+		if err != nil {
+			r.log.Error("Failed to scan testimonial", zap.Error(err))
+			continue
+		}
+		testimonials = append(testimonials, *t)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate testimonials", zap.Error(err))
+		return nil, err
+	}
+	return testimonials, nil
+}
+
+// GetTestimonialByID retrieves a testimonial by ID
+func (r *TestimonialRepository) GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error) {
+	query := `SELECT ` + testimonialColumns + ` FROM testimonials WHERE id = $1`
+
+	t, err := scanTestimonial(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		r.log.Error("Failed to get testimonial by ID", zap.Error(err), zap.Int64("id", id))
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateTestimonial creates a new testimonial
+func (r *TestimonialRepository) CreateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	query := `INSERT INTO testimonials (author, role, company, quote, avatar_url, relationship, status, color) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	row := r.db.QueryRow(ctx, query, t.Author, t.Role, t.Company, t.Quote, t.AvatarURL, t.Relationship, t.Status, t.Color)
+
+	err := row.Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		r.log.Error("Failed to create testimonial", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateTestimonial updates a testimonial, including its status
+func (r *TestimonialRepository) UpdateTestimonial(ctx context.Context, t *model.Testimonial) error {
+	query := `UPDATE testimonials SET author = $1, role = $2, company = $3, quote = $4, avatar_url = $5, 
+		relationship = $6, status = $7, color = $8 WHERE id = $9`
+
+	tag, err := r.db.Exec(ctx, query, t.Author, t.Role, t.Company, t.Quote, t.AvatarURL, t.Relationship, t.Status, t.Color, t.ID)
+	if err != nil {
+		r.log.Error("Failed to update testimonial", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateTestimonialStatus approves or rejects a testimonial
+func (r *TestimonialRepository) UpdateTestimonialStatus(ctx context.Context, id int64, status string) error {
+	query := `UPDATE testimonials SET status = $1 WHERE id = $2`
+	tag, err := r.db.Exec(ctx, query, status, id)
+	if err != nil {
+		r.log.Error("Failed to update testimonial status", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteTestimonial deletes a testimonial
+func (r *TestimonialRepository) DeleteTestimonial(ctx context.Context, id int64) error {
+	query := `DELETE FROM testimonials WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete testimonial", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// GetAllTestimonialInvites retrieves all invites, newest first
+func (r *TestimonialRepository) GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error) {
+	query := `SELECT ` + testimonialInviteColumns + ` FROM testimonial_invites ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		r.log.Error("Failed to get testimonial invites", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invites []model.TestimonialInvite
+	for rows.Next() {
+		invite, err := scanTestimonialInvite(rows)
+		if err != nil {
+			r.log.Error("Failed to scan testimonial invite", zap.Error(err))
+			continue
+		}
+		invites = append(invites, *invite)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.Error("Failed to iterate testimonial invites", zap.Error(err))
+		return nil, err
+	}
+	return invites, nil
+}
+
+// GetTestimonialInviteByToken retrieves an invite by its token, used or not
+func (r *TestimonialRepository) GetTestimonialInviteByToken(ctx context.Context, token string) (*model.TestimonialInvite, error) {
+	query := `SELECT ` + testimonialInviteColumns + ` FROM testimonial_invites WHERE token = $1`
+
+	invite, err := scanTestimonialInvite(r.db.QueryRow(ctx, query, token))
+	if err != nil {
+		// Unknown tokens are expected from mistyped or guessed links
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.log.Error("Failed to get testimonial invite", zap.Error(err))
+		}
+		return nil, err
+	}
+	return invite, nil
+}
+
+// CreateTestimonialInvite creates a new invite
+func (r *TestimonialRepository) CreateTestimonialInvite(ctx context.Context, invite *model.TestimonialInvite) error {
+	query := `INSERT INTO testimonial_invites (token, note, expires_at) VALUES ($1, $2, $3) RETURNING id, created_at`
+
+	err := r.db.QueryRow(ctx, query, invite.Token, invite.Note, invite.ExpiresAt).Scan(&invite.ID, &invite.CreatedAt)
+	if err != nil {
+		r.log.Error("Failed to create testimonial invite", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeleteTestimonialInvite deletes an invite; testimonials submitted through it are kept
+func (r *TestimonialRepository) DeleteTestimonialInvite(ctx context.Context, id int64) error {
+	query := `DELETE FROM testimonial_invites WHERE id = $1`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		r.log.Error("Failed to delete testimonial invite", zap.Error(err), zap.Int64("id", id))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SubmitTestimonial stores a testimonial submitted through the invite with the token, pending moderation.
+// Using up the invite and storing the testimonial happen in one statement, so a link cannot be used twice.
+// It returns pgx.ErrNoRows when the invite does not exist, was used or has expired.
+func (r *TestimonialRepository) SubmitTestimonial(ctx context.Context, token string, t *model.Testimonial) error {
+	query := `WITH invite AS (
+			UPDATE testimonial_invites SET used_at = CURRENT_TIMESTAMP
+			WHERE token = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP RETURNING id
+		)
+		INSERT INTO testimonials (author, role, company, quote, avatar_url, relationship, status, color, invite_id) 
+		SELECT $2, $3, $4, $5, $6, $7, $8, $9, id FROM invite RETURNING id, created_at`
+
+	row := r.db.QueryRow(ctx, query, token, t.Author, t.Role, t.Company, t.Quote, t.AvatarURL, t.Relationship,
+		model.TestimonialPending, t.Color)
+
+	if err := row.Scan(&t.ID, &t.CreatedAt); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			r.log.Error("Failed to submit testimonial", zap.Error(err))
+		}
+		return err
+	}
+	t.Status = model.TestimonialPending
+	return nil
+}
+
+// testimonialColumns lists the columns scanTestimonial reads
+const testimonialColumns = `id, author, COALESCE(role, ''), COALESCE(company, ''), quote, COALESCE(avatar_url, ''), 
+	COALESCE(relationship, ''), status, COALESCE(color, 'yellow'), created_at, COALESCE(invite_id, 0)`
+
+func scanTestimonial(row pgx.Row) (*model.Testimonial, error) {
+	var t model.Testimonial
+	err := row.Scan(&t.ID, &t.Author, &t.Role, &t.Company, &t.Quote, &t.AvatarURL,
+		&t.Relationship, &t.Status, &t.Color, &t.CreatedAt, &t.InviteID)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// testimonialInviteColumns lists the columns scanTestimonialInvite reads
+const testimonialInviteColumns = `id, token, COALESCE(note, ''), expires_at, used_at, created_at`
+
+func scanTestimonialInvite(row pgx.Row) (*model.TestimonialInvite, error) {
+	var invite model.TestimonialInvite
+	err := row.Scan(&invite.ID, &invite.Token, &invite.Note, &invite.ExpiresAt, &invite.UsedAt, &invite.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
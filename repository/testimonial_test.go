@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"session-19/database"
+	"session-19/model"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// newTestTestimonialRepository creates a new test testimonial repository
+func newTestTestimonialRepository() (*TestimonialRepository, *database.MockDB) {
+	mockDB := new(database.MockDB)
+	logger := zap.NewNop()
+	repo := NewTestimonialRepository(mockDB, logger)
+	return repo.(*TestimonialRepository), mockDB
+}
+
+// ==================== Testimonial Repository Tests ====================
+
+func TestTestimonialRepository_GetApprovedTestimonials_Success(t *testing.T) {
+	repo, mockDB := newTestTestimonialRepository()
+	ctx := context.Background()
+
+	mockRows := database.NewMockRows([][]any{
+		{int64(1), "Jane Smith", "Engineering Manager", "Tech Company Inc.", "Great engineer.", "", "Managed Alvin directly", "approved", "yellow", time.Now()},
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		data := mockRows.Data[mockRows.CurrentIndex]
+		*dest[0].(*int64) = data[0].(int64)
+		for i := 1; i <= 8; i++ {
+			*dest[i].(*string) = data[i].(string)
+		}
+		*dest[9].(*time.Time) = data[9].(time.Time)
+	}).Return(nil)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil)
+
+	mockDB.On("Query", ctx, mock.AnythingOfType("string"), []any{model.TestimonialApproved}).Return(mockRows, nil).Once()
+
+	testimonials, err := repo.GetApprovedTestimonials(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, testimonials, 1)
+	assert.Equal(t, "Jane Smith", testimonials[0].Author)
+	assert.Equal(t, "JS", testimonials[0].Initials())
+	mockDB.AssertExpectations(t)
+}
+
+func TestTestimonialRepository_UpdateTestimonialStatus_NotFound(t *testing.T) {
+	repo, mockDB := newTestTestimonialRepository()
+	ctx := context.Background()
+
+	mockDB.On("Exec", ctx, mock.AnythingOfType("string"), []any{model.TestimonialApproved, int64(999)}).
+		Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+
+	err := repo.UpdateTestimonialStatus(ctx, 999, model.TestimonialApproved)
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestTestimonialRepository_SubmitTestimonial_Success(t *testing.T) {
+	repo, mockDB := newTestTestimonialRepository()
+	ctx := context.Background()
+
+	testimonial := &model.Testimonial{Author: "Jane Smith", Quote: "Great engineer.", Color: "yellow"}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 3
+		*dest[1].(*time.Time) = time.Now()
+	}).Return(nil).Once()
+
+	// The submission is always stored pending, whatever status the testimonial carries
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"),
+		[]any{"tok", "Jane Smith", "", "", "Great engineer.", "", "", model.TestimonialPending, "yellow"}).Return(mockRow).Once()
+
+	err := repo.SubmitTestimonial(ctx, "tok", testimonial)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), testimonial.ID)
+	assert.Equal(t, model.TestimonialPending, testimonial.Status)
+	mockDB.AssertExpectations(t)
+	mockRow.AssertExpectations(t)
+}
+
+func TestTestimonialRepository_SubmitTestimonial_InviteUsed(t *testing.T) {
+	repo, mockDB := newTestTestimonialRepository()
+	ctx := context.Background()
+
+	// A used, expired or unknown invite updates no row, so nothing is inserted
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), mock.Anything).Return(mockRow).Once()
+
+	err := repo.SubmitTestimonial(ctx, "used", &model.Testimonial{Author: "Jane Smith", Quote: "Great engineer."})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
+
+func TestTestimonialRepository_CreateTestimonialInvite_Success(t *testing.T) {
+	repo, mockDB := newTestTestimonialRepository()
+	ctx := context.Background()
+
+	expires := time.Now().Add(time.Hour)
+	invite := &model.TestimonialInvite{Token: "tok", Note: "Jane, former manager", ExpiresAt: expires}
+
+	mockRow := new(database.MockRow)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args.Get(0).([]any)
+		*dest[0].(*int64) = 1
+		*dest[1].(*time.Time) = time.Now()
+	}).Return(nil).Once()
+	mockDB.On("QueryRow", ctx, mock.AnythingOfType("string"), []any{"tok", "Jane, former manager", expires}).Return(mockRow).Once()
+
+	err := repo.CreateTestimonialInvite(ctx, invite)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), invite.ID)
+	assert.True(t, invite.Open())
+	assert.Equal(t, "/testimonials/submit/tok", invite.Path())
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,510 @@
+package resume
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"session-19/markdown"
+	"session-19/model"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Path is where the generated résumé is served
+const Path = "/resume.pdf"
+
+// Supported layouts
+const (
+	LayoutClassic = "classic"
+	LayoutSidebar = "sidebar"
+)
+
+// Layouts lists the supported layouts, the default first
+var Layouts = []string{LayoutClassic, LayoutSidebar}
+
+// ErrUnknownLayout is returned for a layout name not listed in Layouts
+var ErrUnknownLayout = errors.New("resume: unknown layout")
+
+// ValidLayout reports whether layout is a supported layout name
+func ValidLayout(layout string) bool {
+	for _, l := range Layouts {
+		if l == layout {
+			return true
+		}
+	}
+	return false
+}
+
+// URL returns the résumé path for layout, leaving the query off for the default
+func URL(layout string) string {
+	if layout == "" || layout == Layouts[0] {
+		return Path
+	}
+	return Path + "?layout=" + url.QueryEscape(layout)
+}
+
+// experienceSections orders the experience types and names their headings
+var experienceSections = []struct {
+	Type    string
+	Heading string
+}{
+	{"work", "Work Experience"},
+	{"internship", "Internships"},
+	{"campus", "Campus Activities"},
+	{"competition", "Competitions & Awards"},
+}
+
+// Page geometry in millimetres (A4)
+const (
+	margin       = 15.0
+	sidebarWidth = 62.0
+	lineHeight   = 5.0
+)
+
+// Colours
+var (
+	accent = [3]int{8, 145, 178} // cyan-600
+	muted  = [3]int{90, 90, 90}
+	ink    = [3]int{20, 20, 20}
+	panel  = [3]int{236, 248, 251}
+)
+
+// Render writes data as an A4 PDF résumé in the given layout. An empty layout
+// selects the default. The core PDF fonts are used, so characters outside
+// Windows-1252 are replaced.
+func Render(w io.Writer, data *model.PortfolioData, layout string) error {
+	if layout == "" {
+		layout = Layouts[0]
+	}
+	if !ValidLayout(layout) {
+		return ErrUnknownLayout
+	}
+
+	d := newDocument(data)
+	switch layout {
+	case LayoutSidebar:
+		d.sidebar(data)
+	default:
+		d.classic(data)
+	}
+	return d.pdf.Output(w)
+}
+
+// document wraps the PDF with the column the main content flows in
+type document struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+	// x and width of the main column
+	x, width float64
+}
+
+func newDocument(data *model.PortfolioData) *document {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	d := &document{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+
+	name := data.Profile.Name
+	if name == "" {
+		name = "Resume"
+	}
+	pdf.SetTitle(name+" - Resume", true)
+	pdf.SetAuthor(data.Profile.Name, true)
+	pdf.SetCreator("Portfolio", true)
+	if !data.Profile.UpdatedAt.IsZero() {
+		pdf.SetCreationDate(data.Profile.UpdatedAt)
+	}
+
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin+5)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin)
+		pdf.SetFont("Helvetica", "", 8)
+		d.color(muted)
+		pdf.CellFormat(0, 4, d.tr(name+"  -  Page "+strconv.Itoa(pdf.PageNo())+" of {nb}"), "", 0, "C", false, 0, "")
+	})
+	return d
+}
+
+// classic renders a single column with a centred header
+func (d *document) classic(data *model.PortfolioData) {
+	pw, _ := d.pdf.GetPageSize()
+	d.x, d.width = margin, pw-2*margin
+	d.pdf.AddPage()
+
+	p := data.Profile
+	d.pdf.SetFont("Helvetica", "B", 22)
+	d.color(ink)
+	d.pdf.CellFormat(0, 10, d.tr(p.Name), "", 1, "C", false, 0, "")
+	if p.Title != "" {
+		d.pdf.SetFont("Helvetica", "", 12)
+		d.color(accent)
+		d.pdf.CellFormat(0, 6, d.tr(p.Title), "", 1, "C", false, 0, "")
+	}
+	if contact := contactLines(p); len(contact) > 0 {
+		d.pdf.SetFont("Helvetica", "", 9)
+		d.color(muted)
+		d.pdf.CellFormat(0, 5, d.tr(strings.Join(contact, "  |  ")), "", 1, "C", false, 0, "")
+	}
+	d.pdf.Ln(2)
+
+	d.summary(p)
+	d.experiences(data.Experiences)
+	d.education(data.Education)
+	if len(data.Skills) > 0 {
+		d.heading("Skills")
+		for _, category := range sortedCategories(data.Skills) {
+			d.ensureSpace(lineHeight * 2)
+			d.pdf.SetX(d.x)
+			d.pdf.SetFont("Helvetica", "B", 10)
+			d.color(ink)
+			label := d.tr(category + ": ")
+			labelWidth := d.pdf.GetStringWidth(label) + 1
+			d.pdf.CellFormat(labelWidth, lineHeight, label, "", 0, "L", false, 0, "")
+			d.pdf.SetFont("Helvetica", "", 10)
+			d.pdf.MultiCell(d.width-labelWidth, lineHeight, d.tr(skillNames(data.Skills[category])), "", "L", false)
+		}
+	}
+	d.certifications(data.Certifications)
+	d.projects(data.Projects)
+	d.publications(data.Publications)
+}
+
+// sidebar renders contact details and skills in a tinted left column on the
+// first page, with everything else in the main column
+func (d *document) sidebar(data *model.PortfolioData) {
+	pw, ph := d.pdf.GetPageSize()
+	d.x, d.width = sidebarWidth+8, pw-sidebarWidth-8-margin
+
+	// The band is drawn on every page so continuation pages keep the layout
+	d.pdf.SetHeaderFunc(func() {
+		d.fill(panel)
+		d.pdf.Rect(0, 0, sidebarWidth, ph, "F")
+	})
+	d.pdf.SetLeftMargin(d.x)
+	d.pdf.AddPage()
+
+	p := data.Profile
+	d.sidebarColumn(p, data.Skills)
+
+	d.pdf.SetXY(d.x, margin)
+	d.pdf.SetFont("Helvetica", "B", 22)
+	d.color(ink)
+	d.pdf.MultiCell(d.width, 10, d.tr(p.Name), "", "L", false)
+	if p.Title != "" {
+		d.pdf.SetFont("Helvetica", "", 12)
+		d.color(accent)
+		d.pdf.SetX(d.x)
+		d.pdf.MultiCell(d.width, 6, d.tr(p.Title), "", "L", false)
+	}
+	d.pdf.Ln(2)
+
+	d.summary(p)
+	d.experiences(data.Experiences)
+	d.education(data.Education)
+	d.certifications(data.Certifications)
+	d.projects(data.Projects)
+	d.publications(data.Publications)
+}
+
+// sidebarColumn fills the first page's side column. It never breaks the page;
+// whatever does not fit is cut off with an ellipsis.
+func (d *document) sidebarColumn(p model.Profile, skills map[string][]model.Skill) {
+	_, ph := d.pdf.GetPageSize()
+	x, width := 8.0, sidebarWidth-16
+	bottom := ph - margin - 5
+
+	auto, breakMargin := d.pdf.GetAutoPageBreak()
+	d.pdf.SetAutoPageBreak(false, 0)
+	defer d.pdf.SetAutoPageBreak(auto, breakMargin)
+
+	full := false
+	write := func(style string, size float64, c [3]int, text string) {
+		if full {
+			return
+		}
+		if d.pdf.GetY()+lineHeight > bottom {
+			d.pdf.SetFont("Helvetica", "", 9)
+			d.pdf.SetX(x)
+			d.pdf.CellFormat(width, lineHeight, "...", "", 1, "L", false, 0, "")
+			full = true
+			return
+		}
+		d.pdf.SetFont("Helvetica", style, size)
+		d.color(c)
+		for _, line := range d.pdf.SplitLines([]byte(d.tr(text)), width) {
+			if d.pdf.GetY()+lineHeight > bottom {
+				break
+			}
+			d.pdf.SetX(x)
+			d.pdf.CellFormat(width, lineHeight-0.5, string(line), "", 1, "L", false, 0, "")
+		}
+	}
+	section := func(title string) {
+		d.pdf.Ln(3)
+		write("B", 10, accent, strings.ToUpper(title))
+		d.pdf.Ln(1)
+	}
+
+	d.pdf.SetY(margin + 2)
+	if contact := contactLines(p); len(contact) > 0 {
+		section("Contact")
+		for _, line := range contact {
+			write("", 8.5, ink, line)
+		}
+	}
+	if len(skills) > 0 {
+		section("Skills")
+		for _, category := range sortedCategories(skills) {
+			write("B", 9, ink, category)
+			write("", 8.5, muted, skillNames(skills[category]))
+			d.pdf.Ln(1)
+		}
+	}
+}
+
+func (d *document) summary(p model.Profile) {
+	if strings.TrimSpace(p.Description) == "" {
+		return
+	}
+	d.heading("Summary")
+	d.paragraph(p.Description)
+}
+
+func (d *document) experiences(experiences []model.Experience) {
+	byType := map[string][]model.Experience{}
+	for _, e := range experiences {
+		byType[e.Type] = append(byType[e.Type], e)
+	}
+
+	known := map[string]bool{}
+	for _, s := range experienceSections {
+		known[s.Type] = true
+		d.experienceSection(s.Heading, byType[s.Type])
+	}
+
+	var other []model.Experience
+	for _, e := range experiences {
+		if !known[e.Type] {
+			other = append(other, e)
+		}
+	}
+	d.experienceSection("Other Experience", other)
+}
+
+func (d *document) experienceSection(heading string, experiences []model.Experience) {
+	if len(experiences) == 0 {
+		return
+	}
+	d.heading(heading)
+	for _, e := range experiences {
+		d.entry(e.Title, e.Period, e.Organization, e.Description)
+	}
+}
+
+func (d *document) education(education []model.Education) {
+	if len(education) == 0 {
+		return
+	}
+	d.heading("Education")
+	for _, e := range education {
+		title := e.Degree
+		if e.Field != "" {
+			if title != "" {
+				title += ", "
+			}
+			title += e.Field
+		}
+		if title == "" {
+			title = e.Institution
+		}
+
+		details := []string{e.Institution}
+		if title == e.Institution {
+			details = nil
+		}
+		if e.GPA != "" {
+			details = append(details, "GPA "+e.GPA)
+		}
+		if e.Honors != "" {
+			details = append(details, e.Honors)
+		}
+		d.entry(title, e.Period(), strings.Join(details, " - "), e.Description)
+	}
+}
+
+func (d *document) certifications(certifications []model.Certification) {
+	if len(certifications) == 0 {
+		return
+	}
+	d.heading("Certifications")
+	for _, c := range certifications {
+		details := []string{c.Issuer}
+		if c.CredentialID != "" {
+			details = append(details, "Credential ID "+c.CredentialID)
+		}
+		if expires := model.MonthYear(c.ExpiryDate); expires != "" {
+			if c.Expired() {
+				details = append(details, "Expired "+expires)
+			} else {
+				details = append(details, "Expires "+expires)
+			}
+		}
+		d.entry(c.Name, c.Issued(), strings.Join(details, " - "), "")
+		d.link(c.VerificationURL)
+	}
+}
+
+func (d *document) projects(projects []model.Project) {
+	if len(projects) == 0 {
+		return
+	}
+	d.heading("Projects")
+	for _, p := range projects {
+		link := p.ProjectURL
+		if link == "" {
+			link = p.GithubURL
+		}
+		d.entry(p.Title, "", p.TechStack, p.Description)
+		d.link(link)
+	}
+}
+
+func (d *document) publications(publications []model.Publication) {
+	if len(publications) == 0 {
+		return
+	}
+	d.heading("Publications")
+	for _, p := range publications {
+		year := ""
+		if p.Year != 0 {
+			year = strconv.Itoa(p.Year)
+		}
+		venue := p.Authors.String()
+		if p.Journal != "" {
+			if venue != "" {
+				venue += ". "
+			}
+			venue += p.Journal
+		}
+		d.entry(p.Title, year, venue, "")
+	}
+}
+
+// heading starts a section, moving to a new page rather than leaving the
+// heading stranded at the bottom of one
+func (d *document) heading(title string) {
+	d.ensureSpace(20)
+	d.pdf.Ln(3)
+	d.pdf.SetX(d.x)
+	d.pdf.SetFont("Helvetica", "B", 12)
+	d.color(accent)
+	d.pdf.CellFormat(d.width, 7, d.tr(strings.ToUpper(title)), "", 1, "L", false, 0, "")
+	d.draw(accent)
+	y := d.pdf.GetY()
+	d.pdf.SetLineWidth(0.4)
+	d.pdf.Line(d.x, y, d.x+d.width, y)
+	d.pdf.Ln(2)
+}
+
+// entry writes a titled item with an optional right-aligned date, subtitle and body
+func (d *document) entry(title, date, subtitle, body string) {
+	d.ensureSpace(lineHeight * 3)
+
+	d.pdf.SetX(d.x)
+	d.pdf.SetFont("Helvetica", "", 9)
+	dateWidth := 0.0
+	if date != "" {
+		dateWidth = d.pdf.GetStringWidth(d.tr(date)) + 2
+	}
+	d.pdf.SetFont("Helvetica", "B", 10.5)
+	d.color(ink)
+	y := d.pdf.GetY()
+	d.pdf.MultiCell(d.width-dateWidth, lineHeight+0.5, d.tr(title), "", "L", false)
+	if date != "" {
+		after := d.pdf.GetY()
+		d.pdf.SetXY(d.x+d.width-dateWidth, y)
+		d.pdf.SetFont("Helvetica", "", 9)
+		d.color(muted)
+		d.pdf.CellFormat(dateWidth, lineHeight+0.5, d.tr(date), "", 0, "R", false, 0, "")
+		d.pdf.SetY(after)
+	}
+
+	if subtitle != "" {
+		d.pdf.SetX(d.x)
+		d.pdf.SetFont("Helvetica", "I", 9.5)
+		d.color(muted)
+		d.pdf.MultiCell(d.width, lineHeight, d.tr(subtitle), "", "L", false)
+	}
+	if strings.TrimSpace(body) != "" {
+		d.paragraph(body)
+	}
+	d.pdf.Ln(2)
+}
+
+// link writes a clickable URL under an entry, nothing when it is empty
+func (d *document) link(link string) {
+	if link == "" {
+		return
+	}
+	d.pdf.SetX(d.x)
+	d.pdf.SetFont("Helvetica", "", 8.5)
+	d.color(accent)
+	d.pdf.CellFormat(d.width, 4, d.tr(link), "", 1, "L", false, 0, link)
+	d.pdf.Ln(1)
+}
+
+// paragraph writes a Markdown description as plain text
+func (d *document) paragraph(text string) {
+	d.pdf.SetX(d.x)
+	d.pdf.SetFont("Helvetica", "", 9.5)
+	d.color(ink)
+	d.pdf.MultiCell(d.width, lineHeight, d.tr(markdown.Text(text)), "", "L", false)
+}
+
+// ensureSpace starts a new page when less than h is left above the bottom margin
+func (d *document) ensureSpace(h float64) {
+	_, ph := d.pdf.GetPageSize()
+	_, breakMargin := d.pdf.GetAutoPageBreak()
+	if d.pdf.GetY()+h > ph-breakMargin {
+		d.pdf.AddPage()
+	}
+}
+
+func (d *document) color(c [3]int) { d.pdf.SetTextColor(c[0], c[1], c[2]) }
+func (d *document) fill(c [3]int)  { d.pdf.SetFillColor(c[0], c[1], c[2]) }
+func (d *document) draw(c [3]int)  { d.pdf.SetDrawColor(c[0], c[1], c[2]) }
+
+// contactLines lists the profile's contact details without URL schemes
+func contactLines(p model.Profile) []string {
+	var lines []string
+	for _, s := range []string{p.Email, p.LinkedInURL, p.GithubURL} {
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "https://"), "http://")
+		if s = strings.TrimSuffix(s, "/"); s != "" {
+			lines = append(lines, s)
+		}
+	}
+	return lines
+}
+
+func sortedCategories(skills map[string][]model.Skill) []string {
+	categories := make([]string, 0, len(skills))
+	for category, list := range skills {
+		if len(list) > 0 {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+func skillNames(skills []model.Skill) string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		names[i] = s.Name
+	}
+	return strings.Join(names, ", ")
+}
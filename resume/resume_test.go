@@ -0,0 +1,123 @@
+package resume
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleData(experiences int) *model.PortfolioData {
+	data := &model.PortfolioData{
+		Profile: model.Profile{
+			Name:        "Jane Doe",
+			Title:       "Backend Engineer",
+			Description: "Builds reliable services in Go. Café regular.",
+			Email:       "jane@example.com",
+			GithubURL:   "https://github.com/jane",
+			UpdatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Skills: map[string][]model.Skill{
+			"Languages": {{Name: "Go"}, {Name: "SQL"}},
+			"Tools":     {{Name: "Docker"}},
+		},
+		Projects:     []model.Project{{Title: "Portfolio", TechStack: "Go, PostgreSQL", ProjectURL: "https://example.com"}},
+		Publications: []model.Publication{{Title: "On Caching", Authors: model.Authors{{Name: "J. Doe"}}, Journal: "Journal of Systems", Year: 2024}},
+	}
+	for i := 0; i < experiences; i++ {
+		data.Experiences = append(data.Experiences, model.Experience{
+			Title:        "Engineer " + strconv.Itoa(i),
+			Organization: "Acme",
+			Period:       "2020 - 2022",
+			Description:  strings.Repeat("Shipped features and kept the lights on. ", 6),
+			Type:         []string{"work", "internship", "campus", "competition", "volunteer"}[i%5],
+		})
+	}
+	return data
+}
+
+func render(t *testing.T, data *model.PortfolioData, layout string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, data, layout))
+	return buf.Bytes()
+}
+
+func pageCount(t *testing.T, pdf []byte) int {
+	t.Helper()
+	m := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(pdf)
+	require.NotNil(t, m, "page tree not found")
+	n, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	return n
+}
+
+func TestRender_ProducesPDFForEveryLayout(t *testing.T) {
+	for _, layout := range Layouts {
+		t.Run(layout, func(t *testing.T) {
+			out := render(t, sampleData(3), layout)
+
+			assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.")))
+			assert.Contains(t, string(out[len(out)-16:]), "%%EOF")
+			assert.Equal(t, 1, pageCount(t, out))
+			assert.Contains(t, string(out), "/CreationDate (D:20260102030405)", "the creation date follows the profile so output is stable")
+		})
+	}
+}
+
+func TestRender_PaginatesLongContent(t *testing.T) {
+	for _, layout := range Layouts {
+		t.Run(layout, func(t *testing.T) {
+			out := render(t, sampleData(40), layout)
+
+			assert.Greater(t, pageCount(t, out), 1)
+		})
+	}
+}
+
+func TestRender_IncludesEducationAndCertifications(t *testing.T) {
+	data := sampleData(1)
+	data.Education = []model.Education{{Institution: "University", Degree: "BSc", Field: "Computer Science",
+		StartDate: "2016-09-01", EndDate: "2020-06-30", GPA: "3.8/4.0"}}
+	data.Certifications = []model.Certification{{Name: "CKA", Issuer: "CNCF", IssueDate: "2024-03-15",
+		VerificationURL: "https://cncf.io/verify/123"}}
+
+	for _, layout := range Layouts {
+		t.Run(layout, func(t *testing.T) {
+			without := render(t, sampleData(1), layout)
+			out := render(t, data, layout)
+
+			// Page content is compressed, but link annotations are not
+			assert.Contains(t, string(out), "(https://cncf.io/verify/123)")
+			assert.Greater(t, len(out), len(without))
+		})
+	}
+}
+
+func TestRender_EmptyDataAndDefaultLayout(t *testing.T) {
+	out := render(t, &model.PortfolioData{}, "")
+
+	assert.Equal(t, 1, pageCount(t, out))
+}
+
+func TestRender_RejectsUnknownLayout(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := Render(&buf, sampleData(1), "fancy")
+
+	assert.ErrorIs(t, err, ErrUnknownLayout)
+	assert.Zero(t, buf.Len())
+}
+
+func TestURL(t *testing.T) {
+	assert.Equal(t, "/resume.pdf", URL(""))
+	assert.Equal(t, "/resume.pdf", URL(LayoutClassic))
+	assert.Equal(t, "/resume.pdf?layout=sidebar", URL(LayoutSidebar))
+}
@@ -2,15 +2,38 @@ package router
 
 import (
 	"net/http"
+	"path"
+	"session-19/citation"
+	"session-19/feed"
 	"session-19/handler"
 	mCostume "session-19/middleware"
+	"session-19/model"
+	"session-19/resume"
+	"session-19/seo"
 	"session-19/service"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"go.uber.org/zap"
 )
 
+// LegacyCVDir is where, under public/, CVs were stored before they moved to private
+// storage. Files left there are only handed out through /cv and the admin, never as static files.
+const LegacyCVDir = "assets/uploads/cv"
+
+// staticFiles serves root, hiding the legacy CV directory
+func staticFiles(root http.FileSystem) http.Handler {
+	fs := http.FileServer(root)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(path.Clean("/"+r.URL.Path)+"/", "/"+LegacyCVDir+"/") {
+			http.NotFound(w, r)
+			return
+		}
+		fs.ServeHTTP(w, r)
+	})
+}
+
 // NewRouter creates a new router with all routes configured
 func NewRouter(h handler.Handler, svc service.Service, log *zap.Logger) *chi.Mux {
 	r := chi.NewRouter()
@@ -25,11 +48,14 @@ func NewRouter(h handler.Handler, svc service.Service, log *zap.Logger) *chi.Mux
 	r.Use(mw.Logging)
 
 	// Serve static files
-	fs := http.FileServer(http.Dir("public"))
-	r.Handle("/public/*", http.StripPrefix("/public/", fs))
+	r.With(mCostume.NoSniff).Handle("/public/*", http.StripPrefix("/public/", staticFiles(http.Dir("public"))))
 
-	// Main portfolio page (HTML template)
-	r.Get("/", h.PortfolioHandler.RenderPortfolio)
+	PublicRoutes(r, h)
+
+	// Testimonial submission through an invite link. It is not a static page,
+	// so it stays out of PublicRoutes.
+	r.Get(model.TestimonialInvitePath+"{token}", h.PortfolioHandler.RenderTestimonialForm)
+	r.Post(model.TestimonialInvitePath+"{token}", h.PortfolioHandler.SubmitTestimonialForm)
 
 	// Auth routes (public)
 	r.Get("/login", h.AuthHandler.LoginView)
@@ -77,6 +103,64 @@ func NewRouter(h handler.Handler, svc service.Service, log *zap.Logger) *chi.Mux
 		r.Get("/publications/edit/{id}", h.AdminHandler.PublicationForm)
 		r.Post("/publications/save", h.AdminHandler.PublicationSave)
 		r.Post("/publications/delete/{id}", h.AdminHandler.PublicationDelete)
+		r.Get("/publications/import", h.AdminHandler.PublicationImportForm)
+		r.Post("/publications/import/preview", h.AdminHandler.PublicationImportPreview)
+		r.Post("/publications/import/apply", h.AdminHandler.PublicationImportApply)
+
+		// Education
+		r.Get("/education", h.AdminHandler.EducationList)
+		r.Get("/education/new", h.AdminHandler.EducationForm)
+		r.Get("/education/edit/{id}", h.AdminHandler.EducationForm)
+		r.Post("/education/save", h.AdminHandler.EducationSave)
+		r.Post("/education/delete/{id}", h.AdminHandler.EducationDelete)
+
+		// Certifications
+		r.Get("/certifications", h.AdminHandler.CertificationsList)
+		r.Get("/certifications/new", h.AdminHandler.CertificationForm)
+		r.Get("/certifications/edit/{id}", h.AdminHandler.CertificationForm)
+		r.Post("/certifications/save", h.AdminHandler.CertificationSave)
+		r.Post("/certifications/delete/{id}", h.AdminHandler.CertificationDelete)
+
+		// Testimonials, their moderation and invite links
+		r.Get("/testimonials", h.AdminHandler.TestimonialsList)
+		r.Get("/testimonials/new", h.AdminHandler.TestimonialForm)
+		r.Get("/testimonials/edit/{id}", h.AdminHandler.TestimonialForm)
+		r.Post("/testimonials/save", h.AdminHandler.TestimonialSave)
+		r.Post("/testimonials/status/{id}", h.AdminHandler.TestimonialStatus)
+		r.Post("/testimonials/delete/{id}", h.AdminHandler.TestimonialDelete)
+		r.Post("/testimonials/invites/new", h.AdminHandler.TestimonialInviteCreate)
+		r.Post("/testimonials/invites/delete/{id}", h.AdminHandler.TestimonialInviteDelete)
+
+		// Media library
+		r.Get("/media", h.AdminHandler.MediaList)
+		r.Post("/media/upload", h.AdminHandler.MediaUpload)
+		r.Post("/media/save/{id}", h.AdminHandler.MediaSave)
+		r.Post("/media/delete/{id}", h.AdminHandler.MediaDelete)
+
+		// CV versions
+		r.Get("/cv", h.AdminHandler.CVList)
+		r.Post("/cv/upload", h.AdminHandler.CVUpload)
+		r.Get("/cv/view/{id}", h.AdminHandler.CVView)
+		r.Post("/cv/activate/{id}", h.AdminHandler.CVActivate)
+		r.Post("/cv/delete/{id}", h.AdminHandler.CVDelete)
+		r.Post("/cv/generated", h.AdminHandler.CVUseGenerated)
+
+		// JSON Resume import
+		r.Get("/import", h.AdminHandler.ImportForm)
+		r.Post("/import/preview", h.AdminHandler.ImportPreview)
+		r.Post("/import/apply", h.AdminHandler.ImportApply)
+
+		// Backup and restore
+		r.Get("/backup", h.AdminHandler.BackupPage)
+		r.Get("/backup/download", h.AdminHandler.BackupDownload)
+		r.Post("/backup/restore", h.AdminHandler.BackupRestore)
+
+		// SEO settings
+		r.Get("/seo", h.AdminHandler.SEOEdit)
+		r.Post("/seo/save", h.AdminHandler.SEOSave)
+
+		// Markdown preview for the description fields
+		r.Post("/markdown/preview", h.AdminHandler.MarkdownPreview)
 	})
 
 	// API v1 routes
@@ -85,6 +169,36 @@ func NewRouter(h handler.Handler, svc service.Service, log *zap.Logger) *chi.Mux
 	return r
 }
 
+// PublicRoutes registers the public pages. cmd/export-static renders the same routes
+// into a static site, so pages added here should also be listed there.
+func PublicRoutes(r chi.Router, h handler.Handler) {
+	// Main portfolio page (HTML template)
+	r.Get("/", h.PortfolioHandler.RenderPortfolio)
+
+	// Active CV download (counted)
+	r.Get(service.CVPath, h.CVHandler.Download)
+
+	// Résumé generated from the portfolio data
+	r.Get(resume.Path, h.PortfolioHandler.RenderResume)
+
+	// Project and publication detail pages; former slugs redirect
+	r.Get(model.ProjectsPath+"{slug}", h.PortfolioHandler.RenderProject)
+	r.Get(model.PublicationsPath+"{slug}", h.PortfolioHandler.RenderPublication)
+
+	// BibTeX of every publication
+	r.Get(citation.BibPath, h.PortfolioHandler.RenderBibliography)
+
+	// Crawler files
+	r.Get(seo.SitemapPath, h.PortfolioHandler.RenderSitemap)
+	r.Get(seo.RobotsPath, h.PortfolioHandler.RenderRobots)
+
+	// Atom and RSS feeds of new projects and publications
+	for _, kind := range feed.Kinds {
+		r.Get(kind.AtomPath(), h.PortfolioHandler.RenderFeed)
+		r.Get(kind.RSSPath(), h.PortfolioHandler.RenderFeed)
+	}
+}
+
 // ApiV1Routes creates API v1 routes
 func ApiV1Routes(h handler.Handler, mw mCostume.MiddlewareCostume) *chi.Mux {
 	r := chi.NewRouter()
@@ -140,11 +254,44 @@ func ApiV1Routes(h handler.Handler, mw mCostume.MiddlewareCostume) *chi.Mux {
 			r.Get("/", h.PublicationHandler.GetPublicationByID)
 			r.Put("/", h.PublicationHandler.UpdatePublication)
 			r.Delete("/", h.PublicationHandler.DeletePublication)
+			r.Get("/cite", h.PublicationHandler.CitePublication)
+		})
+	})
+
+	// Education routes
+	r.Route("/education", func(r chi.Router) {
+		r.Get("/", h.EducationHandler.GetAllEducation)
+		r.Post("/", h.EducationHandler.CreateEducation)
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", h.EducationHandler.GetEducationByID)
+			r.Put("/", h.EducationHandler.UpdateEducation)
+			r.Delete("/", h.EducationHandler.DeleteEducation)
 		})
 	})
 
+	// Certification routes
+	r.Route("/certifications", func(r chi.Router) {
+		r.Get("/", h.CertificationHandler.GetAllCertifications)
+		r.Post("/", h.CertificationHandler.CreateCertification)
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", h.CertificationHandler.GetCertificationByID)
+			r.Put("/", h.CertificationHandler.UpdateCertification)
+			r.Delete("/", h.CertificationHandler.DeleteCertification)
+		})
+	})
+
+	// Export
+	r.Get("/export/jsonresume", h.JSONResumeHandler.Export)
+
 	// Contact form submission
 	r.Post("/contact", h.ContactHandler.SubmitContact)
 
+	// Monitoring
+	r.Get("/cache/stats", h.CacheHandler.GetCacheStats)
+
+	// API documentation
+	r.Get("/openapi.json", h.DocsHandler.OpenAPISpec)
+	r.Get("/docs", h.DocsHandler.DocsUI)
+
 	return r
 }
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"session-19/handler"
+	mCostume "session-19/middleware"
+	"session-19/openapi"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// ==================== Static File Tests ====================
+
+// CVs left in the served directory by older versions must not be reachable as static files
+func TestStaticFiles_HidesLegacyCVDir(t *testing.T) {
+	h := staticFiles(http.FS(fstest.MapFS{
+		"assets/uploads/cv/1_cv.pdf":    {Data: []byte("%PDF-1.4")},
+		"assets/uploads/projects/a.png": {Data: []byte("png")},
+	}))
+
+	for path, want := range map[string]int{
+		"/assets/uploads/projects/a.png":          http.StatusOK,
+		"/assets/uploads/cv/1_cv.pdf":             http.StatusNotFound,
+		"/assets/uploads/cv/":                     http.StatusNotFound,
+		"/assets/uploads/projects/../cv/1_cv.pdf": http.StatusNotFound,
+		"//assets/uploads/cv/1_cv.pdf":            http.StatusNotFound,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, path)
+	}
+}
+
+// ==================== OpenAPI Drift Tests ====================
+
+// Every /api/v1 route must be documented and every documented operation must be routed
+func TestApiV1Routes_MatchOpenAPISpec(t *testing.T) {
+	r := ApiV1Routes(handler.Handler{}, mCostume.MiddlewareCostume{})
+
+	var registered []string
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// Sub-routers report "/experiences/" for routes registered as "/"
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		registered = append(registered, method+" "+route)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	documented := openapi.Operations()
+	sort.Strings(registered)
+	sort.Strings(documented)
+
+	assert.Equal(t, registered, documented, "router.ApiV1Routes and openapi route table are out of sync")
+}
+
+func TestOpenAPISpec_OperationsResolve(t *testing.T) {
+	doc := openapi.Spec()
+
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	for _, op := range openapi.Operations() {
+		method, path, _ := strings.Cut(op, " ")
+		item, ok := doc.Paths[path]
+		if assert.True(t, ok, "missing path %s", path) {
+			assert.NotNil(t, item[strings.ToLower(method)], "missing operation %s", op)
+		}
+	}
+	for name, schema := range doc.Components.Schemas {
+		assert.NotEmpty(t, schema.Properties, "schema %s has no properties", name)
+	}
+}
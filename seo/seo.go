@@ -0,0 +1,240 @@
+// Package seo builds the metadata of the public pages: title, description,
+// canonical URL, OpenGraph and Twitter card tags, and schema.org JSON-LD, and
+// the files crawlers read: sitemap.xml and robots.txt.
+//
+// Everything is derived from the portfolio data; the admin SEO settings
+// (model.SEOSettings) override the site-wide values.
+package seo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"session-19/markdown"
+	"session-19/model"
+)
+
+// MaxDescriptionLength is where generated descriptions are cut, about what search results show
+const MaxDescriptionLength = 160
+
+// DefaultName is shown when the profile has no name yet, as on the portfolio page
+const DefaultName = "Alvin Rama Saputra"
+
+// Page is the metadata of one public page. URLs are absolute.
+type Page struct {
+	Title       string
+	Description string
+	Canonical   string
+	Image       string
+	// Type is the OpenGraph type: "profile" for the portfolio, "article" for detail pages
+	Type        string
+	SiteName    string
+	TwitterSite string
+	// JSONLD is the schema.org description of the page, marshalled by html/template
+	JSONLD Thing
+	// Alternates are other formats of the page's content, such as the feeds
+	Alternates []Alternate
+}
+
+// Alternate is a <link rel="alternate"> of a page. Href is root-relative.
+type Alternate struct {
+	Title string
+	Type  string
+	Href  string
+}
+
+// Thing is a schema.org node. Only the properties the portfolio can fill are listed.
+type Thing struct {
+	Context       string   `json:"@context,omitempty"`
+	Type          string   `json:"@type"`
+	Name          string   `json:"name,omitempty"`
+	Headline      string   `json:"headline,omitempty"`
+	JobTitle      string   `json:"jobTitle,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	Image         string   `json:"image,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	SameAs        []string `json:"sameAs,omitempty"`
+	Keywords      string   `json:"keywords,omitempty"`
+	DatePublished string   `json:"datePublished,omitempty"`
+	Author        []Thing  `json:"author,omitempty"`
+	Affiliation   *Thing   `json:"affiliation,omitempty"`
+	IsPartOf      *Thing   `json:"isPartOf,omitempty"`
+	Pagination    string   `json:"pagination,omitempty"`
+}
+
+const schemaContext = "https://schema.org"
+
+// Home returns the metadata of the portfolio page
+func Home(data *model.PortfolioData, base string) Page {
+	p, s := data.Profile, data.SEO
+	person := personOf(p, base)
+	person.Context = schemaContext
+	return Page{
+		Title:       first(s.Title, name(p)+" - Portfolio"),
+		Description: first(s.Description, Summary(p.Description), p.Title),
+		Canonical:   base + "/",
+		Image:       AbsURL(base, first(s.ImageURL, p.PhotoURL)),
+		Type:        "profile",
+		SiteName:    name(p),
+		TwitterSite: s.TwitterHandle,
+		JSONLD:      person,
+	}
+}
+
+// Project returns the metadata of a project's detail page
+func Project(data *model.PortfolioData, project model.Project, base string) Page {
+	page := detail(data, base, project.Title, project.Description, project.Path(), project.ImageURL)
+	page.JSONLD = Thing{
+		Context:     schemaContext,
+		Type:        "CreativeWork",
+		Name:        project.Title,
+		Description: page.Description,
+		URL:         page.Canonical,
+		Image:       AbsURL(base, project.ImageURL),
+		Keywords:    project.TechStack,
+		SameAs:      nonEmpty(project.ProjectURL, project.GithubURL),
+		Author:      []Thing{personOf(data.Profile, base)},
+	}
+	return page
+}
+
+// venueSchemaTypes maps venue types to the schema.org type of what the article is part of
+var venueSchemaTypes = map[string]string{
+	model.VenueJournal:    "Periodical",
+	model.VenueConference: "Book",
+	model.VenuePreprint:   "CreativeWork",
+}
+
+// Publication returns the metadata of a publication's detail page
+func Publication(data *model.PortfolioData, pub model.Publication, base string) Page {
+	page := detail(data, base, pub.Title, pub.Description, pub.Path(), pub.ImageURL)
+	article := Thing{
+		Context:     schemaContext,
+		Type:        "ScholarlyArticle",
+		Name:        pub.Title,
+		Headline:    pub.Title,
+		Description: page.Description,
+		URL:         page.Canonical,
+		Image:       AbsURL(base, pub.ImageURL),
+		SameAs:      nonEmpty(pub.PublicationURL, pub.DOIURL()),
+		Pagination:  pub.Pages,
+	}
+	for _, a := range pub.Authors {
+		author := Thing{Type: "Person", Name: a.Name, URL: a.URL, SameAs: nonEmpty(a.ORCIDURL())}
+		if a.Affiliation != "" {
+			author.Affiliation = &Thing{Type: "Organization", Name: a.Affiliation}
+		}
+		article.Author = append(article.Author, author)
+	}
+	if pub.Year > 0 {
+		article.DatePublished = strconv.Itoa(pub.Year)
+	}
+	if pub.Journal != "" {
+		article.IsPartOf = &Thing{Type: venueSchemaTypes[pub.VenueType], Name: pub.Journal}
+		if article.IsPartOf.Type == "" {
+			article.IsPartOf.Type = "Periodical"
+		}
+	}
+	page.JSONLD = article
+	return page
+}
+
+// detail fills the fields shared by the project and publication pages
+func detail(data *model.PortfolioData, base, title, description, path, image string) Page {
+	p, s := data.Profile, data.SEO
+	return Page{
+		Title:       title + " - " + name(p),
+		Description: first(Summary(description), s.Description, p.Title),
+		Canonical:   base + path,
+		Image:       AbsURL(base, first(image, s.ImageURL, p.PhotoURL)),
+		Type:        "article",
+		SiteName:    name(p),
+		TwitterSite: s.TwitterHandle,
+	}
+}
+
+// personOf describes the portfolio owner
+func personOf(p model.Profile, base string) Thing {
+	person := Thing{
+		Type:        "Person",
+		Name:        name(p),
+		JobTitle:    p.Title,
+		Description: Summary(p.Description),
+		URL:         base + "/",
+		Image:       AbsURL(base, p.PhotoURL),
+		SameAs:      nonEmpty(p.LinkedInURL, p.GithubURL),
+	}
+	if p.Email != "" {
+		person.Email = "mailto:" + p.Email
+	}
+	return person
+}
+
+// BaseURL returns the scheme and host public URLs are built on: the configured
+// site URL, or else the one the request was made to
+func BaseURL(r *http.Request, siteURL string) string {
+	if siteURL != "" {
+		return strings.TrimRight(siteURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// AbsURL resolves a root-relative URL against base; absolute URLs are returned unchanged
+func AbsURL(base, ref string) string {
+	switch {
+	case ref == "":
+		return ""
+	case strings.HasPrefix(ref, "http://"), strings.HasPrefix(ref, "https://"):
+		return ref
+	case strings.HasPrefix(ref, "/"):
+		return base + ref
+	default:
+		return base + "/" + ref
+	}
+}
+
+// Summary turns a Markdown description into a single line of plain text of at most
+// MaxDescriptionLength characters, cut at a word boundary
+func Summary(description string) string {
+	text := strings.Join(strings.Fields(markdown.Text(description)), " ")
+	if utf8.RuneCountInString(text) <= MaxDescriptionLength {
+		return text
+	}
+	runes := []rune(text)[:MaxDescriptionLength-1]
+	cut := string(runes)
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+func name(p model.Profile) string {
+	return first(p.Name, DefaultName)
+}
+
+// first returns the first non-empty value
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package seo
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const base = "https://example.com"
+
+func testData() *model.PortfolioData {
+	return &model.PortfolioData{
+		Profile: model.Profile{
+			Name:        "John Doe",
+			Title:       "Software Engineer",
+			Description: "I build **backends** in Go.",
+			PhotoURL:    "/public/assets/uploads/profile/john.png",
+			Email:       "john@example.com",
+			GithubURL:   "https://github.com/john",
+		},
+	}
+}
+
+func TestHome_Defaults(t *testing.T) {
+	page := Home(testData(), base)
+
+	assert.Equal(t, "John Doe - Portfolio", page.Title)
+	assert.Equal(t, "I build backends in Go.", page.Description)
+	assert.Equal(t, "https://example.com/", page.Canonical)
+	assert.Equal(t, "https://example.com/public/assets/uploads/profile/john.png", page.Image)
+	assert.Equal(t, "profile", page.Type)
+
+	ld, err := json.Marshal(page.JSONLD)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@context": "https://schema.org",
+		"@type": "Person",
+		"name": "John Doe",
+		"jobTitle": "Software Engineer",
+		"description": "I build backends in Go.",
+		"url": "https://example.com/",
+		"image": "https://example.com/public/assets/uploads/profile/john.png",
+		"email": "mailto:john@example.com",
+		"sameAs": ["https://github.com/john"]
+	}`, string(ld))
+}
+
+func TestHome_Overrides(t *testing.T) {
+	data := testData()
+	data.SEO = model.SEOSettings{Title: "John Doe | Go Engineer", Description: "Custom", ImageURL: "https://cdn.example.com/og.png", TwitterHandle: "@john"}
+
+	page := Home(data, base)
+
+	assert.Equal(t, "John Doe | Go Engineer", page.Title)
+	assert.Equal(t, "Custom", page.Description)
+	assert.Equal(t, "https://cdn.example.com/og.png", page.Image)
+	assert.Equal(t, "@john", page.TwitterSite)
+}
+
+func TestProject(t *testing.T) {
+	project := model.Project{
+		Title:       "Portfolio",
+		Slug:        "portfolio",
+		Description: "A site.",
+		TechStack:   "Go, PostgreSQL",
+		GithubURL:   "https://github.com/john/portfolio",
+	}
+
+	page := Project(testData(), project, base)
+
+	assert.Equal(t, "Portfolio - John Doe", page.Title)
+	assert.Equal(t, "https://example.com/projects/portfolio", page.Canonical)
+	assert.Equal(t, "article", page.Type)
+	// Without a project image the profile photo is shared
+	assert.Equal(t, "https://example.com/public/assets/uploads/profile/john.png", page.Image)
+	assert.Equal(t, "CreativeWork", page.JSONLD.Type)
+	assert.Equal(t, "Go, PostgreSQL", page.JSONLD.Keywords)
+	assert.Equal(t, []string{"https://github.com/john/portfolio"}, page.JSONLD.SameAs)
+	assert.Equal(t, "John Doe", page.JSONLD.Author[0].Name)
+}
+
+func TestPublication(t *testing.T) {
+	pub := model.Publication{
+		Title: "On Caching",
+		Slug:  "on-caching",
+		Authors: model.Authors{
+			{Name: "John Doe", IsMe: true, ORCID: "0000-0002-1825-0097"},
+			{Name: "Jane Roe", Affiliation: "MIT", URL: "https://jane.example.com"},
+		},
+		Journal: "Journal of Systems",
+		Year:    2024,
+		DOI:     "10.1000/xyz123",
+	}
+
+	page := Publication(testData(), pub, base)
+
+	assert.Equal(t, "https://example.com/publications/on-caching", page.Canonical)
+	assert.Equal(t, "Software Engineer", page.Description, "falls back when the publication has no description")
+	assert.Equal(t, "ScholarlyArticle", page.JSONLD.Type)
+	assert.Equal(t, "On Caching", page.JSONLD.Headline)
+	assert.Equal(t, "2024", page.JSONLD.DatePublished)
+	assert.Equal(t, []Thing{
+		{Type: "Person", Name: "John Doe", SameAs: []string{"https://orcid.org/0000-0002-1825-0097"}},
+		{Type: "Person", Name: "Jane Roe", URL: "https://jane.example.com", Affiliation: &Thing{Type: "Organization", Name: "MIT"}},
+	}, page.JSONLD.Author)
+	assert.Equal(t, []string{"https://doi.org/10.1000/xyz123"}, page.JSONLD.SameAs)
+	assert.Equal(t, &Thing{Type: "Periodical", Name: "Journal of Systems"}, page.JSONLD.IsPartOf)
+
+	pub.VenueType = model.VenueConference
+	assert.Equal(t, "Book", Publication(testData(), pub, base).JSONLD.IsPartOf.Type)
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "Line one • line two", Summary("Line one\n\n- line two"))
+
+	long := Summary(strings.Repeat("lorem ipsum ", 30))
+	assert.LessOrEqual(t, utf8.RuneCountInString(long), MaxDescriptionLength)
+	assert.True(t, strings.HasSuffix(long, "ipsum…") || strings.HasSuffix(long, "lorem…"), long)
+}
+
+func TestBaseURL(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Host = "portfolio.test:8080"
+	assert.Equal(t, "http://portfolio.test:8080", BaseURL(r, ""))
+
+	r.Header.Set("X-Forwarded-Proto", "https")
+	assert.Equal(t, "https://portfolio.test:8080", BaseURL(r, ""))
+
+	r = httptest.NewRequest("GET", "/", nil)
+	r.TLS = &tls.ConnectionState{}
+	assert.Equal(t, "https://example.com", BaseURL(r, ""))
+
+	assert.Equal(t, "https://john.dev", BaseURL(r, "https://john.dev/"))
+}
+
+func TestAbsURL(t *testing.T) {
+	assert.Equal(t, "", AbsURL(base, ""))
+	assert.Equal(t, "https://cdn.example.com/a.png", AbsURL(base, "https://cdn.example.com/a.png"))
+	assert.Equal(t, "https://example.com/a.png", AbsURL(base, "/a.png"))
+	assert.Equal(t, "https://example.com/a.png", AbsURL(base, "a.png"))
+}
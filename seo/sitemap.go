@@ -0,0 +1,88 @@
+package seo
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"session-19/model"
+)
+
+// Paths of the crawler files, served from the site root where crawlers look for them
+const (
+	SitemapPath = "/sitemap.xml"
+	RobotsPath  = "/robots.txt"
+)
+
+// RobotsDisallow lists the paths kept out of search engines whatever the settings say
+var RobotsDisallow = []string{"/admin", "/login", "/api"}
+
+// URLSet is a sitemap as defined by https://www.sitemaps.org/protocol.html
+type URLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapURL is one page of a sitemap
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Sitemap lists the portfolio page and every project and publication with a detail page
+func Sitemap(data *model.PortfolioData, base string) ([]byte, error) {
+	home := data.Profile.UpdatedAt
+	var details []SitemapURL
+	for _, p := range data.Projects {
+		if p.Path() == "" {
+			continue
+		}
+		details = append(details, SitemapURL{Loc: base + p.Path(), LastMod: lastMod(p.UpdatedAt)})
+		home = latest(home, p.UpdatedAt)
+	}
+	for _, p := range data.Publications {
+		if p.Path() == "" {
+			continue
+		}
+		details = append(details, SitemapURL{Loc: base + p.Path(), LastMod: lastMod(p.UpdatedAt)})
+		home = latest(home, p.UpdatedAt)
+	}
+
+	// The portfolio page shows a card of every detail page, so it changes with any of them
+	set := URLSet{URLs: append([]SitemapURL{{Loc: base + "/", LastMod: lastMod(home)}}, details...)}
+	out, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// Robots returns the robots.txt of the site: the RobotsDisallow paths and the
+// admin's extra rules for every crawler, and where the sitemap is
+func Robots(settings model.SEOSettings, base string) string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	for _, path := range RobotsDisallow {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+	if settings.Robots != "" {
+		b.WriteString(settings.Robots + "\n")
+	}
+	b.WriteString("\nSitemap: " + base + SitemapPath + "\n")
+	return b.String()
+}
+
+// lastMod formats a sitemap modification time, "" when it is unknown
+func lastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,47 @@
+package seo
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"session-19/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemap(t *testing.T) {
+	profileUpdated := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	projectUpdated := time.Date(2026, 3, 2, 10, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+	data := testData()
+	data.Profile.UpdatedAt = profileUpdated
+	data.Projects = []model.Project{
+		{Title: "API", Slug: "api", UpdatedAt: projectUpdated},
+		{Title: "Draft"},
+	}
+	data.Publications = []model.Publication{{Title: "Paper", Slug: "paper"}}
+
+	out, err := Sitemap(data, base)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, string(out), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+
+	var set URLSet
+	require.NoError(t, xml.Unmarshal(out, &set))
+	assert.Equal(t, []SitemapURL{
+		// The portfolio page changes with the newest detail page
+		{Loc: "https://example.com/", LastMod: "2026-03-02T03:30:00Z"},
+		{Loc: "https://example.com/projects/api", LastMod: "2026-03-02T03:30:00Z"},
+		{Loc: "https://example.com/publications/paper"},
+	}, set.URLs)
+}
+
+func TestRobots(t *testing.T) {
+	robots := Robots(model.SEOSettings{}, base)
+	assert.Equal(t, "User-agent: *\nDisallow: /admin\nDisallow: /login\nDisallow: /api\n\n"+
+		"Sitemap: https://example.com/sitemap.xml\n", robots)
+
+	robots = Robots(model.SEOSettings{Robots: "Disallow: /drafts"}, base)
+	assert.Contains(t, robots, "Disallow: /api\nDisallow: /drafts\n\nSitemap:")
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
 
+	"github.com/jackc/pgx/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -32,17 +34,20 @@ func NewAuthService(userRepo repository.UserRepositoryInterface) AuthServiceInte
 // Login authenticates a user
 func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*model.User, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, &ValidationError{Message: err.Error()}
 	}
 
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, err
 	}
 
 	// Compare password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, ErrInvalidCredentials
 	}
 
 	return user, nil
@@ -51,19 +56,19 @@ func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*model.
 // Register creates a new user
 func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*model.User, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, &ValidationError{Message: err.Error()}
 	}
 
 	// Check if email already exists
 	existingUser, _ := s.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, &ConflictError{Resource: "user", Message: "email already registered"}
 	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, errors.New("failed to process password")
+		return nil, fmt.Errorf("failed to process password: %w", err)
 	}
 
 	user := &model.User{
@@ -74,7 +79,7 @@ func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
-		return nil, err
+		return nil, repoError("user", 0, err)
 	}
 
 	return user, nil
@@ -82,5 +87,9 @@ func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 
 // GetUserByID retrieves a user by ID
 func (s *AuthService) GetUserByID(ctx context.Context, id int64) (*model.User, error) {
-	return s.userRepo.GetByID(ctx, id)
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, repoError("user", id, err)
+	}
+	return user, nil
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"session-19/backup"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"session-19/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RestoreResult is the outcome of restoring a backup archive
+type RestoreResult struct {
+	Manifest backup.Manifest `json:"manifest"`
+	// CreatedUsers are users the archive added. Archives carry no password hashes,
+	// so they cannot log in until a password is set.
+	CreatedUsers []string `json:"created_users"`
+}
+
+// BackupServiceInterface defines the interface for backing up and restoring all portfolio content
+type BackupServiceInterface interface {
+	CreateBackup(ctx context.Context, w io.Writer) (*backup.Manifest, error)
+	RestoreBackup(ctx context.Context, r io.ReaderAt, size int64) (*RestoreResult, error)
+}
+
+// BackupService writes and restores backup archives: every record as JSON, users
+// without their password hashes, and the uploaded files the records reference
+type BackupService struct {
+	repo      repository.BackupRepositoryInterface
+	portfolio PortfolioServiceInterface
+	storage   storage.Storage
+	now       func() time.Time
+}
+
+// NewBackupService creates a new backup service. Restores are recorded on portfolio
+// as a mutation, which also invalidates its cache.
+func NewBackupService(repo repository.BackupRepositoryInterface, portfolio PortfolioServiceInterface, store storage.Storage) BackupServiceInterface {
+	return &BackupService{repo: repo, portfolio: portfolio, storage: store, now: time.Now}
+}
+
+// CreateBackup writes an archive of the current content to w
+func (s *BackupService) CreateBackup(ctx context.Context, w io.Writer) (*backup.Manifest, error) {
+	data, err := s.repo.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bw := backup.NewWriter(w, s.now())
+	if err := bw.WriteData(data); err != nil {
+		return nil, err
+	}
+
+	for _, key := range s.uploadKeys(data) {
+		rc, err := s.storage.Get(ctx, key)
+		if errors.Is(err, storage.ErrNotFound) {
+			bw.AddMissing(key)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		err = bw.AddFile(key, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bw.Close()
+}
+
+// RestoreBackup replaces all content with the archive's. The archive is checked
+// completely before anything is written; files are stored first and the records
+// are then replaced in one transaction.
+func (s *BackupService) RestoreBackup(ctx context.Context, r io.ReaderAt, size int64) (*RestoreResult, error) {
+	archive, err := backup.Open(r, size)
+	if err != nil {
+		return nil, validationResult([]FieldError{fieldError("file", FieldInvalid, err)})
+	}
+	if err := archive.Verify(); err != nil {
+		return nil, validationResult([]FieldError{fieldError("file", FieldInvalid, err)})
+	}
+	for _, file := range archive.Manifest.Files {
+		if !backedUp(file.Key) {
+			return nil, validationResult([]FieldError{fieldError("file", FieldInvalid,
+				fmt.Errorf("%w: %s is not an upload", backup.ErrCorrupt, file.Key))})
+		}
+	}
+
+	// Files go first: if the transaction fails they are left as orphans for media-gc,
+	// whereas records restored without their files would be broken
+	for _, file := range archive.Manifest.Files {
+		if err := s.restoreFile(ctx, archive, file.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	assignSlugs(archive.Data)
+	created, err := s.repo.Replace(ctx, archive.Data)
+	if err != nil {
+		return nil, err
+	}
+	s.portfolio.MarkModified()
+
+	if created == nil {
+		created = []string{}
+	}
+	return &RestoreResult{Manifest: archive.Manifest, CreatedUsers: created}, nil
+}
+
+func (s *BackupService) restoreFile(ctx context.Context, archive *backup.Archive, key string) error {
+	rc, err := archive.OpenFile(key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := s.storage.Put(ctx, key, rc, mime.TypeByExtension(path.Ext(key))); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", key, err)
+	}
+	return nil
+}
+
+// assignSlugs gives records from archives written before slugs existed one
+// derived from their title, unique within the archive
+func assignSlugs(data *model.BackupData) {
+	used := map[string]bool{}
+	for _, p := range data.Projects {
+		used[p.Slug] = true
+	}
+	for i, p := range data.Projects {
+		if p.Slug == "" {
+			data.Projects[i].Slug = freeSlug("project", p.Title, used)
+		}
+	}
+
+	used = map[string]bool{}
+	for _, p := range data.Publications {
+		used[p.Slug] = true
+	}
+	for i, p := range data.Publications {
+		if p.Slug == "" {
+			data.Publications[i].Slug = freeSlug("publication", p.Title, used)
+		}
+	}
+}
+
+// freeSlug returns a slug for title that is not in used, and adds it
+func freeSlug(resource, title string, used map[string]bool) string {
+	base := utils.Slugify(title)
+	if base == "" {
+		base = resource
+	}
+	slug, _ := utils.UniqueSlug(base, func(s string) (bool, error) { return used[s], nil })
+	used[slug] = true
+	return slug
+}
+
+// backedUp reports whether a storage key belongs to an upload, public or private
+func backedUp(key string) bool {
+	return strings.HasPrefix(key, utils.UploadsPrefix) || strings.HasPrefix(key, storage.PrivatePrefix)
+}
+
+// uploadKeys returns the storage keys of every upload data references, sorted.
+// Links elsewhere and bundled assets outside the upload prefixes are not backed up.
+func (s *BackupService) uploadKeys(data *model.BackupData) []string {
+	seen := map[string]bool{}
+	add := func(url string) {
+		if key, ok := s.storage.Key(url); ok && backedUp(key) {
+			seen[key] = true
+		}
+	}
+	addVariants := func(variants model.ImageVariants) {
+		for _, v := range variants {
+			add(v.URL)
+		}
+	}
+
+	if p := data.Profile; p != nil {
+		add(p.PhotoURL)
+		add(p.CVURL)
+	}
+	if seo := data.SEO; seo != nil {
+		add(seo.ImageURL)
+	}
+	for _, p := range data.Projects {
+		add(p.ImageURL)
+		addVariants(p.ImageVariants)
+	}
+	for _, p := range data.Publications {
+		add(p.ImageURL)
+		addVariants(p.ImageVariants)
+	}
+	for _, t := range data.Testimonials {
+		add(t.AvatarURL)
+	}
+	for _, m := range data.Media {
+		add(m.URL)
+		addVariants(m.Variants)
+	}
+	for _, cv := range data.CVVersions {
+		add(cv.URL)
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
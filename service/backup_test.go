@@ -0,0 +1,178 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"session-19/backup"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBackupService creates a backup service over a mock repository, a cached
+// portfolio service and local storage
+func newTestBackupService(t *testing.T) (*BackupService, *repository.MockBackupRepository, *CachedPortfolioService, storage.Storage, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store := storage.NewLocal(dir, t.TempDir(), storage.DefaultLocalURL)
+	mockBackupRepo := new(repository.MockBackupRepository)
+	portfolio := NewCachedPortfolioService(NewPortfolioService(new(repository.MockPortfolioRepository)), 0)
+	svc := NewBackupService(mockBackupRepo, portfolio, store).(*BackupService)
+	svc.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return svc, mockBackupRepo, portfolio, store, dir
+}
+
+// ==================== Backup Service Tests ====================
+
+func TestBackupService_CreateBackup_IncludesReferencedUploads(t *testing.T) {
+	svc, mockBackupRepo, _, store, _ := newTestBackupService(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "uploads/projects/3.png", strings.NewReader("png"), "image/png"))
+	require.NoError(t, store.Put(ctx, "uploads/media/jane.png", strings.NewReader("avatar"), "image/png"))
+	require.NoError(t, store.Put(ctx, "private/cv/1_cv.pdf", strings.NewReader("%PDF-1.4"), "application/pdf"))
+	require.NoError(t, store.Put(ctx, "uploads/projects/unused.png", strings.NewReader("orphan"), "image/png"))
+	data := &model.BackupData{
+		Profile: &model.Profile{ID: 1, PhotoURL: "/public/assets/profile.jpg", CVURL: "/cv"},
+		Projects: []model.Project{{
+			ID:            3,
+			ImageURL:      "/public/assets/uploads/projects/3.png",
+			ImageVariants: model.ImageVariants{{URL: "/public/assets/uploads/projects/3.png", Width: 640, Type: "image/png"}},
+		}},
+		Testimonials: []model.Testimonial{{ID: 2, Author: "Jane", AvatarURL: "/public/assets/uploads/media/jane.png"}},
+		CVVersions:   []model.CVVersion{{ID: 1, URL: "/public/assets/private/cv/1_cv.pdf"}},
+		SEO:          &model.SEOSettings{ImageURL: "/public/assets/uploads/media/og.png"},
+	}
+	mockBackupRepo.On("Snapshot", ctx).Return(data, nil).Once()
+
+	var buf bytes.Buffer
+	manifest, err := svc.CreateBackup(ctx, &buf)
+
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 3)
+	assert.Equal(t, "private/cv/1_cv.pdf", manifest.Files[0].Key)
+	assert.Equal(t, "uploads/media/jane.png", manifest.Files[1].Key)
+	assert.Equal(t, "uploads/projects/3.png", manifest.Files[2].Key)
+	assert.Equal(t, []string{"uploads/media/og.png"}, manifest.Missing)
+
+	archive, err := backup.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, data, archive.Data)
+}
+
+func TestBackupService_RestoreBackup_WritesFilesAndReplacesRecords(t *testing.T) {
+	svc, mockBackupRepo, portfolio, _, dir := newTestBackupService(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, time.Now())
+	data := &model.BackupData{Projects: []model.Project{{ID: 3, Title: "Site", ImageURL: "/public/assets/uploads/projects/3.png"}}}
+	require.NoError(t, w.WriteData(data))
+	require.NoError(t, w.AddFile("uploads/projects/3.png", strings.NewReader("png")))
+	_, err := w.Close()
+	require.NoError(t, err)
+
+	mockBackupRepo.On("Replace", ctx, mock.MatchedBy(func(d *model.BackupData) bool {
+		return len(d.Projects) == 1 && d.Projects[0].Title == "Site"
+	})).Return([]string{"new@example.com"}, nil).Once()
+	before := portfolio.LastModified()
+
+	result, err := svc.RestoreBackup(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new@example.com"}, result.CreatedUsers)
+	content, err := os.ReadFile(filepath.Join(dir, "uploads", "projects", "3.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+	assert.Equal(t, uint64(1), portfolio.CacheStats().Invalidations)
+	// Conditional requests must not be answered 304 with content from before the restore
+	assert.True(t, portfolio.LastModified().After(before))
+	mockBackupRepo.AssertExpectations(t)
+}
+
+func TestBackupService_RestoreBackup_AssignsMissingSlugs(t *testing.T) {
+	svc, mockBackupRepo, _, _, _ := newTestBackupService(t)
+	ctx := context.Background()
+
+	// Archives written before slugs existed carry none
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, time.Now())
+	require.NoError(t, w.WriteData(&model.BackupData{
+		Projects:     []model.Project{{ID: 1, Title: "Site"}, {ID: 2, Title: "Site"}, {ID: 3, Title: "Blog", Slug: "site"}},
+		Publications: []model.Publication{{ID: 1, Title: "!!!"}},
+	}))
+	_, err := w.Close()
+	require.NoError(t, err)
+
+	mockBackupRepo.On("Replace", ctx, mock.MatchedBy(func(d *model.BackupData) bool {
+		return d.Projects[0].Slug == "site-2" && d.Projects[1].Slug == "site-3" && d.Projects[2].Slug == "site" &&
+			d.Publications[0].Slug == "publication"
+	})).Return([]string{}, nil).Once()
+
+	_, err = svc.RestoreBackup(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	require.NoError(t, err)
+	mockBackupRepo.AssertExpectations(t)
+}
+
+func TestBackupService_RestoreBackup_RejectsInvalidArchiveBeforeWriting(t *testing.T) {
+	svc, mockBackupRepo, _, _, dir := newTestBackupService(t)
+	ctx := context.Background()
+
+	_, err := svc.RestoreBackup(ctx, strings.NewReader("not a zip"), 9)
+
+	assert.ErrorIs(t, err, backup.ErrNotBackup)
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+	mockBackupRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+}
+
+func TestBackupService_RestoreBackup_RejectsFilesOutsideUploads(t *testing.T) {
+	svc, mockBackupRepo, _, _, dir := newTestBackupService(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, time.Now())
+	require.NoError(t, w.WriteData(&model.BackupData{}))
+	require.NoError(t, w.AddFile("index.html", strings.NewReader("<script>")))
+	_, err := w.Close()
+	require.NoError(t, err)
+
+	_, err = svc.RestoreBackup(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	assert.ErrorIs(t, err, backup.ErrCorrupt)
+	_, statErr := os.Stat(filepath.Join(dir, "index.html"))
+	assert.True(t, errors.Is(statErr, os.ErrNotExist))
+	mockBackupRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+}
+
+func TestBackupService_RestoreBackup_KeepsCacheWhenReplaceFails(t *testing.T) {
+	svc, mockBackupRepo, portfolio, _, _ := newTestBackupService(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, time.Now())
+	require.NoError(t, w.WriteData(&model.BackupData{}))
+	_, err := w.Close()
+	require.NoError(t, err)
+
+	mockBackupRepo.On("Replace", ctx, mock.Anything).Return(nil, errors.New("tx failed")).Once()
+	before := portfolio.LastModified()
+
+	_, err = svc.RestoreBackup(ctx, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+	assert.Error(t, err)
+	assert.Zero(t, portfolio.CacheStats().Invalidations)
+	assert.Equal(t, before, portfolio.LastModified())
+}
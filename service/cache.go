@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"session-19/dto"
+	"session-19/model"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheStats holds the portfolio cache counters exposed for monitoring
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+	Cached        bool   `json:"cached"`
+}
+
+// CacheStatsProvider is implemented by services that keep cache counters
+type CacheStatsProvider interface {
+	CacheStats() CacheStats
+}
+
+// CachedPortfolioService decorates a PortfolioServiceInterface with an in-process
+// cache of the assembled PortfolioData. Concurrent misses share a single load and
+// every Create/Update/Delete call drops the cached value.
+//
+// Mutations added to PortfolioServiceInterface must be overridden here as well,
+// otherwise they are promoted from the embedded service without invalidating.
+type CachedPortfolioService struct {
+	PortfolioServiceInterface
+
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu         sync.RWMutex
+	data       *model.PortfolioData
+	expiresAt  time.Time
+	generation uint64
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// NewCachedPortfolioService wraps svc with a PortfolioData cache.
+// A positive ttl also expires entries, which picks up changes made outside the application.
+func NewCachedPortfolioService(svc PortfolioServiceInterface, ttl time.Duration) *CachedPortfolioService {
+	return &CachedPortfolioService{
+		PortfolioServiceInterface: svc,
+		ttl:                       ttl,
+	}
+}
+
+// GetPortfolioData returns the cached portfolio data, loading it on a miss.
+// The returned value is shared between callers and must be treated as read-only.
+func (s *CachedPortfolioService) GetPortfolioData(ctx context.Context) (*model.PortfolioData, error) {
+	s.mu.RLock()
+	data, generation := s.data, s.generation
+	fresh := data != nil && (s.ttl <= 0 || time.Now().Before(s.expiresAt))
+	s.mu.RUnlock()
+
+	if fresh {
+		s.hits.Add(1)
+		return data, nil
+	}
+	s.misses.Add(1)
+
+	// Keying by generation keeps callers that arrive after an invalidation
+	// from joining a load that started before it
+	key := strconv.FormatUint(generation, 10)
+	ch := s.group.DoChan(key, func() (any, error) {
+		// The load is shared, so it must not be cancelled with the first caller's request
+		data, err := s.PortfolioServiceInterface.GetPortfolioData(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		// Partial results are served but not cached, so the next request retries the failed sections
+		if !data.Partial() {
+			s.store(data, generation)
+		}
+		return data, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*model.PortfolioData), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// CacheStats returns the current cache counters
+func (s *CachedPortfolioService) CacheStats() CacheStats {
+	s.mu.RLock()
+	cached := s.data != nil
+	s.mu.RUnlock()
+
+	return CacheStats{
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		Invalidations: s.invalidations.Load(),
+		Cached:        cached,
+	}
+}
+
+// Invalidate drops the cached portfolio data
+func (s *CachedPortfolioService) Invalidate() {
+	s.mu.Lock()
+	s.data = nil
+	s.generation++
+	s.mu.Unlock()
+	s.invalidations.Add(1)
+}
+
+// store caches data unless an invalidation happened while it was loading
+func (s *CachedPortfolioService) store(data *model.PortfolioData, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generation != generation {
+		return
+	}
+	s.data = data
+	s.expiresAt = time.Now().Add(s.ttl)
+}
+
+// Profile operations
+func (s *CachedPortfolioService) CreateProfile(ctx context.Context, req *dto.ProfileRequest) (*model.Profile, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateProfile(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateProfile(ctx context.Context, id int64, req *dto.ProfileRequest) (*model.Profile, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateProfile(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateProfileCVURL(ctx, cvURL)
+}
+
+// Experience operations
+func (s *CachedPortfolioService) CreateExperience(ctx context.Context, req *dto.ExperienceRequest) (*model.Experience, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateExperience(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateExperience(ctx context.Context, id int64, req *dto.ExperienceRequest) (*model.Experience, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateExperience(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeleteExperience(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteExperience(ctx, id)
+}
+
+// Skill operations
+func (s *CachedPortfolioService) CreateSkill(ctx context.Context, req *dto.SkillRequest) (*model.Skill, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateSkill(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateSkill(ctx context.Context, id int64, req *dto.SkillRequest) (*model.Skill, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateSkill(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeleteSkill(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteSkill(ctx, id)
+}
+
+// Project operations
+func (s *CachedPortfolioService) CreateProject(ctx context.Context, req *dto.ProjectRequest) (*model.Project, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateProject(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateProject(ctx context.Context, id int64, req *dto.ProjectRequest) (*model.Project, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateProject(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeleteProject(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteProject(ctx, id)
+}
+
+// Publication operations
+func (s *CachedPortfolioService) CreatePublication(ctx context.Context, req *dto.PublicationRequest) (*model.Publication, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreatePublication(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdatePublication(ctx context.Context, id int64, req *dto.PublicationRequest) (*model.Publication, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdatePublication(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeletePublication(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeletePublication(ctx, id)
+}
+
+// Education operations
+func (s *CachedPortfolioService) CreateEducation(ctx context.Context, req *dto.EducationRequest) (*model.Education, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateEducation(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateEducation(ctx context.Context, id int64, req *dto.EducationRequest) (*model.Education, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateEducation(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeleteEducation(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteEducation(ctx, id)
+}
+
+// Certification operations
+func (s *CachedPortfolioService) CreateCertification(ctx context.Context, req *dto.CertificationRequest) (*model.Certification, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateCertification(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateCertification(ctx context.Context, id int64, req *dto.CertificationRequest) (*model.Certification, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateCertification(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) DeleteCertification(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteCertification(ctx, id)
+}
+
+// Testimonial operations
+func (s *CachedPortfolioService) CreateTestimonial(ctx context.Context, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.CreateTestimonial(ctx, req)
+}
+
+func (s *CachedPortfolioService) UpdateTestimonial(ctx context.Context, id int64, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateTestimonial(ctx, id, req)
+}
+
+func (s *CachedPortfolioService) SetTestimonialStatus(ctx context.Context, id int64, status string) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.SetTestimonialStatus(ctx, id, status)
+}
+
+func (s *CachedPortfolioService) DeleteTestimonial(ctx context.Context, id int64) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.DeleteTestimonial(ctx, id)
+}
+
+// SEO settings
+func (s *CachedPortfolioService) UpdateSEOSettings(ctx context.Context, req *dto.SEORequest) (*model.SEOSettings, error) {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateSEOSettings(ctx, req)
+}
+
+// Transactions
+func (s *CachedPortfolioService) UpdateInTransaction(ctx context.Context, fn func(tx PortfolioServiceInterface) error) error {
+	defer s.Invalidate()
+	return s.PortfolioServiceInterface.UpdateInTransaction(ctx, fn)
+}
+
+// MarkModified records an outside mutation and drops the cached data
+func (s *CachedPortfolioService) MarkModified() {
+	defer s.Invalidate()
+	s.PortfolioServiceInterface.MarkModified()
+}
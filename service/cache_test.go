@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/repository"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestCachedService creates a cached portfolio service backed by a mock repository
+func newTestCachedService(ttl time.Duration) (*CachedPortfolioService, *repository.MockPortfolioRepository) {
+	mockRepo := new(repository.MockPortfolioRepository)
+	return NewCachedPortfolioService(NewPortfolioService(mockRepo), ttl), mockRepo
+}
+
+// ==================== Portfolio Cache Tests ====================
+
+func TestCachedPortfolioService_GetPortfolioData_HitAfterMiss(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	expected := &model.PortfolioData{Profile: model.Profile{Name: "John Doe"}}
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(expected, nil).Once()
+
+	first, err := svc.GetPortfolioData(ctx)
+	assert.NoError(t, err)
+	second, err := svc.GetPortfolioData(ctx)
+	assert.NoError(t, err)
+
+	assert.Same(t, expected, first)
+	assert.Same(t, expected, second)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Cached: true}, svc.CacheStats())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedPortfolioService_GetPortfolioData_ErrorNotCached(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	expected := &model.PortfolioData{}
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(nil, errors.New("database error")).Once()
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(expected, nil).Once()
+
+	_, err := svc.GetPortfolioData(ctx)
+	assert.Error(t, err)
+
+	result, err := svc.GetPortfolioData(ctx)
+	assert.NoError(t, err)
+	assert.Same(t, expected, result)
+	assert.Equal(t, uint64(2), svc.CacheStats().Misses)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedPortfolioService_GetPortfolioData_Expires(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Millisecond)
+	ctx := context.Background()
+
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(&model.PortfolioData{}, nil).Twice()
+
+	_, _ = svc.GetPortfolioData(ctx)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = svc.GetPortfolioData(ctx)
+
+	assert.Equal(t, uint64(2), svc.CacheStats().Misses)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedPortfolioService_GetPortfolioData_CoalescesConcurrentMisses(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	mockRepo.On("GetPortfolioData", mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(&model.PortfolioData{}, nil).Once()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.GetPortfolioData(ctx)
+			assert.NoError(t, err)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	mockRepo.AssertNumberOfCalls(t, "GetPortfolioData", 1)
+}
+
+func TestCachedPortfolioService_Mutation_Invalidates(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(&model.PortfolioData{}, nil).Twice()
+	mockRepo.On("ProjectSlugTaken", ctx, "new-project", int64(0)).Return(false, nil).Once()
+	mockRepo.On("CreateProject", ctx, mock.AnythingOfType("*model.Project")).Return(nil).Once()
+
+	_, _ = svc.GetPortfolioData(ctx)
+	_, err := svc.CreateProject(ctx, &dto.ProjectRequest{Title: "New Project", Description: "Description"})
+	assert.NoError(t, err)
+	_, _ = svc.GetPortfolioData(ctx)
+
+	stats := svc.CacheStats()
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Invalidations)
+	mockRepo.AssertExpectations(t)
+}
+
+// Every Create/Update/Delete method of the interface must invalidate the cache,
+// including ones added after the decorator was written
+func TestCachedPortfolioService_AllMutationsInvalidate(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	iface := reflect.TypeOf((*PortfolioServiceInterface)(nil)).Elem()
+	value := reflect.ValueOf(svc)
+
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		if !strings.HasPrefix(method.Name, "Create") && !strings.HasPrefix(method.Name, "Update") && !strings.HasPrefix(method.Name, "Delete") {
+			continue
+		}
+
+		t.Run(method.Name, func(t *testing.T) {
+			mockRepo.On(method.Name, mock.Anything, mock.Anything).Return(errors.New("repository failure")).Maybe()
+
+			args := []reflect.Value{reflect.ValueOf(ctx)}
+			for j := 1; j < method.Type.NumIn(); j++ {
+				in := method.Type.In(j)
+				switch in.Kind() {
+				case reflect.Ptr:
+					args = append(args, reflect.New(in.Elem()))
+				case reflect.Int64:
+					args = append(args, reflect.ValueOf(int64(1)))
+				default:
+					args = append(args, reflect.Zero(in))
+				}
+			}
+
+			before := svc.CacheStats().Invalidations
+			value.MethodByName(method.Name).Call(args)
+			assert.Equal(t, before+1, svc.CacheStats().Invalidations)
+		})
+	}
+}
+
+func TestCachedPortfolioService_GetPortfolioData_PartialNotCached(t *testing.T) {
+	svc, mockRepo := newTestCachedService(time.Minute)
+	ctx := context.Background()
+
+	partial := &model.PortfolioData{Failures: []model.SectionFailure{{Section: model.SectionProjects}}}
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(partial, nil).Twice()
+
+	first, err := svc.GetPortfolioData(ctx)
+	assert.NoError(t, err)
+	assert.True(t, first.Partial())
+	_, _ = svc.GetPortfolioData(ctx)
+
+	assert.False(t, svc.CacheStats().Cached)
+	mockRepo.AssertExpectations(t)
+}
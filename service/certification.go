@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/repository"
+	"strings"
+)
+
+// CertificationServiceInterface defines the interface for certification service
+type CertificationServiceInterface interface {
+	GetAllCertifications(ctx context.Context) ([]model.Certification, error)
+	GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error)
+	CreateCertification(ctx context.Context, req *dto.CertificationRequest) (*model.Certification, error)
+	UpdateCertification(ctx context.Context, id int64, req *dto.CertificationRequest) (*model.Certification, error)
+	DeleteCertification(ctx context.Context, id int64) error
+}
+
+// CertificationService implements CertificationServiceInterface
+type CertificationService struct {
+	repo repository.PortfolioRepositoryInterface
+}
+
+// NewCertificationService creates a new certification service
+func NewCertificationService(repo repository.PortfolioRepositoryInterface) CertificationServiceInterface {
+	return &CertificationService{
+		repo: repo,
+	}
+}
+
+// GetAllCertifications retrieves all certifications
+func (s *CertificationService) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	return s.repo.GetAllCertifications(ctx)
+}
+
+// GetCertificationByID retrieves a certification by ID
+func (s *CertificationService) GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	cert, err := s.repo.GetCertificationByID(ctx, id)
+	if err != nil {
+		return nil, repoError("certification", id, err)
+	}
+	return cert, nil
+}
+
+// CreateCertification creates a new certification
+func (s *CertificationService) CreateCertification(ctx context.Context, req *dto.CertificationRequest) (*model.Certification, error) {
+	if err := ValidateCertificationRequest(req); err != nil {
+		return nil, err
+	}
+
+	cert := newCertification(req)
+	if err := s.repo.CreateCertification(ctx, cert); err != nil {
+		return nil, repoError("certification", 0, err)
+	}
+
+	return cert, nil
+}
+
+// UpdateCertification updates a certification
+func (s *CertificationService) UpdateCertification(ctx context.Context, id int64, req *dto.CertificationRequest) (*model.Certification, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateCertificationRequest(req); err != nil {
+		return nil, err
+	}
+
+	cert := newCertification(req)
+	cert.ID = id
+	if err := s.repo.UpdateCertification(ctx, cert); err != nil {
+		return nil, repoError("certification", id, err)
+	}
+
+	return cert, nil
+}
+
+// DeleteCertification deletes a certification
+func (s *CertificationService) DeleteCertification(ctx context.Context, id int64) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	return repoError("certification", id, s.repo.DeleteCertification(ctx, id))
+}
+
+// newCertification builds the certification of a validated request
+func newCertification(req *dto.CertificationRequest) *model.Certification {
+	return &model.Certification{
+		Name:            strings.TrimSpace(req.Name),
+		Issuer:          strings.TrimSpace(req.Issuer),
+		CredentialID:    strings.TrimSpace(req.CredentialID),
+		IssueDate:       strings.TrimSpace(req.IssueDate),
+		ExpiryDate:      strings.TrimSpace(req.ExpiryDate),
+		VerificationURL: strings.TrimSpace(req.VerificationURL),
+		Color:           getDefaultColor(req.Color, "orange"),
+	}
+}
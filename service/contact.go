@@ -20,6 +20,10 @@ func NewContactService() ContactServiceInterface {
 
 // SubmitContact handles contact form submission
 func (s *ContactService) SubmitContact(ctx context.Context, req *dto.ContactRequest) error {
+	if err := ValidateContactRequest(req); err != nil {
+		return err
+	}
+
 	// In a real application, you would send an email or store the contact message
 	// For now, we just return nil (success)
 	return nil
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"session-19/utils"
+)
+
+// CVPath serves the active CV. Activating a version points the profile's CV link here,
+// so downloads are counted and the link survives uploading a new version.
+const CVPath = "/cv"
+
+// ErrActiveCVDelete is returned when deleting the version the profile links to
+var ErrActiveCVDelete = &ConflictError{Resource: "cv version", Message: "the active CV cannot be deleted; activate another version or link a generated résumé first"}
+
+// CVServiceInterface defines the interface for CV version service
+type CVServiceInterface interface {
+	GetAllCVVersions(ctx context.Context) ([]model.CVVersion, error)
+	UploadCV(ctx context.Context, pdf *utils.PDFUpload, activate bool) (*model.CVVersion, error)
+	ActivateCV(ctx context.Context, id int64) error
+	LinkGeneratedCV(ctx context.Context, url string) error
+	DeleteCV(ctx context.Context, id int64) error
+	OpenCV(ctx context.Context, id int64) (*model.CVVersion, io.ReadCloser, error)
+	DownloadActiveCV(ctx context.Context) (*model.CVVersion, io.ReadCloser, error)
+}
+
+// CVService manages uploaded CV versions
+type CVService struct {
+	repo      repository.CVRepositoryInterface
+	portfolio PortfolioServiceInterface
+	storage   storage.Storage
+}
+
+// NewCVService creates a new CV service. The profile link is updated through portfolio
+// so that a cached portfolio is invalidated.
+func NewCVService(repo repository.CVRepositoryInterface, portfolio PortfolioServiceInterface, store storage.Storage) CVServiceInterface {
+	return &CVService{repo: repo, portfolio: portfolio, storage: store}
+}
+
+// GetAllCVVersions retrieves the CV history, newest first
+func (s *CVService) GetAllCVVersions(ctx context.Context) ([]model.CVVersion, error) {
+	return s.repo.GetAllCVVersions(ctx)
+}
+
+// UploadCV stores a new CV version, optionally making it the active one
+func (s *CVService) UploadCV(ctx context.Context, pdf *utils.PDFUpload, activate bool) (*model.CVVersion, error) {
+	url, err := pdf.Save(ctx, s.storage, utils.CVUploadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cv := &model.CVVersion{
+		Filename: pdf.Filename,
+		URL:      url,
+		Size:     int64(len(pdf.Data)),
+		Hash:     pdf.Hash,
+	}
+	if err := s.repo.CreateCVVersion(ctx, cv); err != nil {
+		s.deleteFile(ctx, cv)
+		return nil, repoError("cv version", 0, err)
+	}
+
+	if activate {
+		if err := s.ActivateCV(ctx, cv.ID); err != nil {
+			return cv, err
+		}
+		cv.Active = true
+	}
+	return cv, nil
+}
+
+// ActivateCV makes a version the active CV and links it from the profile
+func (s *CVService) ActivateCV(ctx context.Context, id int64) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	if err := s.repo.ActivateCVVersion(ctx, id); err != nil {
+		return repoError("cv version", id, err)
+	}
+	return s.portfolio.UpdateProfileCVURL(ctx, CVPath)
+}
+
+// LinkGeneratedCV links the profile's CV to a generated résumé at url. No version
+// stays active, so /cv stops serving one the profile no longer links to and every
+// version can be deleted.
+func (s *CVService) LinkGeneratedCV(ctx context.Context, url string) error {
+	if err := s.repo.DeactivateCVVersions(ctx); err != nil {
+		return repoError("cv version", 0, err)
+	}
+	return s.portfolio.UpdateProfileCVURL(ctx, url)
+}
+
+// DeleteCV removes an inactive version and its file
+func (s *CVService) DeleteCV(ctx context.Context, id int64) error {
+	cv, err := s.getCVVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cv.Active {
+		return ErrActiveCVDelete
+	}
+
+	if err := s.repo.DeleteCVVersion(ctx, id); err != nil {
+		return repoError("cv version", id, err)
+	}
+	// The row is gone, so a file left behind is an orphan for the media-gc command
+	s.deleteFile(ctx, cv)
+	return nil
+}
+
+// OpenCV opens any version for reading, without counting a download
+func (s *CVService) OpenCV(ctx context.Context, id int64) (*model.CVVersion, io.ReadCloser, error) {
+	cv, err := s.getCVVersion(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.open(ctx, cv)
+}
+
+// DownloadActiveCV opens the active version for reading and counts the download
+func (s *CVService) DownloadActiveCV(ctx context.Context) (*model.CVVersion, io.ReadCloser, error) {
+	cv, err := s.repo.GetActiveCVVersion(ctx)
+	if err != nil {
+		return nil, nil, repoError("cv", 0, err)
+	}
+	cv, rc, err := s.open(ctx, cv)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The counter is informational; a failed update must not block the download
+	if err := s.repo.IncrementCVDownloads(ctx, cv.ID); err == nil {
+		cv.Downloads++
+	}
+	return cv, rc, nil
+}
+
+// getCVVersion loads a version by ID
+func (s *CVService) getCVVersion(ctx context.Context, id int64) (*model.CVVersion, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	cv, err := s.repo.GetCVVersionByID(ctx, id)
+	if err != nil {
+		return nil, repoError("cv version", id, err)
+	}
+	return cv, nil
+}
+
+// open reads the stored file of a version
+func (s *CVService) open(ctx context.Context, cv *model.CVVersion) (*model.CVVersion, io.ReadCloser, error) {
+	key, ok := s.storage.Key(cv.URL)
+	if !ok {
+		return nil, nil, &NotFoundError{Resource: "cv file", ID: cv.ID}
+	}
+	rc, err := s.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, &NotFoundError{Resource: "cv file", ID: cv.ID}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return cv, rc, nil
+}
+
+// deleteFile removes the stored PDF of a version, best effort
+func (s *CVService) deleteFile(ctx context.Context, cv *model.CVVersion) {
+	if key, ok := s.storage.Key(cv.URL); ok {
+		s.storage.Delete(ctx, key)
+	}
+}
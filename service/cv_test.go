@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"session-19/utils"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCVService creates a CV service over mock repositories and local storage.
+// The returned directory holds the private objects, where CVs are stored.
+func newTestCVService(t *testing.T) (*CVService, *repository.MockCVRepository, *repository.MockPortfolioRepository, storage.Storage, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store := storage.NewLocal(t.TempDir(), dir, storage.DefaultLocalURL)
+	mockCVRepo := new(repository.MockCVRepository)
+	mockRepo := new(repository.MockPortfolioRepository)
+	svc := NewCVService(mockCVRepo, NewPortfolioService(mockRepo), store).(*CVService)
+	return svc, mockCVRepo, mockRepo, store, dir
+}
+
+// ==================== CV Service Tests ====================
+
+func TestCVService_UploadCV_ActivatesAndLinksProfile(t *testing.T) {
+	svc, mockCVRepo, mockRepo, _, dir := newTestCVService(t)
+	ctx := context.Background()
+	pdf := &utils.PDFUpload{Filename: "cv.pdf", Data: []byte("%PDF-1.4 ..."), Hash: "aa"}
+
+	mockCVRepo.On("CreateCVVersion", ctx, mock.AnythingOfType("*model.CVVersion")).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.CVVersion).ID = 4
+	}).Return(nil).Once()
+	mockCVRepo.On("ActivateCVVersion", ctx, int64(4)).Return(nil).Once()
+	mockRepo.On("UpdateProfileCVURL", ctx, CVPath).Return(nil).Once()
+
+	cv, err := svc.UploadCV(ctx, pdf, true)
+
+	require.NoError(t, err)
+	assert.True(t, cv.Active)
+	assert.Equal(t, int64(len(pdf.Data)), cv.Size)
+	assert.True(t, strings.HasPrefix(cv.URL, "/public/assets/private/cv/"), cv.URL)
+	entries, _ := os.ReadDir(filepath.Join(dir, "cv"))
+	assert.Len(t, entries, 1)
+	mockCVRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCVService_UploadCV_RemovesFileWhenRecordFails(t *testing.T) {
+	svc, mockCVRepo, _, _, dir := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("CreateCVVersion", ctx, mock.Anything).Return(errors.New("insert failed")).Once()
+
+	_, err := svc.UploadCV(ctx, &utils.PDFUpload{Filename: "cv.pdf", Data: []byte("%PDF-1.4"), Hash: "aa"}, false)
+
+	assert.Error(t, err)
+	entries, _ := os.ReadDir(filepath.Join(dir, "cv"))
+	assert.Empty(t, entries)
+}
+
+func TestCVService_ActivateCV_NotFound(t *testing.T) {
+	svc, mockCVRepo, mockRepo, _, _ := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("ActivateCVVersion", ctx, int64(9)).Return(pgx.ErrNoRows).Once()
+
+	err := svc.ActivateCV(ctx, 9)
+
+	var notFoundErr *NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	mockRepo.AssertNotCalled(t, "UpdateProfileCVURL", mock.Anything, mock.Anything)
+}
+
+func TestCVService_LinkGeneratedCV_DeactivatesVersions(t *testing.T) {
+	svc, mockCVRepo, mockRepo, _, _ := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("DeactivateCVVersions", ctx).Return(nil).Once()
+	mockRepo.On("UpdateProfileCVURL", ctx, "/resume.pdf").Return(nil).Once()
+
+	require.NoError(t, svc.LinkGeneratedCV(ctx, "/resume.pdf"))
+	mockCVRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCVService_LinkGeneratedCV_KeepsProfileWhenDeactivateFails(t *testing.T) {
+	svc, mockCVRepo, mockRepo, _, _ := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("DeactivateCVVersions", ctx).Return(errors.New("update failed")).Once()
+
+	assert.Error(t, svc.LinkGeneratedCV(ctx, "/resume.pdf"))
+	mockRepo.AssertNotCalled(t, "UpdateProfileCVURL", mock.Anything, mock.Anything)
+}
+
+func TestCVService_DeleteCV_RefusesActiveVersion(t *testing.T) {
+	svc, mockCVRepo, _, _, _ := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("GetCVVersionByID", ctx, int64(1)).Return(&model.CVVersion{ID: 1, Active: true}, nil).Once()
+
+	err := svc.DeleteCV(ctx, 1)
+
+	assert.ErrorIs(t, err, ErrActiveCVDelete)
+	mockCVRepo.AssertNotCalled(t, "DeleteCVVersion", mock.Anything, mock.Anything)
+}
+
+func TestCVService_DeleteCV_RemovesFile(t *testing.T) {
+	svc, mockCVRepo, _, store, dir := newTestCVService(t)
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "private/cv/1_old.pdf", strings.NewReader("%PDF-1.4"), "application/pdf"))
+
+	mockCVRepo.On("GetCVVersionByID", ctx, int64(1)).Return(&model.CVVersion{ID: 1, URL: "/public/assets/private/cv/1_old.pdf"}, nil).Once()
+	mockCVRepo.On("DeleteCVVersion", ctx, int64(1)).Return(nil).Once()
+
+	require.NoError(t, svc.DeleteCV(ctx, 1))
+
+	assert.NoFileExists(t, filepath.Join(dir, "cv", "1_old.pdf"))
+	mockCVRepo.AssertExpectations(t)
+}
+
+func TestCVService_DownloadActiveCV_CountsDownload(t *testing.T) {
+	svc, mockCVRepo, _, store, _ := newTestCVService(t)
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "private/cv/2_cv.pdf", strings.NewReader("%PDF-1.4 body"), "application/pdf"))
+
+	mockCVRepo.On("GetActiveCVVersion", ctx).Return(&model.CVVersion{ID: 2, Filename: "cv.pdf", URL: "/public/assets/private/cv/2_cv.pdf", Downloads: 5}, nil).Once()
+	mockCVRepo.On("IncrementCVDownloads", ctx, int64(2)).Return(nil).Once()
+
+	cv, rc, err := svc.DownloadActiveCV(ctx)
+
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "%PDF-1.4 body", string(body))
+	assert.Equal(t, int64(6), cv.Downloads)
+	mockCVRepo.AssertExpectations(t)
+}
+
+func TestCVService_DownloadActiveCV_NoActiveVersion(t *testing.T) {
+	svc, mockCVRepo, _, _, _ := newTestCVService(t)
+	ctx := context.Background()
+
+	mockCVRepo.On("GetActiveCVVersion", ctx).Return(nil, pgx.ErrNoRows).Once()
+
+	_, _, err := svc.DownloadActiveCV(ctx)
+
+	assert.Equal(t, CodeNotFound, ErrorCode(err))
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/repository"
+	"strings"
+)
+
+// EducationServiceInterface defines the interface for education service
+type EducationServiceInterface interface {
+	GetAllEducation(ctx context.Context) ([]model.Education, error)
+	GetEducationByID(ctx context.Context, id int64) (*model.Education, error)
+	CreateEducation(ctx context.Context, req *dto.EducationRequest) (*model.Education, error)
+	UpdateEducation(ctx context.Context, id int64, req *dto.EducationRequest) (*model.Education, error)
+	DeleteEducation(ctx context.Context, id int64) error
+}
+
+// EducationService implements EducationServiceInterface
+type EducationService struct {
+	repo repository.PortfolioRepositoryInterface
+}
+
+// NewEducationService creates a new education service
+func NewEducationService(repo repository.PortfolioRepositoryInterface) EducationServiceInterface {
+	return &EducationService{
+		repo: repo,
+	}
+}
+
+// GetAllEducation retrieves all education
+func (s *EducationService) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	return s.repo.GetAllEducation(ctx)
+}
+
+// GetEducationByID retrieves an education by ID
+func (s *EducationService) GetEducationByID(ctx context.Context, id int64) (*model.Education, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	edu, err := s.repo.GetEducationByID(ctx, id)
+	if err != nil {
+		return nil, repoError("education", id, err)
+	}
+	return edu, nil
+}
+
+// CreateEducation creates a new education
+func (s *EducationService) CreateEducation(ctx context.Context, req *dto.EducationRequest) (*model.Education, error) {
+	if err := ValidateEducationRequest(req); err != nil {
+		return nil, err
+	}
+
+	edu := newEducation(req)
+	if err := s.repo.CreateEducation(ctx, edu); err != nil {
+		return nil, repoError("education", 0, err)
+	}
+
+	return edu, nil
+}
+
+// UpdateEducation updates an education
+func (s *EducationService) UpdateEducation(ctx context.Context, id int64, req *dto.EducationRequest) (*model.Education, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateEducationRequest(req); err != nil {
+		return nil, err
+	}
+
+	edu := newEducation(req)
+	edu.ID = id
+	if err := s.repo.UpdateEducation(ctx, edu); err != nil {
+		return nil, repoError("education", id, err)
+	}
+
+	return edu, nil
+}
+
+// DeleteEducation deletes an education
+func (s *EducationService) DeleteEducation(ctx context.Context, id int64) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	return repoError("education", id, s.repo.DeleteEducation(ctx, id))
+}
+
+// newEducation builds the education of a validated request
+func newEducation(req *dto.EducationRequest) *model.Education {
+	return &model.Education{
+		Institution: strings.TrimSpace(req.Institution),
+		Degree:      strings.TrimSpace(req.Degree),
+		Field:       strings.TrimSpace(req.Field),
+		StartDate:   strings.TrimSpace(req.StartDate),
+		EndDate:     strings.TrimSpace(req.EndDate),
+		GPA:         strings.TrimSpace(req.GPA),
+		Honors:      strings.TrimSpace(req.Honors),
+		Description: strings.TrimSpace(req.Description),
+		Color:       getDefaultColor(req.Color, "lime"),
+	}
+}
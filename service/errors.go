@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Error codes identify the kind of a failed request. They are part of the API
+// contract: clients match on them, so an existing code must never change meaning.
+const (
+	CodeValidation   = "validation_failed"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeUnauthorized = "unauthorized"
+	CodeInternal     = "internal_error"
+
+	// CodeMalformedBody is used by handlers when a request body cannot be decoded
+	CodeMalformedBody = "malformed_body"
+)
+
+// ErrorCodes lists every error code, for API documentation
+var ErrorCodes = []string{CodeValidation, CodeNotFound, CodeConflict, CodeUnauthorized, CodeInternal, CodeMalformedBody}
+
+// Field error codes explain why a single field was rejected
+const (
+	FieldRequired   = "required"
+	FieldInvalid    = "invalid"
+	FieldOutOfRange = "out_of_range"
+)
+
+// PostgreSQL error codes translated into domain errors
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgNotNullViolation    = "23502"
+)
+
+// FieldError describes why one request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	err error
+}
+
+// ValidationError reports a request that failed validation, with one entry per rejected field
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the sentinel error of each field, so errors.Is(err, ErrTitleRequired) works
+func (e *ValidationError) Unwrap() []error {
+	var errs []error
+	for _, f := range e.Fields {
+		if f.err != nil {
+			errs = append(errs, f.err)
+		}
+	}
+	return errs
+}
+
+// NotFoundError reports that the requested resource does not exist
+type NotFoundError struct {
+	Resource string
+	ID       int64
+}
+
+// Error implements error
+func (e *NotFoundError) Error() string {
+	if e.ID == 0 {
+		return e.Resource + " not found"
+	}
+	return fmt.Sprintf("%s %d not found", e.Resource, e.ID)
+}
+
+// ConflictError reports a request that clashes with existing data
+type ConflictError struct {
+	Resource string
+	Message  string
+}
+
+// Error implements error
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// UnauthorizedError reports missing or invalid credentials
+type UnauthorizedError struct {
+	Message string
+}
+
+// Error implements error
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+// ErrInvalidCredentials is returned by Login for an unknown email or a wrong password
+var ErrInvalidCredentials = &UnauthorizedError{Message: "invalid email or password"}
+
+// ErrorCode returns the stable code for err, CodeInternal for anything unexpected
+func ErrorCode(err error) string {
+	var (
+		validationErr   *ValidationError
+		notFoundErr     *NotFoundError
+		conflictErr     *ConflictError
+		unauthorizedErr *UnauthorizedError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		return CodeValidation
+	case errors.As(err, &notFoundErr):
+		return CodeNotFound
+	case errors.As(err, &conflictErr):
+		return CodeConflict
+	case errors.As(err, &unauthorizedErr):
+		return CodeUnauthorized
+	default:
+		return CodeInternal
+	}
+}
+
+// FieldErrors returns the per-field details of a validation error, nil for other errors
+func FieldErrors(err error) []FieldError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Fields
+	}
+	return nil
+}
+
+// fieldError creates a field error wrapping a sentinel validation error
+func fieldError(field, code string, err error) FieldError {
+	return FieldError{Field: field, Code: code, Message: err.Error(), err: err}
+}
+
+// validationResult turns collected field errors into an error, nil when there are none
+func validationResult(fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Message: "request validation failed", Fields: fields}
+}
+
+// repoError translates a repository error into a domain error.
+// Errors it does not recognise are returned unchanged and surface as internal errors.
+func repoError(resource string, id int64, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &NotFoundError{Resource: resource, ID: id}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	field := constraintField(pgErr)
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return &ConflictError{Resource: resource, Message: fmt.Sprintf("%s with this %s already exists", resource, field)}
+	case pgForeignKeyViolation:
+		return validationResult([]FieldError{{Field: field, Code: FieldInvalid, Message: field + " refers to a record that does not exist"}})
+	case pgCheckViolation:
+		return validationResult([]FieldError{{Field: field, Code: FieldInvalid, Message: field + " has a value that is not allowed"}})
+	case pgNotNullViolation:
+		return validationResult([]FieldError{{Field: field, Code: FieldRequired, Message: field + " is required"}})
+	}
+	return err
+}
+
+// constraintField guesses the column behind a constraint violation,
+// e.g. "projects_profile_id_fkey" on table "projects" becomes "profile_id"
+func constraintField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	name := strings.TrimPrefix(pgErr.ConstraintName, pgErr.TableName+"_")
+	for _, suffix := range []string{"_fkey", "_check", "_key"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if name == "" {
+		return "value"
+	}
+	return name
+}
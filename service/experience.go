@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
@@ -37,14 +36,22 @@ func (s *ExperienceService) GetAllExperiences(ctx context.Context) ([]model.Expe
 
 // GetExperienceByID retrieves an experience by ID
 func (s *ExperienceService) GetExperienceByID(ctx context.Context, id int64) (*model.Experience, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid experience ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	exp, err := s.repo.GetExperienceByID(ctx, id)
+	if err != nil {
+		return nil, repoError("experience", id, err)
 	}
-	return s.repo.GetExperienceByID(ctx, id)
+	return exp, nil
 }
 
 // CreateExperience creates a new experience
 func (s *ExperienceService) CreateExperience(ctx context.Context, req *dto.ExperienceRequest) (*model.Experience, error) {
+	if err := ValidateExperienceRequest(req); err != nil {
+		return nil, err
+	}
+
 	exp := &model.Experience{
 		Title:        strings.TrimSpace(req.Title),
 		Organization: strings.TrimSpace(req.Organization),
@@ -55,7 +62,7 @@ func (s *ExperienceService) CreateExperience(ctx context.Context, req *dto.Exper
 	}
 
 	if err := s.repo.CreateExperience(ctx, exp); err != nil {
-		return nil, err
+		return nil, repoError("experience", 0, err)
 	}
 
 	return exp, nil
@@ -63,8 +70,11 @@ func (s *ExperienceService) CreateExperience(ctx context.Context, req *dto.Exper
 
 // UpdateExperience updates an experience
 func (s *ExperienceService) UpdateExperience(ctx context.Context, id int64, req *dto.ExperienceRequest) (*model.Experience, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid experience ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateExperienceRequest(req); err != nil {
+		return nil, err
 	}
 
 	exp := &model.Experience{
@@ -78,7 +88,7 @@ func (s *ExperienceService) UpdateExperience(ctx context.Context, id int64, req
 	}
 
 	if err := s.repo.UpdateExperience(ctx, exp); err != nil {
-		return nil, err
+		return nil, repoError("experience", id, err)
 	}
 
 	return exp, nil
@@ -86,10 +96,10 @@ func (s *ExperienceService) UpdateExperience(ctx context.Context, id int64, req
 
 // DeleteExperience deletes an experience
 func (s *ExperienceService) DeleteExperience(ctx context.Context, id int64) error {
-	if id <= 0 {
-		return errors.New("invalid experience ID")
+	if err := ValidateID(id); err != nil {
+		return err
 	}
-	return s.repo.DeleteExperience(ctx, id)
+	return repoError("experience", id, s.repo.DeleteExperience(ctx, id))
 }
 
 // getColorForType returns a color based on experience type
@@ -0,0 +1,612 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"session-19/dto"
+	"session-19/jsonresume"
+	"session-19/model"
+)
+
+// ErrPortfolioIncomplete is returned when exporting while some portfolio sections fail to load
+var ErrPortfolioIncomplete = errors.New("portfolio data is incomplete; some sections failed to load")
+
+// Import actions
+const (
+	ImportCreate    = "create"
+	ImportUpdate    = "update"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportField is one field an import changes
+type ImportField struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// ImportChange describes what an import does to one record
+type ImportChange struct {
+	Entity string        `json:"entity"`
+	Label  string        `json:"label"`
+	Action string        `json:"action"`
+	Fields []ImportField `json:"fields,omitempty"`
+	// Error is the validation problem that blocks the import, if any
+	Error string `json:"error,omitempty"`
+
+	err   error
+	apply func(ctx context.Context, svc PortfolioServiceInterface) error
+}
+
+// ImportPlan is the set of changes an import makes, for preview and for applying
+type ImportPlan struct {
+	Changes []ImportChange `json:"changes"`
+}
+
+// Count returns the number of changes with the given action
+func (p *ImportPlan) Count(action string) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns a validation error listing every change that cannot be applied, nil when all can
+func (p *ImportPlan) Err() error {
+	var fields []FieldError
+	for _, c := range p.Changes {
+		if c.Error != "" {
+			fields = append(fields, FieldError{Field: c.Entity, Code: FieldInvalid, Message: c.Label + ": " + c.Error, err: c.err})
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Message: "import validation failed", Fields: fields}
+}
+
+// JSONResumeServiceInterface defines the interface for JSON Resume import and export
+type JSONResumeServiceInterface interface {
+	ExportJSONResume(ctx context.Context) (*jsonresume.Resume, error)
+	PreviewJSONResume(ctx context.Context, resume *jsonresume.Resume) (*ImportPlan, error)
+	ImportJSONResume(ctx context.Context, resume *jsonresume.Resume) (*ImportPlan, error)
+}
+
+// JSONResumeService moves portfolio data in and out as JSON Resume documents.
+// Importing is an upsert: records are matched by their natural keys (experience
+// title and organization, skill category and name, project and publication title,
+// education institution and degree, certification name and issuer), fields the
+// document leaves empty keep their current value, and nothing is deleted.
+type JSONResumeService struct {
+	portfolio PortfolioServiceInterface
+}
+
+// NewJSONResumeService creates a new JSON Resume service. Imports go through
+// portfolio so that a cached portfolio is invalidated.
+func NewJSONResumeService(portfolio PortfolioServiceInterface) JSONResumeServiceInterface {
+	return &JSONResumeService{portfolio: portfolio}
+}
+
+// ExportJSONResume builds a JSON Resume document from the current portfolio
+func (s *JSONResumeService) ExportJSONResume(ctx context.Context) (*jsonresume.Resume, error) {
+	data, err := s.portfolio.GetPortfolioData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// An export is used as a backup, so a silently truncated one is worse than none
+	if data.Partial() {
+		return nil, ErrPortfolioIncomplete
+	}
+	return jsonresume.FromPortfolio(data), nil
+}
+
+// PreviewJSONResume reports what importing resume would change without changing anything
+func (s *JSONResumeService) PreviewJSONResume(ctx context.Context, resume *jsonresume.Resume) (*ImportPlan, error) {
+	current, err := loadCurrent(ctx, s.portfolio)
+	if err != nil {
+		return nil, err
+	}
+	return planImport(current, resume.Portfolio()), nil
+}
+
+// ImportJSONResume upserts the records of resume in one transaction. The plan is
+// rebuilt inside the transaction, so it reflects the data it was applied to.
+func (s *JSONResumeService) ImportJSONResume(ctx context.Context, resume *jsonresume.Resume) (*ImportPlan, error) {
+	incoming := resume.Portfolio()
+
+	var plan *ImportPlan
+	err := s.portfolio.UpdateInTransaction(ctx, func(tx PortfolioServiceInterface) error {
+		current, err := loadCurrent(ctx, tx)
+		if err != nil {
+			return err
+		}
+		plan = planImport(current, incoming)
+		if err := plan.Err(); err != nil {
+			return err
+		}
+		for _, c := range plan.Changes {
+			if c.apply == nil {
+				continue
+			}
+			if err := c.apply(ctx, tx); err != nil {
+				return fmt.Errorf("failed to import %s %q: %w", c.Entity, c.Label, err)
+			}
+		}
+		return nil
+	})
+	return plan, err
+}
+
+// currentData is the portfolio an import is planned against
+type currentData struct {
+	profile        *model.Profile
+	experiences    []model.Experience
+	skills         []model.Skill
+	projects       []model.Project
+	publications   []model.Publication
+	education      []model.Education
+	certifications []model.Certification
+}
+
+// loadCurrent reads every section, failing rather than planning against a partial portfolio
+func loadCurrent(ctx context.Context, svc PortfolioServiceInterface) (*currentData, error) {
+	var (
+		current currentData
+		err     error
+	)
+	current.profile, err = svc.GetProfile(ctx)
+	var notFound *NotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, err
+	}
+	if current.experiences, err = svc.GetAllExperiences(ctx); err != nil {
+		return nil, err
+	}
+	if current.skills, err = svc.GetAllSkills(ctx); err != nil {
+		return nil, err
+	}
+	if current.projects, err = svc.GetAllProjects(ctx); err != nil {
+		return nil, err
+	}
+	if current.publications, err = svc.GetAllPublications(ctx); err != nil {
+		return nil, err
+	}
+	if current.education, err = svc.GetAllEducation(ctx); err != nil {
+		return nil, err
+	}
+	if current.certifications, err = svc.GetAllCertifications(ctx); err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+// planImport works out the changes that bring current in line with incoming
+func planImport(current *currentData, incoming *model.PortfolioData) *ImportPlan {
+	plan := &ImportPlan{}
+	plan.planProfile(current.profile, incoming.Profile)
+	plan.planExperiences(current.experiences, incoming.Experiences)
+	plan.planSkills(current.skills, incoming.Skills)
+	plan.planProjects(current.projects, incoming.Projects)
+
+	// Publications carry no authors in JSON Resume; new ones are credited to the profile owner
+	owner := incoming.Profile.Name
+	if owner == "" && current.profile != nil {
+		owner = current.profile.Name
+	}
+	plan.planPublications(current.publications, incoming.Publications, owner)
+	plan.planEducation(current.education, incoming.Education)
+	plan.planCertifications(current.certifications, incoming.Certifications)
+	return plan
+}
+
+func (p *ImportPlan) planProfile(existing *model.Profile, in model.Profile) {
+	req := dto.ProfileRequest{}
+	if existing != nil {
+		req = dto.ProfileRequest{
+			ID: existing.ID, Name: existing.Name, Title: existing.Title, Description: existing.Description,
+			PhotoURL: existing.PhotoURL, Email: existing.Email, LinkedInURL: existing.LinkedInURL,
+			GithubURL: existing.GithubURL, CVURL: existing.CVURL,
+		}
+	}
+
+	var d fieldDiff
+	d.set("name", &req.Name, in.Name)
+	d.set("title", &req.Title, in.Title)
+	d.set("description", &req.Description, in.Description)
+	d.set("photo_url", &req.PhotoURL, in.PhotoURL)
+	d.set("email", &req.Email, in.Email)
+	d.set("linkedin_url", &req.LinkedInURL, in.LinkedInURL)
+	d.set("github_url", &req.GithubURL, in.GithubURL)
+
+	// A document without basics says nothing about the profile
+	if existing == nil && len(d) == 0 {
+		return
+	}
+
+	label := req.Name
+	if existing == nil {
+		p.add("profile", label, false, d, ValidateProfileRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.CreateProfile(ctx, &req)
+			return err
+		})
+		return
+	}
+	id := existing.ID
+	p.add("profile", label, true, d, ValidateProfileRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+		_, err := svc.UpdateProfile(ctx, id, &req)
+		return err
+	})
+}
+
+func (p *ImportPlan) planExperiences(existing []model.Experience, incoming []model.Experience) {
+	index := make(map[string]model.Experience, len(existing))
+	for _, e := range existing {
+		index[importKey(e.Title, e.Organization)] = e
+	}
+
+	seen := map[string]bool{}
+	for _, in := range incoming {
+		key := importKey(in.Title, in.Organization)
+		// A document listing the same entry twice only imports it once
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		label := in.Title
+		if in.Organization != "" {
+			label += " - " + in.Organization
+		}
+
+		match, ok := index[key]
+		if !ok {
+			req := dto.ExperienceRequest{}
+			var d fieldDiff
+			d.set("title", &req.Title, in.Title)
+			d.set("organization", &req.Organization, in.Organization)
+			d.set("period", &req.Period, in.Period)
+			d.set("description", &req.Description, in.Description)
+			d.set("type", &req.Type, in.Type)
+			p.add("experience", label, false, d, ValidateExperienceRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.CreateExperience(ctx, &req)
+				return err
+			})
+			continue
+		}
+
+		req := dto.ExperienceRequest{
+			Title: match.Title, Organization: match.Organization, Period: match.Period,
+			Description: match.Description, Type: match.Type, Color: match.Color,
+		}
+		var d fieldDiff
+		d.set("period", &req.Period, in.Period)
+		d.set("description", &req.Description, in.Description)
+		// JSON Resume cannot tell work from internships, so the type only changes across sections
+		if jsonresume.Section(match.Type) != jsonresume.Section(in.Type) {
+			d.set("type", &req.Type, in.Type)
+		}
+		id := match.ID
+		p.add("experience", label, true, d, ValidateExperienceRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.UpdateExperience(ctx, id, &req)
+			return err
+		})
+	}
+}
+
+func (p *ImportPlan) planSkills(existing []model.Skill, incoming map[string][]model.Skill) {
+	index := make(map[string]model.Skill, len(existing))
+	for _, s := range existing {
+		index[importKey(s.Category, s.Name)] = s
+	}
+
+	categories := make([]string, 0, len(incoming))
+	for category := range incoming {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	seen := map[string]bool{}
+	for _, category := range categories {
+		for _, in := range incoming[category] {
+			key := importKey(in.Category, in.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			label := in.Category + ": " + in.Name
+			match, ok := index[key]
+			if !ok {
+				req := dto.SkillRequest{}
+				var d fieldDiff
+				d.set("category", &req.Category, in.Category)
+				d.set("name", &req.Name, in.Name)
+				d.set("level", &req.Level, in.Level)
+				p.add("skill", label, false, d, ValidateSkillRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+					_, err := svc.CreateSkill(ctx, &req)
+					return err
+				})
+				continue
+			}
+
+			req := dto.SkillRequest{Category: match.Category, Name: match.Name, Level: match.Level, Color: match.Color}
+			var d fieldDiff
+			d.set("level", &req.Level, in.Level)
+			id := match.ID
+			p.add("skill", label, true, d, ValidateSkillRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.UpdateSkill(ctx, id, &req)
+				return err
+			})
+		}
+	}
+}
+
+func (p *ImportPlan) planProjects(existing []model.Project, incoming []model.Project) {
+	index := make(map[string]model.Project, len(existing))
+	for _, pr := range existing {
+		index[importKey(pr.Title)] = pr
+	}
+
+	seen := map[string]bool{}
+	for _, in := range incoming {
+		key := importKey(in.Title)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		req := dto.ProjectRequest{}
+		match, ok := index[key]
+		if ok {
+			req = dto.ProjectRequest{
+				Title: match.Title, Description: match.Description, ImageURL: match.ImageURL, ImageVariants: match.ImageVariants,
+				ProjectURL: match.ProjectURL, GithubURL: match.GithubURL, TechStack: match.TechStack, Color: match.Color, ProfileID: match.ProfileID,
+				Slug: match.Slug, Content: match.Content,
+			}
+		}
+		var d fieldDiff
+		// A matched title differs at most in case and spacing, which is not worth a change
+		if !ok {
+			d.set("title", &req.Title, in.Title)
+		}
+		d.set("description", &req.Description, in.Description)
+		d.set("project_url", &req.ProjectURL, in.ProjectURL)
+		d.set("github_url", &req.GithubURL, in.GithubURL)
+		d.set("tech_stack", &req.TechStack, in.TechStack)
+
+		if !ok {
+			p.add("project", in.Title, false, d, ValidateProjectRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.CreateProject(ctx, &req)
+				return err
+			})
+			continue
+		}
+		id := match.ID
+		p.add("project", in.Title, true, d, ValidateProjectRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.UpdateProject(ctx, id, &req)
+			return err
+		})
+	}
+}
+
+func (p *ImportPlan) planPublications(existing []model.Publication, incoming []model.Publication, owner string) {
+	index := make(map[string]model.Publication, len(existing))
+	for _, pub := range existing {
+		index[importKey(pub.Title)] = pub
+	}
+
+	seen := map[string]bool{}
+	for _, in := range incoming {
+		key := importKey(in.Title)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		req := dto.PublicationRequest{}
+		match, ok := index[key]
+		if ok {
+			req = dto.PublicationRequest{
+				Title: match.Title, Authors: match.Authors, Journal: match.Journal, VenueType: match.VenueType, Volume: match.Volume,
+				Pages: match.Pages, Year: match.Year, DOI: match.DOI, Description: match.Description,
+				ImageURL: match.ImageURL, ImageVariants: match.ImageVariants, PublicationURL: match.PublicationURL, Color: match.Color,
+				Slug: match.Slug, Content: match.Content,
+			}
+		}
+		var d fieldDiff
+		if !ok {
+			d.set("title", &req.Title, in.Title)
+			if owner != "" {
+				d.setAuthors("authors", &req.Authors, model.Authors{{Name: owner, IsMe: true}})
+			}
+		}
+		d.set("journal", &req.Journal, in.Journal)
+		d.setInt("year", &req.Year, in.Year)
+		d.set("publication_url", &req.PublicationURL, in.PublicationURL)
+		d.set("description", &req.Description, in.Description)
+
+		if !ok {
+			p.add("publication", in.Title, false, d, ValidatePublicationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.CreatePublication(ctx, &req)
+				return err
+			})
+			continue
+		}
+		id := match.ID
+		p.add("publication", in.Title, true, d, ValidatePublicationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.UpdatePublication(ctx, id, &req)
+			return err
+		})
+	}
+}
+
+func (p *ImportPlan) planEducation(existing []model.Education, incoming []model.Education) {
+	index := make(map[string]model.Education, len(existing))
+	for _, e := range existing {
+		index[importKey(e.Institution, e.Degree)] = e
+	}
+
+	seen := map[string]bool{}
+	for _, in := range incoming {
+		key := importKey(in.Institution, in.Degree)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		label := in.Institution
+		if in.Degree != "" {
+			label = in.Degree + " - " + in.Institution
+		}
+
+		req := dto.EducationRequest{}
+		match, ok := index[key]
+		if ok {
+			req = dto.EducationRequest{
+				Institution: match.Institution, Degree: match.Degree, Field: match.Field, StartDate: match.StartDate,
+				EndDate: match.EndDate, GPA: match.GPA, Honors: match.Honors, Description: match.Description, Color: match.Color,
+			}
+		}
+		var d fieldDiff
+		if !ok {
+			d.set("institution", &req.Institution, in.Institution)
+			d.set("degree", &req.Degree, in.Degree)
+		}
+		d.set("field", &req.Field, in.Field)
+		d.set("start_date", &req.StartDate, in.StartDate)
+		d.set("end_date", &req.EndDate, in.EndDate)
+		d.set("gpa", &req.GPA, in.GPA)
+
+		if !ok {
+			p.add("education", label, false, d, ValidateEducationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.CreateEducation(ctx, &req)
+				return err
+			})
+			continue
+		}
+		id := match.ID
+		p.add("education", label, true, d, ValidateEducationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.UpdateEducation(ctx, id, &req)
+			return err
+		})
+	}
+}
+
+func (p *ImportPlan) planCertifications(existing []model.Certification, incoming []model.Certification) {
+	index := make(map[string]model.Certification, len(existing))
+	for _, c := range existing {
+		index[importKey(c.Name, c.Issuer)] = c
+	}
+
+	seen := map[string]bool{}
+	for _, in := range incoming {
+		key := importKey(in.Name, in.Issuer)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		label := in.Name
+		if in.Issuer != "" {
+			label += " - " + in.Issuer
+		}
+
+		req := dto.CertificationRequest{}
+		match, ok := index[key]
+		if ok {
+			req = dto.CertificationRequest{
+				Name: match.Name, Issuer: match.Issuer, CredentialID: match.CredentialID, IssueDate: match.IssueDate,
+				ExpiryDate: match.ExpiryDate, VerificationURL: match.VerificationURL, Color: match.Color,
+			}
+		}
+		var d fieldDiff
+		if !ok {
+			d.set("name", &req.Name, in.Name)
+			d.set("issuer", &req.Issuer, in.Issuer)
+		}
+		d.set("issue_date", &req.IssueDate, in.IssueDate)
+		d.set("verification_url", &req.VerificationURL, in.VerificationURL)
+
+		if !ok {
+			p.add("certification", label, false, d, ValidateCertificationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+				_, err := svc.CreateCertification(ctx, &req)
+				return err
+			})
+			continue
+		}
+		id := match.ID
+		p.add("certification", label, true, d, ValidateCertificationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.UpdateCertification(ctx, id, &req)
+			return err
+		})
+	}
+}
+
+// add records a change to a new or, with update set, an existing record.
+// An update without field changes is recorded as unchanged and applies nothing.
+func (p *ImportPlan) add(entity, label string, update bool, d fieldDiff, invalid error, apply func(ctx context.Context, svc PortfolioServiceInterface) error) {
+	c := ImportChange{Entity: entity, Label: label, Action: ImportCreate, Fields: d, apply: apply}
+	if update {
+		c.Action = ImportUpdate
+		if len(d) == 0 {
+			c.Action = ImportUnchanged
+			c.apply = nil
+			invalid = nil
+		}
+	}
+	if invalid != nil {
+		c.Error, c.err = invalid.Error(), invalid
+	}
+	p.Changes = append(p.Changes, c)
+}
+
+// fieldDiff collects the fields an import changes
+type fieldDiff []ImportField
+
+// set overwrites *dst with incoming and records the change. An empty incoming
+// value means the document does not say, so the current value is kept.
+func (d *fieldDiff) set(name string, dst *string, incoming string) {
+	if incoming == "" || incoming == *dst {
+		return
+	}
+	*d = append(*d, ImportField{Name: name, Old: *dst, New: incoming})
+	*dst = incoming
+}
+
+// setAuthors records the author list as the names it is shown with
+func (d *fieldDiff) setAuthors(name string, dst *model.Authors, incoming model.Authors) {
+	if len(incoming) == 0 || slices.Equal(incoming, *dst) {
+		return
+	}
+	*d = append(*d, ImportField{Name: name, Old: dst.String(), New: incoming.String()})
+	*dst = incoming
+}
+
+func (d *fieldDiff) setInt(name string, dst *int, incoming int) {
+	if incoming == 0 || incoming == *dst {
+		return
+	}
+	old := ""
+	if *dst != 0 {
+		old = strconv.Itoa(*dst)
+	}
+	*d = append(*d, ImportField{Name: name, Old: old, New: strconv.Itoa(incoming)})
+	*dst = incoming
+}
+
+// importKey matches records case- and whitespace-insensitively
+func importKey(parts ...string) string {
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Join(strings.Fields(part), " "))
+	}
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"session-19/jsonresume"
+	"session-19/model"
+	"session-19/repository"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestJSONResumeService creates a JSON Resume service over a mock repository holding a small portfolio
+func newTestJSONResumeService() (JSONResumeServiceInterface, *repository.MockPortfolioRepository) {
+	mockRepo := new(repository.MockPortfolioRepository)
+	mockRepo.On("GetProfile", mock.Anything).Return(&model.Profile{ID: 1, Name: "Jane Doe", Email: "jane@example.com", CVURL: "/cv"}, nil).Maybe()
+	mockRepo.On("GetAllExperiences", mock.Anything).Return([]model.Experience{
+		{ID: 3, Title: "Intern", Organization: "ABC", Period: "2021", Type: "internship", Color: "pink"},
+	}, nil).Maybe()
+	mockRepo.On("GetAllSkills", mock.Anything).Return([]model.Skill{
+		{ID: 4, Category: "Languages", Name: "Go", Level: "advanced", Color: "black"},
+	}, nil).Maybe()
+	mockRepo.On("GetAllProjects", mock.Anything).Return([]model.Project{
+		{ID: 5, Title: "Site", Description: "My site", Color: "lime"},
+	}, nil).Maybe()
+	mockRepo.On("GetAllPublications", mock.Anything).Return([]model.Publication{}, nil).Maybe()
+	mockRepo.On("GetAllEducation", mock.Anything).Return([]model.Education{
+		{ID: 6, Institution: "University", Degree: "BSc", StartDate: "2016-09-01", Honors: "Cum laude", Color: "lime"},
+	}, nil).Maybe()
+	mockRepo.On("GetAllCertifications", mock.Anything).Return([]model.Certification{}, nil).Maybe()
+	return NewJSONResumeService(NewPortfolioService(mockRepo)), mockRepo
+}
+
+func sampleResume() *jsonresume.Resume {
+	return &jsonresume.Resume{
+		Basics: jsonresume.Basics{Name: "Jane Doe", Label: "Engineer"},
+		Work:   []jsonresume.Work{{Name: "abc", Position: "intern", StartDate: "2021-06", EndDate: "2021-12"}},
+		Skills: []jsonresume.Skill{{Name: "Languages", Keywords: []string{"Go", "Rust"}}},
+		Projects: []jsonresume.Project{
+			{Name: "site", Description: "My site"},
+		},
+		Publications: []jsonresume.Publication{{Name: "On Caching", Publisher: "JSys", ReleaseDate: "2024-03-01"}},
+	}
+}
+
+func TestJSONResumeService_Preview_DiffsAgainstCurrentData(t *testing.T) {
+	svc, mockRepo := newTestJSONResumeService()
+
+	plan, err := svc.PreviewJSONResume(context.Background(), sampleResume())
+
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 6)
+	assert.Equal(t, ImportChange{Entity: "profile", Label: "Jane Doe", Action: ImportUpdate,
+		Fields: []ImportField{{Name: "title", Old: "", New: "Engineer"}}}, withoutApply(plan.Changes[0]))
+	// Matched case-insensitively; work keeps the internship type as both export to "work"
+	assert.Equal(t, ImportChange{Entity: "experience", Label: "intern - abc", Action: ImportUpdate,
+		Fields: []ImportField{{Name: "period", Old: "2021", New: "Jun 2021 - Dec 2021"}}}, withoutApply(plan.Changes[1]))
+	assert.Equal(t, ImportUnchanged, plan.Changes[2].Action, "Go")
+	assert.Equal(t, ImportCreate, plan.Changes[3].Action, "Rust")
+	assert.Equal(t, ImportUnchanged, plan.Changes[4].Action, "site")
+	assert.Equal(t, ImportChange{Entity: "publication", Label: "On Caching", Action: ImportCreate, Fields: []ImportField{
+		{Name: "title", New: "On Caching"}, {Name: "authors", New: "Jane Doe"}, {Name: "journal", New: "JSys"}, {Name: "year", New: "2024"},
+	}}, withoutApply(plan.Changes[5]))
+	assert.NoError(t, plan.Err())
+	mockRepo.AssertNotCalled(t, "UpdateInTransaction", mock.Anything, mock.Anything)
+}
+
+func TestJSONResumeService_Import_UpsertsInOneTransaction(t *testing.T) {
+	svc, mockRepo := newTestJSONResumeService()
+	ctx := context.Background()
+
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(nil).Once()
+	mockRepo.On("UpdateProfile", ctx, mock.MatchedBy(func(p *model.Profile) bool {
+		return p.ID == 1 && p.Title == "Engineer" && p.CVURL == "/cv"
+	})).Return(nil).Once()
+	mockRepo.On("UpdateExperience", ctx, mock.MatchedBy(func(e *model.Experience) bool {
+		return e.ID == 3 && e.Type == "internship" && e.Color == "pink" && e.Period == "Jun 2021 - Dec 2021"
+	})).Return(nil).Once()
+	mockRepo.On("CreateSkill", ctx, mock.MatchedBy(func(s *model.Skill) bool { return s.Name == "Rust" })).Return(nil).Once()
+	mockRepo.On("PublicationSlugTaken", ctx, "on-caching", int64(0)).Return(false, nil).Once()
+	mockRepo.On("CreatePublication", ctx, mock.MatchedBy(func(p *model.Publication) bool {
+		return p.Authors.String() == "Jane Doe" && p.Authors[0].IsMe && p.Year == 2024
+	})).Return(nil).Once()
+
+	plan, err := svc.ImportJSONResume(ctx, sampleResume())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, plan.Count(ImportCreate))
+	assert.Equal(t, 2, plan.Count(ImportUpdate))
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdateSkill", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateProject", mock.Anything, mock.Anything)
+}
+
+func TestJSONResumeService_Import_UpsertsEducationAndCertifications(t *testing.T) {
+	svc, mockRepo := newTestJSONResumeService()
+	ctx := context.Background()
+	resume := &jsonresume.Resume{
+		Education:    []jsonresume.Education{{Institution: "university", StudyType: "bsc", Area: "Computer Science", EndDate: "2020-06"}},
+		Certificates: []jsonresume.Certificate{{Name: "CKA", Issuer: "CNCF", Date: "2024-03", URL: "https://cncf.io/verify/123"}},
+	}
+
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(nil).Once()
+	// Fields JSON Resume does not carry, such as honours and colour, are kept
+	mockRepo.On("UpdateEducation", ctx, mock.MatchedBy(func(e *model.Education) bool {
+		return e.ID == 6 && e.Field == "Computer Science" && e.EndDate == "2020-06-01" && e.Honors == "Cum laude" && e.Color == "lime"
+	})).Return(nil).Once()
+	mockRepo.On("CreateCertification", ctx, mock.MatchedBy(func(c *model.Certification) bool {
+		return c.Name == "CKA" && c.Issuer == "CNCF" && c.IssueDate == "2024-03-01" && c.VerificationURL == "https://cncf.io/verify/123"
+	})).Return(nil).Once()
+
+	plan, err := svc.ImportJSONResume(ctx, resume)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(ImportUpdate))
+	assert.Equal(t, 1, plan.Count(ImportCreate))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJSONResumeService_Import_InvalidRecordBlocksEverything(t *testing.T) {
+	svc, mockRepo := newTestJSONResumeService()
+	ctx := context.Background()
+	resume := sampleResume()
+	resume.Projects = append(resume.Projects, jsonresume.Project{Name: "No description"})
+
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(nil).Once()
+
+	plan, err := svc.ImportJSONResume(ctx, resume)
+
+	assert.ErrorIs(t, err, ErrDescriptionRequired)
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.Equal(t, "project", FieldErrors(err)[0].Field)
+	assert.Equal(t, "description is required", plan.Changes[5].Error, plan.Changes[5].Label)
+	mockRepo.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateSkill", mock.Anything, mock.Anything)
+}
+
+func TestJSONResumeService_Import_CreatesProfileWhenMissing(t *testing.T) {
+	mockRepo := new(repository.MockPortfolioRepository)
+	ctx := context.Background()
+	mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows).Once()
+	mockRepo.On("GetAllExperiences", ctx).Return([]model.Experience{}, nil).Once()
+	mockRepo.On("GetAllSkills", ctx).Return([]model.Skill{}, nil).Once()
+	mockRepo.On("GetAllProjects", ctx).Return([]model.Project{}, nil).Once()
+	mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{}, nil).Once()
+	mockRepo.On("GetAllEducation", ctx).Return([]model.Education{}, nil).Once()
+	mockRepo.On("GetAllCertifications", ctx).Return([]model.Certification{}, nil).Once()
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(nil).Once()
+	mockRepo.On("CreateProfile", ctx, mock.MatchedBy(func(p *model.Profile) bool { return p.Email == "jane@example.com" })).Return(nil).Once()
+	svc := NewJSONResumeService(NewPortfolioService(mockRepo))
+
+	plan, err := svc.ImportJSONResume(ctx, &jsonresume.Resume{Basics: jsonresume.Basics{Name: "Jane", Email: "jane@example.com"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(ImportCreate))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJSONResumeService_Import_TransactionFailure(t *testing.T) {
+	svc, mockRepo := newTestJSONResumeService()
+	ctx := context.Background()
+
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(errors.New("connection refused")).Once()
+
+	_, err := svc.ImportJSONResume(ctx, sampleResume())
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetProfile", mock.Anything)
+}
+
+func TestJSONResumeService_Export_RefusesPartialData(t *testing.T) {
+	mockRepo := new(repository.MockPortfolioRepository)
+	mockRepo.On("GetPortfolioData", mock.Anything).Return(&model.PortfolioData{
+		Failures: []model.SectionFailure{{Section: model.SectionSkills}},
+	}, nil).Once()
+	svc := NewJSONResumeService(NewPortfolioService(mockRepo))
+
+	resume, err := svc.ExportJSONResume(context.Background())
+
+	assert.ErrorIs(t, err, ErrPortfolioIncomplete)
+	assert.Nil(t, resume)
+}
+
+// withoutApply drops the unexported fields so changes can be compared
+func withoutApply(c ImportChange) ImportChange {
+	c.apply, c.err = nil, nil
+	return c
+}
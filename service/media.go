@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"session-19/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultOrphanGracePeriod keeps fresh uploads whose record may not be saved yet
+const DefaultOrphanGracePeriod = 24 * time.Hour
+
+// MediaReference is a database field pointing at a media URL
+type MediaReference struct {
+	Resource string `json:"resource"`
+	ID       int64  `json:"id"`
+	Field    string `json:"field"`
+	URL      string `json:"url"`
+}
+
+// ReconcileOptions controls a media reconciliation run
+type ReconcileOptions struct {
+	// GracePeriod is how old an orphan must be before it is deleted
+	GracePeriod time.Duration
+	// Delete removes eligible orphans; without it the run only reports
+	Delete bool
+}
+
+// MediaReport is the outcome of a media reconciliation run
+type MediaReport struct {
+	Scanned    int              `json:"scanned"`
+	Referenced int              `json:"referenced"`
+	Orphans    []storage.Object `json:"orphans"`
+	Broken     []MediaReference `json:"broken"`
+	Deleted    []string         `json:"deleted"`
+	Errors     []string         `json:"errors,omitempty"`
+}
+
+// MediaServiceInterface defines the interface for the media library and media maintenance
+type MediaServiceInterface interface {
+	GetAllMedia(ctx context.Context) ([]model.Media, error)
+	GetMediaByID(ctx context.Context, id int64) (*model.Media, error)
+	UploadMedia(ctx context.Context, img *utils.ImageUpload, altText string) (*model.Media, bool, error)
+	UpdateMediaAltText(ctx context.Context, id int64, altText string) (*model.Media, error)
+	DeleteMedia(ctx context.Context, id int64) error
+	Reconcile(ctx context.Context, opts ReconcileOptions) (*MediaReport, error)
+}
+
+// MediaService manages the media library and reconciles stored uploads with the
+// URLs recorded in the database
+type MediaService struct {
+	repo      repository.PortfolioRepositoryInterface
+	mediaRepo repository.MediaRepositoryInterface
+	cvRepo    repository.CVRepositoryInterface
+	storage   storage.Storage
+	now       func() time.Time
+}
+
+// NewMediaService creates a new media service
+func NewMediaService(repo repository.PortfolioRepositoryInterface, mediaRepo repository.MediaRepositoryInterface, cvRepo repository.CVRepositoryInterface, store storage.Storage) MediaServiceInterface {
+	return &MediaService{repo: repo, mediaRepo: mediaRepo, cvRepo: cvRepo, storage: store, now: time.Now}
+}
+
+// GetAllMedia retrieves the media library, newest first
+func (s *MediaService) GetAllMedia(ctx context.Context) ([]model.Media, error) {
+	return s.mediaRepo.GetAllMedia(ctx)
+}
+
+// GetMediaByID retrieves a media item by ID
+func (s *MediaService) GetMediaByID(ctx context.Context, id int64) (*model.Media, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	media, err := s.mediaRepo.GetMediaByID(ctx, id)
+	if err != nil {
+		return nil, repoError("media", id, err)
+	}
+	return media, nil
+}
+
+// UploadMedia adds an image to the library. Content is deduplicated by hash: when the
+// same image was uploaded before, the existing item is returned with duplicate set and
+// nothing new is stored.
+func (s *MediaService) UploadMedia(ctx context.Context, img *utils.ImageUpload, altText string) (*model.Media, bool, error) {
+	if err := ValidateAltText(altText); err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.mediaRepo.GetMediaByHash(ctx, img.Hash)
+	if err == nil {
+		return existing, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	url, err := img.Save(ctx, s.storage, utils.MediaUploadDir)
+	if err != nil {
+		return nil, false, err
+	}
+	// Variants only improve page weight, so the upload stands without them
+	variants, _ := utils.GenerateImageVariants(ctx, s.storage, url)
+
+	media := &model.Media{
+		Filename: img.Filename,
+		URL:      url,
+		MimeType: img.ContentType,
+		Size:     int64(len(img.Data)),
+		Width:    img.Width,
+		Height:   img.Height,
+		Hash:     img.Hash,
+		AltText:  strings.TrimSpace(altText),
+		Variants: variants,
+	}
+	if err := s.mediaRepo.CreateMedia(ctx, media); err != nil {
+		s.deleteFiles(ctx, media)
+		// A concurrent upload of the same image won the race for the hash
+		if existing, findErr := s.mediaRepo.GetMediaByHash(ctx, img.Hash); findErr == nil {
+			return existing, true, nil
+		}
+		return nil, false, repoError("media", 0, err)
+	}
+	return media, false, nil
+}
+
+// UpdateMediaAltText changes the alt text of a media item
+func (s *MediaService) UpdateMediaAltText(ctx context.Context, id int64, altText string) (*model.Media, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateAltText(altText); err != nil {
+		return nil, err
+	}
+
+	media := &model.Media{ID: id, AltText: strings.TrimSpace(altText)}
+	if err := s.mediaRepo.UpdateMedia(ctx, media); err != nil {
+		return nil, repoError("media", id, err)
+	}
+	return s.GetMediaByID(ctx, id)
+}
+
+// DeleteMedia removes a media item and its files. An item still picked as a
+// photo or image is kept, since deleting it would break that page.
+func (s *MediaService) DeleteMedia(ctx context.Context, id int64) error {
+	media, err := s.GetMediaByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	refs, err := s.references(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if ref.Resource != "media" && ref.URL == media.URL {
+			return &ConflictError{Resource: "media", Message: fmt.Sprintf("media is in use by %s %d", ref.Resource, ref.ID)}
+		}
+	}
+
+	if err := s.mediaRepo.DeleteMedia(ctx, id); err != nil {
+		return repoError("media", id, err)
+	}
+	// The row is gone, so files left behind are orphans for the media-gc command
+	s.deleteFiles(ctx, media)
+	return nil
+}
+
+// deleteFiles removes the stored original and variants of a media item, best effort
+func (s *MediaService) deleteFiles(ctx context.Context, media *model.Media) {
+	urls := []string{media.URL}
+	for _, v := range media.Variants {
+		urls = append(urls, v.URL)
+	}
+	for _, url := range urls {
+		if key, ok := s.storage.Key(url); ok {
+			s.storage.Delete(ctx, key)
+		}
+	}
+}
+
+// Reconcile compares the stored uploads with the URLs the database references: profile
+// photo and CV link, project and publication images, testimonial avatars, the SEO image,
+// media library files and CV versions. Uploads nobody references are orphans; references
+// to files that do not exist are broken. With opts.Delete, orphans older than the grace
+// period are removed.
+func (s *MediaService) Reconcile(ctx context.Context, opts ReconcileOptions) (*MediaReport, error) {
+	refs, err := s.references(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []storage.Object
+	for _, prefix := range []string{utils.UploadsPrefix, storage.PrivatePrefix} {
+		listed, err := s.storage.List(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uploads: %w", err)
+		}
+		objects = append(objects, listed...)
+	}
+	stored := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		stored[obj.Key] = true
+	}
+
+	report := &MediaReport{Scanned: len(objects), Orphans: []storage.Object{}, Broken: []MediaReference{}, Deleted: []string{}}
+	referenced := map[string]bool{}
+	for _, ref := range refs {
+		key, ok := s.storage.Key(ref.URL)
+		if !ok {
+			continue // external link
+		}
+		referenced[key] = true
+		if stored[key] {
+			continue
+		}
+		// Not among the listed uploads, e.g. a bundled sample asset or a deleted file
+		if missing, err := s.missing(ctx, key); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to check %s: %v", ref.URL, err))
+		} else if missing {
+			report.Broken = append(report.Broken, ref)
+		}
+	}
+	report.Referenced = len(referenced)
+
+	cutoff := s.now().Add(-opts.GracePeriod)
+	for _, obj := range objects {
+		if referenced[obj.Key] {
+			continue
+		}
+		report.Orphans = append(report.Orphans, obj)
+		if !opts.Delete || obj.ModTime.After(cutoff) {
+			continue
+		}
+		if err := s.storage.Delete(ctx, obj.Key); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete %s: %v", obj.Key, err))
+			continue
+		}
+		report.Deleted = append(report.Deleted, obj.Key)
+	}
+
+	sort.Slice(report.Orphans, func(i, j int) bool { return report.Orphans[i].Key < report.Orphans[j].Key })
+	return report, nil
+}
+
+// references collects every media URL recorded in the database
+func (s *MediaService) references(ctx context.Context) ([]MediaReference, error) {
+	var refs []MediaReference
+	add := func(resource string, id int64, field, url string) {
+		if url != "" {
+			refs = append(refs, MediaReference{Resource: resource, ID: id, Field: field, URL: url})
+		}
+	}
+
+	profile, err := s.repo.GetProfile(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load profile: %w", err)
+	}
+	if profile != nil {
+		add("profile", profile.ID, "photo_url", profile.PhotoURL)
+		add("profile", profile.ID, "cv_url", profile.CVURL)
+	}
+
+	projects, err := s.repo.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+	for _, p := range projects {
+		add("project", p.ID, "image_url", p.ImageURL)
+		for _, v := range p.ImageVariants {
+			add("project", p.ID, "image_variants", v.URL)
+		}
+	}
+
+	publications, err := s.repo.GetAllPublications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load publications: %w", err)
+	}
+	for _, p := range publications {
+		add("publication", p.ID, "image_url", p.ImageURL)
+		for _, v := range p.ImageVariants {
+			add("publication", p.ID, "image_variants", v.URL)
+		}
+	}
+
+	testimonials, err := s.repo.GetAllTestimonials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load testimonials: %w", err)
+	}
+	for _, t := range testimonials {
+		add("testimonial", t.ID, "avatar_url", t.AvatarURL)
+	}
+
+	seo, err := s.repo.GetSEOSettings(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load SEO settings: %w", err)
+	}
+	if seo != nil {
+		add("seo_settings", 1, "image_url", seo.ImageURL)
+	}
+
+	media, err := s.mediaRepo.GetAllMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load media: %w", err)
+	}
+	for _, m := range media {
+		add("media", m.ID, "url", m.URL)
+		for _, v := range m.Variants {
+			add("media", m.ID, "variants", v.URL)
+		}
+	}
+
+	versions, err := s.cvRepo.GetAllCVVersions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CV versions: %w", err)
+	}
+	for _, cv := range versions {
+		add("cv_version", cv.ID, "url", cv.URL)
+	}
+
+	return refs, nil
+}
+
+// missing reports whether nothing is stored under key
+func (s *MediaService) missing(ctx context.Context, key string) (bool, error) {
+	rc, err := s.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	rc.Close()
+	return false, nil
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"session-19/model"
+	"session-19/repository"
+	"session-19/storage"
+	"session-19/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMediaService creates a media service over mock repositories and local storage holding files
+func newTestMediaService(t *testing.T, files map[string]time.Time) (*MediaService, *repository.MockPortfolioRepository, *repository.MockMediaRepository, string) {
+	t.Helper()
+	dir, privateDir := t.TempDir(), t.TempDir()
+	store := storage.NewLocal(dir, privateDir, storage.DefaultLocalURL)
+	for key, modTime := range files {
+		require.NoError(t, store.Put(context.Background(), key, strings.NewReader(key), "image/png"))
+		path := filepath.Join(dir, filepath.FromSlash(key))
+		if rest, ok := strings.CutPrefix(key, storage.PrivatePrefix); ok {
+			path = filepath.Join(privateDir, filepath.FromSlash(rest))
+		}
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	mockRepo := new(repository.MockPortfolioRepository)
+	mockMediaRepo := new(repository.MockMediaRepository)
+	mockCVRepo := new(repository.MockCVRepository)
+	mockCVRepo.On("GetAllCVVersions", mock.Anything).Return([]model.CVVersion{{ID: 1, URL: "/public/assets/private/cv/1_cv.pdf"}}, nil).Maybe()
+	svc := NewMediaService(mockRepo, mockMediaRepo, mockCVRepo, store).(*MediaService)
+	return svc, mockRepo, mockMediaRepo, dir
+}
+
+// testImageUpload returns a small PNG upload as produced by utils.ReadImage
+func testImageUpload(t *testing.T) *utils.ImageUpload {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))))
+	sum := sha256.Sum256(buf.Bytes())
+	return &utils.ImageUpload{
+		Filename:    "cover.png",
+		ContentType: "image/png",
+		Data:        buf.Bytes(),
+		Width:       4,
+		Height:      3,
+		Hash:        hex.EncodeToString(sum[:]),
+	}
+}
+
+// ==================== Media Service Tests ====================
+
+func TestMediaService_Reconcile_ReportsOrphansAndBrokenReferences(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	svc, mockRepo, mockMediaRepo, dir := newTestMediaService(t, map[string]time.Time{
+		"uploads/media/1_logo.png":       old,
+		"uploads/media/2_jane.png":       old,
+		"uploads/media/og.png":           old,
+		"private/cv/1_cv.pdf":            old,
+		"private/cv/0_stale.pdf":         old,
+		"uploads/profile/me.jpg":         old,
+		"uploads/projects/used.png":      old,
+		"uploads/projects/used_320w.png": old,
+		"uploads/projects/old.png":       old,
+		"sample.jpg":                     old,
+	})
+	ctx := context.Background()
+
+	mockRepo.On("GetProfile", ctx).Return(&model.Profile{ID: 1, PhotoURL: "/public/assets/uploads/profile/me.jpg", CVURL: "https://example.com/cv.pdf"}, nil)
+	mockRepo.On("GetAllProjects", ctx).Return([]model.Project{{
+		ID:       3,
+		ImageURL: "/public/assets/uploads/projects/used.png",
+		ImageVariants: model.ImageVariants{
+			{URL: "/public/assets/uploads/projects/used.png", Width: 800, Type: "image/png"},
+			{URL: "/public/assets/uploads/projects/used_320w.png", Width: 320, Type: "image/png"},
+		},
+	}}, nil)
+	mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{
+		{ID: 7, ImageURL: "/public/assets/uploads/publications/gone.png"},
+		{ID: 8, ImageURL: "/public/assets/sample.jpg"},
+	}, nil)
+	mockRepo.On("GetAllTestimonials", ctx).Return([]model.Testimonial{{ID: 2, AvatarURL: "/public/assets/uploads/media/2_jane.png"}}, nil)
+	mockRepo.On("GetSEOSettings", ctx).Return(&model.SEOSettings{ImageURL: "/public/assets/uploads/media/og.png"}, nil)
+	mockMediaRepo.On("GetAllMedia", ctx).Return([]model.Media{{ID: 1, URL: "/public/assets/uploads/media/1_logo.png"}}, nil)
+
+	report, err := svc.Reconcile(ctx, ReconcileOptions{GracePeriod: DefaultOrphanGracePeriod})
+
+	require.NoError(t, err)
+	assert.Equal(t, 9, report.Scanned)
+	require.Len(t, report.Orphans, 2)
+	assert.Equal(t, "private/cv/0_stale.pdf", report.Orphans[0].Key)
+	assert.Equal(t, "uploads/projects/old.png", report.Orphans[1].Key)
+	assert.Equal(t, []MediaReference{{Resource: "publication", ID: 7, Field: "image_url", URL: "/public/assets/uploads/publications/gone.png"}}, report.Broken)
+	assert.Empty(t, report.Deleted)
+	assert.FileExists(t, filepath.Join(dir, "uploads", "projects", "old.png"), "a dry run deletes nothing")
+}
+
+func TestMediaService_Reconcile_DeletesOnlyOrphansPastGracePeriod(t *testing.T) {
+	svc, mockRepo, mockMediaRepo, dir := newTestMediaService(t, map[string]time.Time{
+		"uploads/projects/stale.png": time.Now().Add(-72 * time.Hour),
+		"uploads/projects/fresh.png": time.Now().Add(-time.Hour),
+	})
+	ctx := context.Background()
+
+	mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows)
+	mockRepo.On("GetAllProjects", ctx).Return([]model.Project{}, nil)
+	mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{}, nil)
+	mockRepo.On("GetAllTestimonials", ctx).Return([]model.Testimonial{}, nil)
+	mockRepo.On("GetSEOSettings", ctx).Return(nil, pgx.ErrNoRows)
+	mockMediaRepo.On("GetAllMedia", ctx).Return([]model.Media{}, nil)
+
+	report, err := svc.Reconcile(ctx, ReconcileOptions{GracePeriod: DefaultOrphanGracePeriod, Delete: true})
+
+	require.NoError(t, err)
+	assert.Len(t, report.Orphans, 2)
+	assert.Equal(t, []string{"uploads/projects/stale.png"}, report.Deleted)
+	assert.NoFileExists(t, filepath.Join(dir, "uploads", "projects", "stale.png"))
+	assert.FileExists(t, filepath.Join(dir, "uploads", "projects", "fresh.png"))
+}
+
+func TestMediaService_Reconcile_RepositoryError(t *testing.T) {
+	svc, mockRepo, _, dir := newTestMediaService(t, map[string]time.Time{
+		"uploads/projects/a.png": time.Now().Add(-72 * time.Hour),
+	})
+	ctx := context.Background()
+
+	mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows)
+	mockRepo.On("GetAllProjects", ctx).Return(nil, errors.New("connection refused"))
+
+	report, err := svc.Reconcile(ctx, ReconcileOptions{Delete: true})
+
+	// Without the full list of references nothing can safely be called an orphan
+	assert.Error(t, err)
+	assert.Nil(t, report)
+	assert.FileExists(t, filepath.Join(dir, "uploads", "projects", "a.png"))
+}
+
+func TestMediaService_UploadMedia_StoresNewImage(t *testing.T) {
+	svc, _, mockMediaRepo, dir := newTestMediaService(t, nil)
+	ctx := context.Background()
+	img := testImageUpload(t)
+
+	mockMediaRepo.On("GetMediaByHash", ctx, img.Hash).Return(nil, pgx.ErrNoRows).Once()
+	mockMediaRepo.On("CreateMedia", ctx, mock.AnythingOfType("*model.Media")).Return(nil).Once()
+
+	media, duplicate, err := svc.UploadMedia(ctx, img, "  Cover art ")
+
+	require.NoError(t, err)
+	assert.False(t, duplicate)
+	assert.Equal(t, "cover.png", media.Filename)
+	assert.Equal(t, "image/png", media.MimeType)
+	assert.Equal(t, int64(len(img.Data)), media.Size)
+	assert.Equal(t, 4, media.Width)
+	assert.Equal(t, "Cover art", media.AltText)
+	assert.True(t, strings.HasPrefix(media.URL, "/public/assets/uploads/media/"), media.URL)
+	key, _ := svc.storage.Key(media.URL)
+	assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(key)))
+	mockMediaRepo.AssertExpectations(t)
+}
+
+func TestMediaService_UploadMedia_DeduplicatesByHash(t *testing.T) {
+	svc, _, mockMediaRepo, dir := newTestMediaService(t, nil)
+	ctx := context.Background()
+	img := testImageUpload(t)
+	existing := &model.Media{ID: 4, URL: "/public/assets/uploads/media/1_cover.png", Hash: img.Hash}
+
+	mockMediaRepo.On("GetMediaByHash", ctx, img.Hash).Return(existing, nil).Once()
+
+	media, duplicate, err := svc.UploadMedia(ctx, img, "")
+
+	require.NoError(t, err)
+	assert.True(t, duplicate)
+	assert.Equal(t, existing, media)
+	assert.NoDirExists(t, filepath.Join(dir, "uploads", "media"), "a duplicate stores nothing")
+	mockMediaRepo.AssertNotCalled(t, "CreateMedia", mock.Anything, mock.Anything)
+}
+
+func TestMediaService_UploadMedia_RejectsLongAltText(t *testing.T) {
+	svc, _, mockMediaRepo, _ := newTestMediaService(t, nil)
+
+	_, _, err := svc.UploadMedia(context.Background(), testImageUpload(t), strings.Repeat("a", 301))
+
+	assert.ErrorIs(t, err, ErrAltTextTooLong)
+	mockMediaRepo.AssertNotCalled(t, "GetMediaByHash", mock.Anything, mock.Anything)
+}
+
+func TestMediaService_DeleteMedia_InUse(t *testing.T) {
+	svc, mockRepo, mockMediaRepo, dir := newTestMediaService(t, map[string]time.Time{
+		"uploads/media/1_logo.png": time.Now(),
+	})
+	ctx := context.Background()
+	media := &model.Media{ID: 1, URL: "/public/assets/uploads/media/1_logo.png"}
+
+	mockMediaRepo.On("GetMediaByID", ctx, int64(1)).Return(media, nil)
+	mockMediaRepo.On("GetAllMedia", ctx).Return([]model.Media{*media}, nil)
+	mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows)
+	mockRepo.On("GetAllProjects", ctx).Return([]model.Project{{ID: 5, ImageURL: media.URL}}, nil)
+	mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{}, nil)
+	mockRepo.On("GetAllTestimonials", ctx).Return([]model.Testimonial{}, nil)
+	mockRepo.On("GetSEOSettings", ctx).Return(nil, pgx.ErrNoRows)
+
+	err := svc.DeleteMedia(ctx, 1)
+
+	var conflictErr *ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "media is in use by project 5", conflictErr.Message)
+	assert.FileExists(t, filepath.Join(dir, "uploads", "media", "1_logo.png"))
+	mockMediaRepo.AssertNotCalled(t, "DeleteMedia", mock.Anything, mock.Anything)
+}
+
+func TestMediaService_DeleteMedia_InUseByAvatarOrSEOImage(t *testing.T) {
+	url := "/public/assets/uploads/media/1_logo.png"
+	for _, tc := range []struct {
+		name         string
+		testimonials []model.Testimonial
+		seo          *model.SEOSettings
+		want         string
+	}{
+		{"testimonial avatar", []model.Testimonial{{ID: 4, AvatarURL: url}}, nil, "media is in use by testimonial 4"},
+		{"SEO image", []model.Testimonial{}, &model.SEOSettings{ImageURL: url}, "media is in use by seo_settings 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, mockRepo, mockMediaRepo, _ := newTestMediaService(t, map[string]time.Time{
+				"uploads/media/1_logo.png": time.Now(),
+			})
+			ctx := context.Background()
+			media := &model.Media{ID: 1, URL: url}
+
+			mockMediaRepo.On("GetMediaByID", ctx, int64(1)).Return(media, nil)
+			mockMediaRepo.On("GetAllMedia", ctx).Return([]model.Media{*media}, nil)
+			mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows)
+			mockRepo.On("GetAllProjects", ctx).Return([]model.Project{}, nil)
+			mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{}, nil)
+			mockRepo.On("GetAllTestimonials", ctx).Return(tc.testimonials, nil)
+			if tc.seo != nil {
+				mockRepo.On("GetSEOSettings", ctx).Return(tc.seo, nil)
+			} else {
+				mockRepo.On("GetSEOSettings", ctx).Return(nil, pgx.ErrNoRows)
+			}
+
+			err := svc.DeleteMedia(ctx, 1)
+
+			var conflictErr *ConflictError
+			require.ErrorAs(t, err, &conflictErr)
+			assert.Equal(t, tc.want, conflictErr.Message)
+			mockMediaRepo.AssertNotCalled(t, "DeleteMedia", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMediaService_DeleteMedia_RemovesFiles(t *testing.T) {
+	svc, mockRepo, mockMediaRepo, dir := newTestMediaService(t, map[string]time.Time{
+		"uploads/media/1_logo.png":      time.Now(),
+		"uploads/media/1_logo_320w.png": time.Now(),
+	})
+	ctx := context.Background()
+	media := &model.Media{
+		ID:       1,
+		URL:      "/public/assets/uploads/media/1_logo.png",
+		Variants: model.ImageVariants{{URL: "/public/assets/uploads/media/1_logo_320w.png", Width: 320, Type: "image/png"}},
+	}
+
+	mockMediaRepo.On("GetMediaByID", ctx, int64(1)).Return(media, nil)
+	mockMediaRepo.On("GetAllMedia", ctx).Return([]model.Media{*media}, nil)
+	mockMediaRepo.On("DeleteMedia", ctx, int64(1)).Return(nil).Once()
+	mockRepo.On("GetProfile", ctx).Return(nil, pgx.ErrNoRows)
+	mockRepo.On("GetAllProjects", ctx).Return([]model.Project{}, nil)
+	mockRepo.On("GetAllPublications", ctx).Return([]model.Publication{}, nil)
+	mockRepo.On("GetAllTestimonials", ctx).Return([]model.Testimonial{}, nil)
+	mockRepo.On("GetSEOSettings", ctx).Return(nil, pgx.ErrNoRows)
+
+	require.NoError(t, svc.DeleteMedia(ctx, 1))
+
+	assert.NoFileExists(t, filepath.Join(dir, "uploads", "media", "1_logo.png"))
+	assert.NoFileExists(t, filepath.Join(dir, "uploads", "media", "1_logo_320w.png"))
+	mockMediaRepo.AssertExpectations(t)
+}
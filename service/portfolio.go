@@ -5,6 +5,8 @@ import (
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
+	"sync/atomic"
+	"time"
 )
 
 // PortfolioServiceInterface defines the interface for portfolio service
@@ -13,6 +15,7 @@ type PortfolioServiceInterface interface {
 	GetProfile(ctx context.Context) (*model.Profile, error)
 	CreateProfile(ctx context.Context, req *dto.ProfileRequest) (*model.Profile, error)
 	UpdateProfile(ctx context.Context, id int64, req *dto.ProfileRequest) (*model.Profile, error)
+	UpdateProfileCVURL(ctx context.Context, cvURL string) error
 
 	// Experience operations
 	GetAllExperiences(ctx context.Context) ([]model.Experience, error)
@@ -32,6 +35,7 @@ type PortfolioServiceInterface interface {
 	// Project operations
 	GetAllProjects(ctx context.Context) ([]model.Project, error)
 	GetProjectByID(ctx context.Context, id int64) (*model.Project, error)
+	GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error)
 	CreateProject(ctx context.Context, req *dto.ProjectRequest) (*model.Project, error)
 	UpdateProject(ctx context.Context, id int64, req *dto.ProjectRequest) (*model.Project, error)
 	DeleteProject(ctx context.Context, id int64) error
@@ -39,39 +43,92 @@ type PortfolioServiceInterface interface {
 	// Publication operations
 	GetAllPublications(ctx context.Context) ([]model.Publication, error)
 	GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error)
+	GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error)
 	CreatePublication(ctx context.Context, req *dto.PublicationRequest) (*model.Publication, error)
 	UpdatePublication(ctx context.Context, id int64, req *dto.PublicationRequest) (*model.Publication, error)
 	DeletePublication(ctx context.Context, id int64) error
 
+	// Education operations
+	GetAllEducation(ctx context.Context) ([]model.Education, error)
+	GetEducationByID(ctx context.Context, id int64) (*model.Education, error)
+	CreateEducation(ctx context.Context, req *dto.EducationRequest) (*model.Education, error)
+	UpdateEducation(ctx context.Context, id int64, req *dto.EducationRequest) (*model.Education, error)
+	DeleteEducation(ctx context.Context, id int64) error
+
+	// Certification operations
+	GetAllCertifications(ctx context.Context) ([]model.Certification, error)
+	GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error)
+	CreateCertification(ctx context.Context, req *dto.CertificationRequest) (*model.Certification, error)
+	UpdateCertification(ctx context.Context, id int64, req *dto.CertificationRequest) (*model.Certification, error)
+	DeleteCertification(ctx context.Context, id int64) error
+
+	// Testimonial operations
+	GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error)
+	CreateTestimonial(ctx context.Context, req *dto.TestimonialRequest) (*model.Testimonial, error)
+	UpdateTestimonial(ctx context.Context, id int64, req *dto.TestimonialRequest) (*model.Testimonial, error)
+	SetTestimonialStatus(ctx context.Context, id int64, status string) error
+	DeleteTestimonial(ctx context.Context, id int64) error
+	GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error)
+	IssueTestimonialInvite(ctx context.Context, req *dto.TestimonialInviteRequest) (*model.TestimonialInvite, error)
+	RevokeTestimonialInvite(ctx context.Context, id int64) error
+	GetOpenTestimonialInvite(ctx context.Context, token string) (*model.TestimonialInvite, error)
+	SubmitTestimonial(ctx context.Context, token string, req *dto.TestimonialRequest) (*model.Testimonial, error)
+
+	// SEO settings
+	GetSEOSettings(ctx context.Context) (*model.SEOSettings, error)
+	UpdateSEOSettings(ctx context.Context, req *dto.SEORequest) (*model.SEOSettings, error)
+
 	// Full portfolio data
 	GetPortfolioData(ctx context.Context) (*model.PortfolioData, error)
 
+	// UpdateInTransaction runs fn against a service whose changes commit together
+	// when fn returns nil and are rolled back otherwise
+	UpdateInTransaction(ctx context.Context, fn func(tx PortfolioServiceInterface) error) error
+
 	// Contact
 	SubmitContact(ctx context.Context, req *dto.ContactRequest) error
+
+	// LastModified returns the time of the most recent successful mutation
+	LastModified() time.Time
+	// MarkModified records a mutation made outside the service, such as a backup restore
+	MarkModified()
 }
 
 // PortfolioService implements PortfolioServiceInterface by aggregating all services
 type PortfolioService struct {
-	profileSvc     ProfileServiceInterface
-	experienceSvc  ExperienceServiceInterface
-	skillSvc       SkillServiceInterface
-	projectSvc     ProjectServiceInterface
-	publicationSvc PublicationServiceInterface
-	contactSvc     ContactServiceInterface
-	repo           repository.PortfolioRepositoryInterface
+	profileSvc       ProfileServiceInterface
+	experienceSvc    ExperienceServiceInterface
+	skillSvc         SkillServiceInterface
+	projectSvc       ProjectServiceInterface
+	publicationSvc   PublicationServiceInterface
+	educationSvc     EducationServiceInterface
+	certificationSvc CertificationServiceInterface
+	testimonialSvc   TestimonialServiceInterface
+	seoSvc           SEOServiceInterface
+	contactSvc       ContactServiceInterface
+	repo             repository.PortfolioRepositoryInterface
+	lastModified     atomic.Int64
 }
 
 // NewPortfolioService creates a new portfolio service
 func NewPortfolioService(repo repository.PortfolioRepositoryInterface) PortfolioServiceInterface {
-	return &PortfolioService{
-		profileSvc:     NewProfileService(repo),
-		experienceSvc:  NewExperienceService(repo),
-		skillSvc:       NewSkillService(repo),
-		projectSvc:     NewProjectService(repo),
-		publicationSvc: NewPublicationService(repo),
-		contactSvc:     NewContactService(),
-		repo:           repo,
+	svc := &PortfolioService{
+		profileSvc:       NewProfileService(repo),
+		experienceSvc:    NewExperienceService(repo),
+		skillSvc:         NewSkillService(repo),
+		projectSvc:       NewProjectService(repo),
+		publicationSvc:   NewPublicationService(repo),
+		educationSvc:     NewEducationService(repo),
+		certificationSvc: NewCertificationService(repo),
+		testimonialSvc:   NewTestimonialService(repo),
+		seoSvc:           NewSEOService(repo),
+		contactSvc:       NewContactService(),
+		repo:             repo,
 	}
+	// Nothing is known about changes made before startup, so start from now
+	svc.lastModified.Store(time.Now().UnixNano())
+	return svc
 }
 
 // Profile operations
@@ -80,11 +137,21 @@ func (s *PortfolioService) GetProfile(ctx context.Context) (*model.Profile, erro
 }
 
 func (s *PortfolioService) CreateProfile(ctx context.Context, req *dto.ProfileRequest) (*model.Profile, error) {
-	return s.profileSvc.CreateProfile(ctx, req)
+	profile, err := s.profileSvc.CreateProfile(ctx, req)
+	s.markModified(err)
+	return profile, err
 }
 
 func (s *PortfolioService) UpdateProfile(ctx context.Context, id int64, req *dto.ProfileRequest) (*model.Profile, error) {
-	return s.profileSvc.UpdateProfile(ctx, id, req)
+	profile, err := s.profileSvc.UpdateProfile(ctx, id, req)
+	s.markModified(err)
+	return profile, err
+}
+
+func (s *PortfolioService) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	err := s.profileSvc.UpdateProfileCVURL(ctx, cvURL)
+	s.markModified(err)
+	return err
 }
 
 // Experience operations
@@ -97,15 +164,21 @@ func (s *PortfolioService) GetExperienceByID(ctx context.Context, id int64) (*mo
 }
 
 func (s *PortfolioService) CreateExperience(ctx context.Context, req *dto.ExperienceRequest) (*model.Experience, error) {
-	return s.experienceSvc.CreateExperience(ctx, req)
+	exp, err := s.experienceSvc.CreateExperience(ctx, req)
+	s.markModified(err)
+	return exp, err
 }
 
 func (s *PortfolioService) UpdateExperience(ctx context.Context, id int64, req *dto.ExperienceRequest) (*model.Experience, error) {
-	return s.experienceSvc.UpdateExperience(ctx, id, req)
+	exp, err := s.experienceSvc.UpdateExperience(ctx, id, req)
+	s.markModified(err)
+	return exp, err
 }
 
 func (s *PortfolioService) DeleteExperience(ctx context.Context, id int64) error {
-	return s.experienceSvc.DeleteExperience(ctx, id)
+	err := s.experienceSvc.DeleteExperience(ctx, id)
+	s.markModified(err)
+	return err
 }
 
 // Skill operations
@@ -122,15 +195,21 @@ func (s *PortfolioService) GetSkillByID(ctx context.Context, id int64) (*model.S
 }
 
 func (s *PortfolioService) CreateSkill(ctx context.Context, req *dto.SkillRequest) (*model.Skill, error) {
-	return s.skillSvc.CreateSkill(ctx, req)
+	skill, err := s.skillSvc.CreateSkill(ctx, req)
+	s.markModified(err)
+	return skill, err
 }
 
 func (s *PortfolioService) UpdateSkill(ctx context.Context, id int64, req *dto.SkillRequest) (*model.Skill, error) {
-	return s.skillSvc.UpdateSkill(ctx, id, req)
+	skill, err := s.skillSvc.UpdateSkill(ctx, id, req)
+	s.markModified(err)
+	return skill, err
 }
 
 func (s *PortfolioService) DeleteSkill(ctx context.Context, id int64) error {
-	return s.skillSvc.DeleteSkill(ctx, id)
+	err := s.skillSvc.DeleteSkill(ctx, id)
+	s.markModified(err)
+	return err
 }
 
 // Project operations
@@ -142,16 +221,26 @@ func (s *PortfolioService) GetProjectByID(ctx context.Context, id int64) (*model
 	return s.projectSvc.GetProjectByID(ctx, id)
 }
 
+func (s *PortfolioService) GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error) {
+	return s.projectSvc.GetProjectBySlug(ctx, slug)
+}
+
 func (s *PortfolioService) CreateProject(ctx context.Context, req *dto.ProjectRequest) (*model.Project, error) {
-	return s.projectSvc.CreateProject(ctx, req)
+	project, err := s.projectSvc.CreateProject(ctx, req)
+	s.markModified(err)
+	return project, err
 }
 
 func (s *PortfolioService) UpdateProject(ctx context.Context, id int64, req *dto.ProjectRequest) (*model.Project, error) {
-	return s.projectSvc.UpdateProject(ctx, id, req)
+	project, err := s.projectSvc.UpdateProject(ctx, id, req)
+	s.markModified(err)
+	return project, err
 }
 
 func (s *PortfolioService) DeleteProject(ctx context.Context, id int64) error {
-	return s.projectSvc.DeleteProject(ctx, id)
+	err := s.projectSvc.DeleteProject(ctx, id)
+	s.markModified(err)
+	return err
 }
 
 // Publication operations
@@ -163,16 +252,145 @@ func (s *PortfolioService) GetPublicationByID(ctx context.Context, id int64) (*m
 	return s.publicationSvc.GetPublicationByID(ctx, id)
 }
 
+func (s *PortfolioService) GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error) {
+	return s.publicationSvc.GetPublicationBySlug(ctx, slug)
+}
+
 func (s *PortfolioService) CreatePublication(ctx context.Context, req *dto.PublicationRequest) (*model.Publication, error) {
-	return s.publicationSvc.CreatePublication(ctx, req)
+	pub, err := s.publicationSvc.CreatePublication(ctx, req)
+	s.markModified(err)
+	return pub, err
 }
 
 func (s *PortfolioService) UpdatePublication(ctx context.Context, id int64, req *dto.PublicationRequest) (*model.Publication, error) {
-	return s.publicationSvc.UpdatePublication(ctx, id, req)
+	pub, err := s.publicationSvc.UpdatePublication(ctx, id, req)
+	s.markModified(err)
+	return pub, err
 }
 
 func (s *PortfolioService) DeletePublication(ctx context.Context, id int64) error {
-	return s.publicationSvc.DeletePublication(ctx, id)
+	err := s.publicationSvc.DeletePublication(ctx, id)
+	s.markModified(err)
+	return err
+}
+
+// Education operations
+func (s *PortfolioService) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	return s.educationSvc.GetAllEducation(ctx)
+}
+
+func (s *PortfolioService) GetEducationByID(ctx context.Context, id int64) (*model.Education, error) {
+	return s.educationSvc.GetEducationByID(ctx, id)
+}
+
+func (s *PortfolioService) CreateEducation(ctx context.Context, req *dto.EducationRequest) (*model.Education, error) {
+	edu, err := s.educationSvc.CreateEducation(ctx, req)
+	s.markModified(err)
+	return edu, err
+}
+
+func (s *PortfolioService) UpdateEducation(ctx context.Context, id int64, req *dto.EducationRequest) (*model.Education, error) {
+	edu, err := s.educationSvc.UpdateEducation(ctx, id, req)
+	s.markModified(err)
+	return edu, err
+}
+
+func (s *PortfolioService) DeleteEducation(ctx context.Context, id int64) error {
+	err := s.educationSvc.DeleteEducation(ctx, id)
+	s.markModified(err)
+	return err
+}
+
+// Certification operations
+func (s *PortfolioService) GetAllCertifications(ctx context.Context) ([]model.Certification, error) {
+	return s.certificationSvc.GetAllCertifications(ctx)
+}
+
+func (s *PortfolioService) GetCertificationByID(ctx context.Context, id int64) (*model.Certification, error) {
+	return s.certificationSvc.GetCertificationByID(ctx, id)
+}
+
+func (s *PortfolioService) CreateCertification(ctx context.Context, req *dto.CertificationRequest) (*model.Certification, error) {
+	cert, err := s.certificationSvc.CreateCertification(ctx, req)
+	s.markModified(err)
+	return cert, err
+}
+
+func (s *PortfolioService) UpdateCertification(ctx context.Context, id int64, req *dto.CertificationRequest) (*model.Certification, error) {
+	cert, err := s.certificationSvc.UpdateCertification(ctx, id, req)
+	s.markModified(err)
+	return cert, err
+}
+
+func (s *PortfolioService) DeleteCertification(ctx context.Context, id int64) error {
+	err := s.certificationSvc.DeleteCertification(ctx, id)
+	s.markModified(err)
+	return err
+}
+
+// Testimonial operations
+func (s *PortfolioService) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	return s.testimonialSvc.GetAllTestimonials(ctx)
+}
+
+func (s *PortfolioService) GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error) {
+	return s.testimonialSvc.GetTestimonialByID(ctx, id)
+}
+
+func (s *PortfolioService) CreateTestimonial(ctx context.Context, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	t, err := s.testimonialSvc.CreateTestimonial(ctx, req)
+	s.markModified(err)
+	return t, err
+}
+
+func (s *PortfolioService) UpdateTestimonial(ctx context.Context, id int64, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	t, err := s.testimonialSvc.UpdateTestimonial(ctx, id, req)
+	s.markModified(err)
+	return t, err
+}
+
+func (s *PortfolioService) SetTestimonialStatus(ctx context.Context, id int64, status string) error {
+	err := s.testimonialSvc.SetTestimonialStatus(ctx, id, status)
+	s.markModified(err)
+	return err
+}
+
+func (s *PortfolioService) DeleteTestimonial(ctx context.Context, id int64) error {
+	err := s.testimonialSvc.DeleteTestimonial(ctx, id)
+	s.markModified(err)
+	return err
+}
+
+// Invites and pending submissions are not part of the portfolio, so they leave it unmodified
+func (s *PortfolioService) GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error) {
+	return s.testimonialSvc.GetAllTestimonialInvites(ctx)
+}
+
+func (s *PortfolioService) IssueTestimonialInvite(ctx context.Context, req *dto.TestimonialInviteRequest) (*model.TestimonialInvite, error) {
+	return s.testimonialSvc.IssueTestimonialInvite(ctx, req)
+}
+
+func (s *PortfolioService) RevokeTestimonialInvite(ctx context.Context, id int64) error {
+	return s.testimonialSvc.RevokeTestimonialInvite(ctx, id)
+}
+
+func (s *PortfolioService) GetOpenTestimonialInvite(ctx context.Context, token string) (*model.TestimonialInvite, error) {
+	return s.testimonialSvc.GetOpenTestimonialInvite(ctx, token)
+}
+
+func (s *PortfolioService) SubmitTestimonial(ctx context.Context, token string, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	return s.testimonialSvc.SubmitTestimonial(ctx, token, req)
+}
+
+// SEO settings
+func (s *PortfolioService) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	return s.seoSvc.GetSEOSettings(ctx)
+}
+
+func (s *PortfolioService) UpdateSEOSettings(ctx context.Context, req *dto.SEORequest) (*model.SEOSettings, error) {
+	settings, err := s.seoSvc.UpdateSEOSettings(ctx, req)
+	s.markModified(err)
+	return settings, err
 }
 
 // Full portfolio data
@@ -180,7 +398,33 @@ func (s *PortfolioService) GetPortfolioData(ctx context.Context) (*model.Portfol
 	return s.repo.GetPortfolioData(ctx)
 }
 
+// UpdateInTransaction runs fn against a service bound to a single transaction
+func (s *PortfolioService) UpdateInTransaction(ctx context.Context, fn func(tx PortfolioServiceInterface) error) error {
+	err := s.repo.UpdateInTransaction(ctx, func(tx repository.PortfolioRepositoryInterface) error {
+		return fn(NewPortfolioService(tx))
+	})
+	s.markModified(err)
+	return err
+}
+
 // Contact
 func (s *PortfolioService) SubmitContact(ctx context.Context, req *dto.ContactRequest) error {
 	return s.contactSvc.SubmitContact(ctx, req)
 }
+
+// LastModified returns the time of the most recent successful mutation
+func (s *PortfolioService) LastModified() time.Time {
+	return time.Unix(0, s.lastModified.Load())
+}
+
+// MarkModified records a mutation made outside the service
+func (s *PortfolioService) MarkModified() {
+	s.markModified(nil)
+}
+
+// markModified records a mutation time when the mutation succeeded
+func (s *PortfolioService) markModified(err error) {
+	if err == nil {
+		s.lastModified.Store(time.Now().UnixNano())
+	}
+}
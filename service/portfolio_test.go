@@ -7,7 +7,10 @@ import (
 	"session-19/model"
 	"session-19/repository"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -443,12 +446,14 @@ func TestPortfolioService_CreateProject_Success(t *testing.T) {
 		Title:       "New Project",
 		Description: "Project description",
 	}
+	mockRepo.On("ProjectSlugTaken", ctx, "new-project", int64(0)).Return(false, nil).Once()
 	mockRepo.On("CreateProject", ctx, mock.AnythingOfType("*model.Project")).Return(nil).Once()
 
 	result, err := svc.CreateProject(ctx, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.Equal(t, "new-project", result.Slug)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -488,8 +493,8 @@ func TestPortfolioService_GetAllPublications_Success(t *testing.T) {
 	ctx := context.Background()
 
 	expected := []model.Publication{
-		{ID: 1, Title: "Publication 1", Authors: "Author 1", Journal: "Journal 1"},
-		{ID: 2, Title: "Publication 2", Authors: "Author 2", Journal: "Journal 2"},
+		{ID: 1, Title: "Publication 1", Authors: model.Authors{{Name: "Author 1"}}, Journal: "Journal 1"},
+		{ID: 2, Title: "Publication 2", Authors: model.Authors{{Name: "Author 2"}}, Journal: "Journal 2"},
 	}
 	mockRepo.On("GetAllPublications", ctx).Return(expected, nil).Once()
 
@@ -517,7 +522,7 @@ func TestPortfolioService_GetPublicationByID_Success(t *testing.T) {
 	svc, mockRepo := newTestService()
 	ctx := context.Background()
 
-	expected := &model.Publication{ID: 1, Title: "Publication 1", Authors: "Author 1", Journal: "Journal 1"}
+	expected := &model.Publication{ID: 1, Title: "Publication 1", Authors: model.Authors{{Name: "Author 1"}}, Journal: "Journal 1"}
 	mockRepo.On("GetPublicationByID", ctx, int64(1)).Return(expected, nil).Once()
 
 	result, err := svc.GetPublicationByID(ctx, 1)
@@ -533,16 +538,21 @@ func TestPortfolioService_CreatePublication_Success(t *testing.T) {
 
 	req := &dto.PublicationRequest{
 		Title:   "New Publication",
-		Authors: "Author Name",
+		Authors: model.Authors{{Name: " Author  Name ", ORCID: "https://orcid.org/0000-0002-1694-233x", IsMe: true}},
 		Journal: "Journal Name",
 		Year:    2024,
 	}
+	mockRepo.On("PublicationSlugTaken", ctx, "new-publication", int64(0)).Return(true, nil).Once()
+	mockRepo.On("PublicationSlugTaken", ctx, "new-publication-2", int64(0)).Return(false, nil).Once()
 	mockRepo.On("CreatePublication", ctx, mock.AnythingOfType("*model.Publication")).Return(nil).Once()
 
 	result, err := svc.CreatePublication(ctx, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.Equal(t, "new-publication-2", result.Slug)
+	assert.Equal(t, model.Authors{{Name: "Author Name", ORCID: "0000-0002-1694-233X", IsMe: true}}, result.Authors)
+	assert.Equal(t, model.VenueJournal, result.VenueType)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -552,7 +562,7 @@ func TestPortfolioService_UpdatePublication_Success(t *testing.T) {
 
 	req := &dto.PublicationRequest{
 		Title:   "Updated Publication",
-		Authors: "Updated Author",
+		Authors: model.Authors{{Name: "Updated Author"}},
 		Journal: "Updated Journal",
 		Year:    2024,
 	}
@@ -577,6 +587,293 @@ func TestPortfolioService_DeletePublication_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// ==================== Education Service Tests ====================
+
+func TestPortfolioService_CreateEducation_Success(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	req := &dto.EducationRequest{Institution: " State University ", Degree: "B.Sc.", StartDate: "2018-09-01", EndDate: "2022-06-30", GPA: "3.85/4.00"}
+	mockRepo.On("CreateEducation", ctx, mock.AnythingOfType("*model.Education")).Return(nil).Once()
+
+	result, err := svc.CreateEducation(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "State University", result.Institution)
+	assert.Equal(t, "lime", result.Color)
+	assert.Equal(t, "Sep 2018 - Jun 2022", result.Period())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_CreateEducation_ValidationError(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreateEducation(ctx, &dto.EducationRequest{StartDate: "2022-09-01", EndDate: "2018-06-30"})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.ErrorIs(t, err, ErrInstitutionRequired)
+	assert.ErrorIs(t, err, ErrEndBeforeStart)
+	assert.Equal(t, "end_date", FieldErrors(err)[1].Field)
+
+	_, err = svc.CreateEducation(ctx, &dto.EducationRequest{Institution: "State University", StartDate: "09/2018"})
+	assert.ErrorIs(t, err, ErrDateInvalid)
+	assert.Equal(t, "start_date", FieldErrors(err)[0].Field)
+	mockRepo.AssertNotCalled(t, "CreateEducation", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_UpdateEducation_NotFound(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("UpdateEducation", ctx, mock.AnythingOfType("*model.Education")).Return(pgx.ErrNoRows).Once()
+
+	_, err := svc.UpdateEducation(ctx, 7, &dto.EducationRequest{Institution: "State University"})
+
+	assert.Equal(t, CodeNotFound, ErrorCode(err))
+	assert.EqualError(t, err, "education 7 not found")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_DeleteEducation_Success(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("DeleteEducation", ctx, int64(1)).Return(nil).Once()
+
+	err := svc.DeleteEducation(ctx, 1)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// ==================== Certification Service Tests ====================
+
+func TestPortfolioService_CreateCertification_Success(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	req := &dto.CertificationRequest{Name: "CKA", Issuer: "CNCF", IssueDate: "2024-05-01", VerificationURL: " https://verify.example/abc "}
+	mockRepo.On("CreateCertification", ctx, mock.AnythingOfType("*model.Certification")).Return(nil).Once()
+
+	result, err := svc.CreateCertification(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://verify.example/abc", result.VerificationURL)
+	assert.Equal(t, "orange", result.Color)
+	assert.False(t, result.Expired())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_CreateCertification_ValidationError(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreateCertification(ctx, &dto.CertificationRequest{
+		Name: "CKA", IssueDate: "2024-05-01", ExpiryDate: "2023-05-01", VerificationURL: "verify.example/abc",
+	})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	var fields []string
+	for _, f := range FieldErrors(err) {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"issuer", "expiry_date", "verification_url"}, fields)
+	mockRepo.AssertNotCalled(t, "CreateCertification", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_UpdateCertification_CheckViolation(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	pgErr := &pgconn.PgError{Code: "23514", TableName: "certifications", ConstraintName: "certifications_expiry_date_check"}
+	mockRepo.On("UpdateCertification", ctx, mock.AnythingOfType("*model.Certification")).Return(pgErr).Once()
+
+	_, err := svc.UpdateCertification(ctx, 1, &dto.CertificationRequest{Name: "CKA", Issuer: "CNCF"})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.Equal(t, "expiry_date", FieldErrors(err)[0].Field)
+	mockRepo.AssertExpectations(t)
+}
+
+// ==================== SEO Settings Tests ====================
+
+func TestPortfolioService_GetSEOSettings_NotSaved(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("GetSEOSettings", ctx).Return(nil, pgx.ErrNoRows).Once()
+
+	result, err := svc.GetSEOSettings(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.SEOSettings{}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_UpdateSEOSettings_Normalizes(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("UpdateSEOSettings", ctx, &model.SEOSettings{
+		SiteURL:       "https://example.com",
+		Title:         "John Doe",
+		ImageURL:      "/public/og.png",
+		TwitterHandle: "@john_doe",
+		Robots:        "Disallow: /drafts\n# keep the archive out\nDisallow: /old",
+	}).Return(nil).Once()
+
+	result, err := svc.UpdateSEOSettings(ctx, &dto.SEORequest{
+		SiteURL:       " https://example.com/ ",
+		Title:         "John Doe",
+		ImageURL:      "/public/og.png",
+		TwitterHandle: "john_doe",
+		Robots:        " Disallow: /drafts\r\n\r\n# keep the archive out\r\nDisallow: /old\r\n",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", result.SiteURL)
+	assert.Equal(t, "@john_doe", result.TwitterHandle)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_UpdateSEOSettings_ValidationError(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.UpdateSEOSettings(ctx, &dto.SEORequest{
+		SiteURL:       "example.com",
+		ImageURL:      "javascript:alert(1)",
+		TwitterHandle: "not a handle",
+		Robots:        "Disallow: /drafts\n<html>",
+	})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.ErrorIs(t, err, ErrSiteURLInvalid)
+	assert.ErrorIs(t, err, ErrImageURLInvalid)
+	assert.ErrorIs(t, err, ErrTwitterInvalid)
+	assert.ErrorIs(t, err, ErrRobotsInvalid)
+	mockRepo.AssertNotCalled(t, "UpdateSEOSettings", mock.Anything, mock.Anything)
+}
+
+// ==================== Testimonial Service Tests ====================
+
+func TestPortfolioService_CreateTestimonial_ApprovedByDefault(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	req := &dto.TestimonialRequest{Author: " Jane Smith ", Quote: "Great engineer.", Relationship: "Managed Alvin directly"}
+	mockRepo.On("CreateTestimonial", ctx, mock.AnythingOfType("*model.Testimonial")).Return(nil).Once()
+
+	result, err := svc.CreateTestimonial(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Smith", result.Author)
+	assert.Equal(t, model.TestimonialApproved, result.Status)
+	assert.Equal(t, "yellow", result.Color)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_CreateTestimonial_ValidationError(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreateTestimonial(ctx, &dto.TestimonialRequest{Author: "Jane Smith", AvatarURL: "avatar.png", Status: "hidden"})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.ErrorIs(t, err, ErrQuoteRequired)
+	assert.ErrorIs(t, err, ErrAvatarURLInvalid)
+	assert.ErrorIs(t, err, ErrStatusInvalid)
+	mockRepo.AssertNotCalled(t, "CreateTestimonial", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_UpdateTestimonial_KeepsStatus(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("GetTestimonialByID", ctx, int64(4)).Return(&model.Testimonial{ID: 4, Status: model.TestimonialPending}, nil).Once()
+	mockRepo.On("UpdateTestimonial", ctx, mock.MatchedBy(func(t *model.Testimonial) bool {
+		return t.ID == 4 && t.Status == model.TestimonialPending
+	})).Return(nil).Once()
+
+	_, err := svc.UpdateTestimonial(ctx, 4, &dto.TestimonialRequest{Author: "Jane Smith", Quote: "Great engineer, fixed typo."})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_SetTestimonialStatus_Invalid(t *testing.T) {
+	svc, mockRepo := newTestService()
+
+	err := svc.SetTestimonialStatus(context.Background(), 1, "published")
+
+	assert.ErrorIs(t, err, ErrStatusInvalid)
+	mockRepo.AssertNotCalled(t, "UpdateTestimonialStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_IssueTestimonialInvite_Success(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("CreateTestimonialInvite", ctx, mock.AnythingOfType("*model.TestimonialInvite")).Return(nil).Once()
+
+	invite, err := svc.IssueTestimonialInvite(ctx, &dto.TestimonialInviteRequest{Note: " Jane, former manager "})
+
+	assert.NoError(t, err)
+	assert.Len(t, invite.Token, 32)
+	assert.Equal(t, "Jane, former manager", invite.Note)
+	assert.WithinDuration(t, time.Now().Add(TestimonialInviteTTL), invite.ExpiresAt, time.Minute)
+	assert.True(t, invite.Open())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_GetOpenTestimonialInvite_Closed(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	used := time.Now().Add(-time.Hour)
+	mockRepo.On("GetTestimonialInviteByToken", ctx, "used").
+		Return(&model.TestimonialInvite{Token: "used", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used}, nil).Once()
+	mockRepo.On("GetTestimonialInviteByToken", ctx, "expired").
+		Return(&model.TestimonialInvite{Token: "expired", ExpiresAt: time.Now().Add(-time.Hour)}, nil).Once()
+	mockRepo.On("GetTestimonialInviteByToken", ctx, "unknown").Return(nil, pgx.ErrNoRows).Once()
+
+	for _, token := range []string{"used", "expired", "unknown"} {
+		_, err := svc.GetOpenTestimonialInvite(ctx, token)
+		assert.Same(t, ErrInviteClosed, err, token)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_SubmitTestimonial_IgnoresStatusAndColor(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("SubmitTestimonial", ctx, "tok", mock.MatchedBy(func(t *model.Testimonial) bool {
+		return t.Status == "" && t.Color == "yellow"
+	})).Return(nil).Once()
+
+	_, err := svc.SubmitTestimonial(ctx, "tok", &dto.TestimonialRequest{
+		Author: "Jane Smith", Quote: "Great engineer.", Status: model.TestimonialApproved, Color: "pink",
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_SubmitTestimonial_InviteClosed(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("SubmitTestimonial", ctx, "used", mock.AnythingOfType("*model.Testimonial")).Return(pgx.ErrNoRows).Once()
+
+	_, err := svc.SubmitTestimonial(ctx, "used", &dto.TestimonialRequest{Author: "Jane Smith", Quote: "Great engineer."})
+
+	assert.Same(t, ErrInviteClosed, err)
+	assert.Equal(t, CodeNotFound, ErrorCode(err))
+	mockRepo.AssertExpectations(t)
+}
+
 // ==================== Portfolio Data Tests ====================
 
 func TestPortfolioService_GetPortfolioData_Success(t *testing.T) {
@@ -624,3 +921,182 @@ func TestPortfolioService_SubmitContact_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+// ==================== Cache Validation Tests ====================
+
+func TestPortfolioService_LastModified_AdvancesOnMutation(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	before := svc.LastModified()
+	time.Sleep(time.Millisecond)
+
+	mockRepo.On("DeleteSkill", ctx, int64(1)).Return(nil).Once()
+
+	err := svc.DeleteSkill(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, svc.LastModified().After(before))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_LastModified_UnchangedOnFailedMutation(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	before := svc.LastModified()
+	time.Sleep(time.Millisecond)
+
+	mockRepo.On("DeleteSkill", ctx, int64(1)).Return(errors.New("delete failed")).Once()
+
+	err := svc.DeleteSkill(ctx, 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, before, svc.LastModified())
+	mockRepo.AssertExpectations(t)
+}
+
+// ==================== Domain Error Tests ====================
+
+func TestPortfolioService_CreateProject_ValidationError(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreateProject(ctx, &dto.ProjectRequest{Title: " "})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.ErrorIs(t, err, ErrTitleRequired)
+	assert.ErrorIs(t, err, ErrDescriptionRequired)
+	assert.Equal(t, []string{"title", "description"}, []string{FieldErrors(err)[0].Field, FieldErrors(err)[1].Field})
+	mockRepo.AssertNotCalled(t, "CreateProject", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_CreatePublication_AuthorErrors(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreatePublication(ctx, &dto.PublicationRequest{
+		Title: "Paper", Journal: "JSys", Year: 2024, VenueType: "book", DOI: "doi.org/xyz",
+		Authors: model.Authors{
+			{Name: "Jane Doe", ORCID: "0000-0002-1825-0098", IsMe: true},
+			{Name: " ", URL: "example.com/rich"},
+			{Name: "Sam Poe", ORCID: "https://orcid.org/0000-0002-1825-0097", IsMe: true},
+		},
+	})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	var fields []string
+	for _, f := range FieldErrors(err) {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"authors[0].orcid", "authors[1].name", "authors[1].url", "authors[2].is_me", "venue_type", "doi"}, fields)
+	assert.Equal(t, "author 1: "+ErrORCIDInvalid.Error(), FieldErrors(err)[0].Message)
+	assert.ErrorIs(t, err, ErrAuthorMeTwice)
+	mockRepo.AssertNotCalled(t, "CreatePublication", mock.Anything, mock.Anything)
+
+	_, err = svc.CreatePublication(ctx, &dto.PublicationRequest{Title: "Paper", Journal: "JSys", Year: 2024})
+	assert.ErrorIs(t, err, ErrAuthorsRequired)
+}
+
+func TestPortfolioService_GetProjectByID_NotFound(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("GetProjectByID", ctx, int64(999)).Return(nil, pgx.ErrNoRows).Once()
+
+	result, err := svc.GetProjectByID(ctx, 999)
+
+	assert.Nil(t, result)
+	assert.Equal(t, CodeNotFound, ErrorCode(err))
+	assert.EqualError(t, err, "project 999 not found")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_DeleteSkill_InvalidID(t *testing.T) {
+	svc, mockRepo := newTestService()
+
+	err := svc.DeleteSkill(context.Background(), 0)
+
+	assert.ErrorIs(t, err, ErrInvalidID)
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	mockRepo.AssertNotCalled(t, "DeleteSkill", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_UpdateProject_ForeignKeyViolation(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	pgErr := &pgconn.PgError{Code: "23503", TableName: "projects", ConstraintName: "projects_profile_id_fkey"}
+	mockRepo.On("UpdateProject", ctx, mock.AnythingOfType("*model.Project")).Return(pgErr).Once()
+
+	_, err := svc.UpdateProject(ctx, 1, &dto.ProjectRequest{Title: "Project", Description: "Description", ProfileID: 42})
+
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+	assert.Equal(t, "profile_id", FieldErrors(err)[0].Field)
+	assert.NotContains(t, err.Error(), "23503")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_UpdateProject_SlugTaken(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("ProjectSlugTaken", ctx, "task-api", int64(1)).Return(true, nil).Once()
+
+	_, err := svc.UpdateProject(ctx, 1, &dto.ProjectRequest{Title: "Project", Description: "Description", Slug: "Task API"})
+
+	assert.Equal(t, CodeConflict, ErrorCode(err))
+	assert.EqualError(t, err, "project with this slug already exists")
+	mockRepo.AssertNotCalled(t, "UpdateProject", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_UpdateProject_NormalizesSlug(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("ProjectSlugTaken", ctx, "task-api", int64(1)).Return(false, nil).Once()
+	mockRepo.On("UpdateProject", ctx, mock.MatchedBy(func(p *model.Project) bool { return p.Slug == "task-api" })).Return(nil).Once()
+
+	_, err := svc.UpdateProject(ctx, 1, &dto.ProjectRequest{Title: "Project", Description: "Description", Slug: "  Task API "})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_CreateProject_InvalidSlug(t *testing.T) {
+	svc, mockRepo := newTestService()
+
+	_, err := svc.CreateProject(context.Background(), &dto.ProjectRequest{Title: "Project", Description: "Description", Slug: "!!!"})
+
+	assert.ErrorIs(t, err, ErrSlugInvalid)
+	assert.Equal(t, "slug", FieldErrors(err)[0].Field)
+	mockRepo.AssertNotCalled(t, "CreateProject", mock.Anything, mock.Anything)
+}
+
+func TestPortfolioService_GetProjectBySlug_NotFound(t *testing.T) {
+	svc, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("GetProjectBySlug", ctx, "missing").Return(nil, pgx.ErrNoRows).Once()
+
+	_, err := svc.GetProjectBySlug(ctx, "missing")
+
+	assert.Equal(t, CodeNotFound, ErrorCode(err))
+	assert.EqualError(t, err, "project not found")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPortfolioService_SubmitContact_ValidationError(t *testing.T) {
+	svc, _ := newTestService()
+
+	err := svc.SubmitContact(context.Background(), &dto.ContactRequest{Name: "Visitor", Email: "not-an-email"})
+
+	assert.ErrorIs(t, err, ErrEmailInvalid)
+	assert.ErrorIs(t, err, ErrMessageRequired)
+}
+
+func TestErrorCode_UnknownErrorIsInternal(t *testing.T) {
+	assert.Equal(t, CodeInternal, ErrorCode(errors.New("connection refused")))
+	assert.Equal(t, CodeConflict, ErrorCode(&ConflictError{Resource: "user", Message: "email already registered"}))
+	assert.Equal(t, CodeUnauthorized, ErrorCode(ErrInvalidCredentials))
+}
@@ -13,6 +13,7 @@ type ProfileServiceInterface interface {
 	GetProfile(ctx context.Context) (*model.Profile, error)
 	CreateProfile(ctx context.Context, req *dto.ProfileRequest) (*model.Profile, error)
 	UpdateProfile(ctx context.Context, id int64, req *dto.ProfileRequest) (*model.Profile, error)
+	UpdateProfileCVURL(ctx context.Context, cvURL string) error
 }
 
 // ProfileService implements ProfileServiceInterface
@@ -29,11 +30,19 @@ func NewProfileService(repo repository.PortfolioRepositoryInterface) ProfileServ
 
 // GetProfile retrieves the main profile
 func (s *ProfileService) GetProfile(ctx context.Context) (*model.Profile, error) {
-	return s.repo.GetProfile(ctx)
+	profile, err := s.repo.GetProfile(ctx)
+	if err != nil {
+		return nil, repoError("profile", 0, err)
+	}
+	return profile, nil
 }
 
 // CreateProfile creates a new profile
 func (s *ProfileService) CreateProfile(ctx context.Context, req *dto.ProfileRequest) (*model.Profile, error) {
+	if err := ValidateProfileRequest(req); err != nil {
+		return nil, err
+	}
+
 	profile := &model.Profile{
 		Name:        strings.TrimSpace(req.Name),
 		Title:       strings.TrimSpace(req.Title),
@@ -46,7 +55,7 @@ func (s *ProfileService) CreateProfile(ctx context.Context, req *dto.ProfileRequ
 	}
 
 	if err := s.repo.CreateProfile(ctx, profile); err != nil {
-		return nil, err
+		return nil, repoError("profile", 0, err)
 	}
 
 	return profile, nil
@@ -54,6 +63,13 @@ func (s *ProfileService) CreateProfile(ctx context.Context, req *dto.ProfileRequ
 
 // UpdateProfile updates the profile
 func (s *ProfileService) UpdateProfile(ctx context.Context, id int64, req *dto.ProfileRequest) (*model.Profile, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateProfileRequest(req); err != nil {
+		return nil, err
+	}
+
 	profile := &model.Profile{
 		ID:          id,
 		Name:        strings.TrimSpace(req.Name),
@@ -67,8 +83,16 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, id int64, req *dto.P
 	}
 
 	if err := s.repo.UpdateProfile(ctx, profile); err != nil {
-		return nil, err
+		return nil, repoError("profile", id, err)
 	}
 
 	return profile, nil
 }
+
+// UpdateProfileCVURL points the profile's CV link at cvURL, leaving the other fields alone
+func (s *ProfileService) UpdateProfileCVURL(ctx context.Context, cvURL string) error {
+	if err := s.repo.UpdateProfileCVURL(ctx, strings.TrimSpace(cvURL)); err != nil {
+		return repoError("profile", 0, err)
+	}
+	return nil
+}
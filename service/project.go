@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
@@ -13,6 +12,7 @@ import (
 type ProjectServiceInterface interface {
 	GetAllProjects(ctx context.Context) ([]model.Project, error)
 	GetProjectByID(ctx context.Context, id int64) (*model.Project, error)
+	GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error)
 	CreateProject(ctx context.Context, req *dto.ProjectRequest) (*model.Project, error)
 	UpdateProject(ctx context.Context, id int64, req *dto.ProjectRequest) (*model.Project, error)
 	DeleteProject(ctx context.Context, id int64) error
@@ -37,27 +37,53 @@ func (s *ProjectService) GetAllProjects(ctx context.Context) ([]model.Project, e
 
 // GetProjectByID retrieves a project by ID
 func (s *ProjectService) GetProjectByID(ctx context.Context, id int64) (*model.Project, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid project ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	project, err := s.repo.GetProjectByID(ctx, id)
+	if err != nil {
+		return nil, repoError("project", id, err)
+	}
+	return project, nil
+}
+
+// GetProjectBySlug retrieves the project a slug names now or named before it was changed.
+// A returned project whose Slug differs from slug was found by an old slug.
+func (s *ProjectService) GetProjectBySlug(ctx context.Context, slug string) (*model.Project, error) {
+	project, err := s.repo.GetProjectBySlug(ctx, slug)
+	if err != nil {
+		return nil, repoError("project", 0, err)
 	}
-	return s.repo.GetProjectByID(ctx, id)
+	return project, nil
 }
 
 // CreateProject creates a new project
 func (s *ProjectService) CreateProject(ctx context.Context, req *dto.ProjectRequest) (*model.Project, error) {
+	if err := ValidateProjectRequest(req); err != nil {
+		return nil, err
+	}
+
 	project := &model.Project{
-		Title:       strings.TrimSpace(req.Title),
-		Description: strings.TrimSpace(req.Description),
-		ImageURL:    strings.TrimSpace(req.ImageURL),
-		ProjectURL:  strings.TrimSpace(req.ProjectURL),
-		GithubURL:   strings.TrimSpace(req.GithubURL),
-		TechStack:   strings.TrimSpace(req.TechStack),
-		Color:       getDefaultColor(req.Color, "cyan"),
-		ProfileID:   req.ProfileID,
+		Title:         strings.TrimSpace(req.Title),
+		Description:   strings.TrimSpace(req.Description),
+		ImageURL:      strings.TrimSpace(req.ImageURL),
+		ImageVariants: req.ImageVariants,
+		ProjectURL:    strings.TrimSpace(req.ProjectURL),
+		GithubURL:     strings.TrimSpace(req.GithubURL),
+		TechStack:     strings.TrimSpace(req.TechStack),
+		Color:         getDefaultColor(req.Color, "cyan"),
+		ProfileID:     req.ProfileID,
+		Content:       strings.TrimSpace(req.Content),
 	}
 
+	slug, err := resolveSlug(ctx, "project", req.Slug, project.Title, 0, s.repo.ProjectSlugTaken)
+	if err != nil {
+		return nil, repoError("project", 0, err)
+	}
+	project.Slug = slug
+
 	if err := s.repo.CreateProject(ctx, project); err != nil {
-		return nil, err
+		return nil, repoError("project", 0, err)
 	}
 
 	return project, nil
@@ -65,24 +91,36 @@ func (s *ProjectService) CreateProject(ctx context.Context, req *dto.ProjectRequ
 
 // UpdateProject updates a project
 func (s *ProjectService) UpdateProject(ctx context.Context, id int64, req *dto.ProjectRequest) (*model.Project, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid project ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateProjectRequest(req); err != nil {
+		return nil, err
 	}
 
 	project := &model.Project{
-		ID:          id,
-		Title:       strings.TrimSpace(req.Title),
-		Description: strings.TrimSpace(req.Description),
-		ImageURL:    strings.TrimSpace(req.ImageURL),
-		ProjectURL:  strings.TrimSpace(req.ProjectURL),
-		GithubURL:   strings.TrimSpace(req.GithubURL),
-		TechStack:   strings.TrimSpace(req.TechStack),
-		Color:       getDefaultColor(req.Color, "cyan"),
-		ProfileID:   req.ProfileID,
+		ID:            id,
+		Title:         strings.TrimSpace(req.Title),
+		Description:   strings.TrimSpace(req.Description),
+		ImageURL:      strings.TrimSpace(req.ImageURL),
+		ImageVariants: req.ImageVariants,
+		ProjectURL:    strings.TrimSpace(req.ProjectURL),
+		GithubURL:     strings.TrimSpace(req.GithubURL),
+		TechStack:     strings.TrimSpace(req.TechStack),
+		Color:         getDefaultColor(req.Color, "cyan"),
+		ProfileID:     req.ProfileID,
+		Content:       strings.TrimSpace(req.Content),
+	}
+
+	// An empty slug keeps the current one, which the repository fills in
+	slug, err := resolveSlug(ctx, "project", req.Slug, project.Title, id, s.repo.ProjectSlugTaken)
+	if err != nil {
+		return nil, repoError("project", id, err)
 	}
+	project.Slug = slug
 
 	if err := s.repo.UpdateProject(ctx, project); err != nil {
-		return nil, err
+		return nil, repoError("project", id, err)
 	}
 
 	return project, nil
@@ -90,10 +128,10 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id int64, req *dto.P
 
 // DeleteProject deletes a project
 func (s *ProjectService) DeleteProject(ctx context.Context, id int64) error {
-	if id <= 0 {
-		return errors.New("invalid project ID")
+	if err := ValidateID(id); err != nil {
+		return err
 	}
-	return s.repo.DeleteProject(ctx, id)
+	return repoError("project", id, s.repo.DeleteProject(ctx, id))
 }
 
 // getDefaultColor returns the provided color or default if empty
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"errors"
+	"session-19/citation"
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
@@ -13,6 +13,7 @@ import (
 type PublicationServiceInterface interface {
 	GetAllPublications(ctx context.Context) ([]model.Publication, error)
 	GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error)
+	GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error)
 	CreatePublication(ctx context.Context, req *dto.PublicationRequest) (*model.Publication, error)
 	UpdatePublication(ctx context.Context, id int64, req *dto.PublicationRequest) (*model.Publication, error)
 	DeletePublication(ctx context.Context, id int64) error
@@ -37,27 +38,57 @@ func (s *PublicationService) GetAllPublications(ctx context.Context) ([]model.Pu
 
 // GetPublicationByID retrieves a publication by ID
 func (s *PublicationService) GetPublicationByID(ctx context.Context, id int64) (*model.Publication, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid publication ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	pub, err := s.repo.GetPublicationByID(ctx, id)
+	if err != nil {
+		return nil, repoError("publication", id, err)
 	}
-	return s.repo.GetPublicationByID(ctx, id)
+	return pub, nil
+}
+
+// GetPublicationBySlug retrieves the publication a slug names now or named before it was changed.
+// A returned publication whose Slug differs from slug was found by an old slug.
+func (s *PublicationService) GetPublicationBySlug(ctx context.Context, slug string) (*model.Publication, error) {
+	pub, err := s.repo.GetPublicationBySlug(ctx, slug)
+	if err != nil {
+		return nil, repoError("publication", 0, err)
+	}
+	return pub, nil
 }
 
 // CreatePublication creates a new publication
 func (s *PublicationService) CreatePublication(ctx context.Context, req *dto.PublicationRequest) (*model.Publication, error) {
+	if err := ValidatePublicationRequest(req); err != nil {
+		return nil, err
+	}
+
 	pub := &model.Publication{
 		Title:          strings.TrimSpace(req.Title),
-		Authors:        strings.TrimSpace(req.Authors),
+		Authors:        normalizeAuthors(req.Authors),
 		Journal:        strings.TrimSpace(req.Journal),
+		VenueType:      venueType(req.VenueType),
+		Volume:         strings.TrimSpace(req.Volume),
+		Pages:          strings.TrimSpace(req.Pages),
 		Year:           req.Year,
+		DOI:            citation.DOI(req.DOI),
 		Description:    strings.TrimSpace(req.Description),
 		ImageURL:       strings.TrimSpace(req.ImageURL),
+		ImageVariants:  req.ImageVariants,
 		PublicationURL: strings.TrimSpace(req.PublicationURL),
 		Color:          getPublicationDefaultColor(req.Color, "red"),
+		Content:        strings.TrimSpace(req.Content),
 	}
 
+	slug, err := resolveSlug(ctx, "publication", req.Slug, pub.Title, 0, s.repo.PublicationSlugTaken)
+	if err != nil {
+		return nil, repoError("publication", 0, err)
+	}
+	pub.Slug = slug
+
 	if err := s.repo.CreatePublication(ctx, pub); err != nil {
-		return nil, err
+		return nil, repoError("publication", 0, err)
 	}
 
 	return pub, nil
@@ -65,24 +96,40 @@ func (s *PublicationService) CreatePublication(ctx context.Context, req *dto.Pub
 
 // UpdatePublication updates a publication
 func (s *PublicationService) UpdatePublication(ctx context.Context, id int64, req *dto.PublicationRequest) (*model.Publication, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid publication ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidatePublicationRequest(req); err != nil {
+		return nil, err
 	}
 
 	pub := &model.Publication{
 		ID:             id,
 		Title:          strings.TrimSpace(req.Title),
-		Authors:        strings.TrimSpace(req.Authors),
+		Authors:        normalizeAuthors(req.Authors),
 		Journal:        strings.TrimSpace(req.Journal),
+		VenueType:      venueType(req.VenueType),
+		Volume:         strings.TrimSpace(req.Volume),
+		Pages:          strings.TrimSpace(req.Pages),
 		Year:           req.Year,
+		DOI:            citation.DOI(req.DOI),
 		Description:    strings.TrimSpace(req.Description),
 		ImageURL:       strings.TrimSpace(req.ImageURL),
+		ImageVariants:  req.ImageVariants,
 		PublicationURL: strings.TrimSpace(req.PublicationURL),
 		Color:          getPublicationDefaultColor(req.Color, "red"),
+		Content:        strings.TrimSpace(req.Content),
 	}
 
+	// An empty slug keeps the current one, which the repository fills in
+	slug, err := resolveSlug(ctx, "publication", req.Slug, pub.Title, id, s.repo.PublicationSlugTaken)
+	if err != nil {
+		return nil, repoError("publication", id, err)
+	}
+	pub.Slug = slug
+
 	if err := s.repo.UpdatePublication(ctx, pub); err != nil {
-		return nil, err
+		return nil, repoError("publication", id, err)
 	}
 
 	return pub, nil
@@ -90,10 +137,33 @@ func (s *PublicationService) UpdatePublication(ctx context.Context, id int64, re
 
 // DeletePublication deletes a publication
 func (s *PublicationService) DeletePublication(ctx context.Context, id int64) error {
-	if id <= 0 {
-		return errors.New("invalid publication ID")
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	return repoError("publication", id, s.repo.DeletePublication(ctx, id))
+}
+
+// normalizeAuthors trims the author details and stores ORCID iDs bare
+func normalizeAuthors(authors model.Authors) model.Authors {
+	out := make(model.Authors, len(authors))
+	for i, a := range authors {
+		out[i] = model.Author{
+			Name:        strings.Join(strings.Fields(a.Name), " "),
+			Affiliation: strings.TrimSpace(a.Affiliation),
+			ORCID:       orcidID(a.ORCID),
+			URL:         strings.TrimSpace(a.URL),
+			IsMe:        a.IsMe,
+		}
+	}
+	return out
+}
+
+// venueType defaults an empty venue type to journal
+func venueType(venue string) string {
+	if venue == "" {
+		return model.VenueJournal
 	}
-	return s.repo.DeletePublication(ctx, id)
+	return venue
 }
 
 // getPublicationDefaultColor returns the provided color or default if empty
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"session-19/citation"
+	"session-19/dto"
+	"session-19/model"
+)
+
+// ImportSkip marks an imported record that duplicates an existing one
+const ImportSkip = "skip"
+
+// MaxDOIs caps the DOIs looked up in one import; each is a request to the resolver
+const MaxDOIs = 20
+
+// Publication import errors
+var (
+	ErrImportEmpty = errors.New("paste BibTeX or RIS, or enter at least one DOI")
+	ErrTooManyDOIs = fmt.Errorf("at most %d DOIs can be looked up at once", MaxDOIs)
+)
+
+// PublicationImportServiceInterface defines the interface for importing publications from citations
+type PublicationImportServiceInterface interface {
+	ReadPublications(ctx context.Context, document, dois string) ([]model.Publication, error)
+	PreviewPublications(ctx context.Context, pubs []model.Publication) (*ImportPlan, error)
+	ImportPublications(ctx context.Context, pubs []model.Publication) (*ImportPlan, error)
+}
+
+// PublicationImportService creates publications from pasted BibTeX or RIS and
+// from DOIs looked up with a metadata resolver. Records that match an existing
+// publication by DOI or title are skipped rather than updated.
+type PublicationImportService struct {
+	portfolio PortfolioServiceInterface
+	resolver  citation.Resolver
+}
+
+// NewPublicationImportService creates a new publication import service. Imports go
+// through portfolio so that a cached portfolio is invalidated.
+func NewPublicationImportService(portfolio PortfolioServiceInterface, resolver citation.Resolver) PublicationImportServiceInterface {
+	return &PublicationImportService{portfolio: portfolio, resolver: resolver}
+}
+
+// ReadPublications parses document as BibTeX or RIS and looks up each DOI in dois,
+// one per line or separated by spaces or commas
+func (s *PublicationImportService) ReadPublications(ctx context.Context, document, dois string) ([]model.Publication, error) {
+	var pubs []model.Publication
+	if strings.TrimSpace(document) != "" {
+		parsed, err := citation.Parse(document)
+		if err != nil {
+			return nil, validationResult([]FieldError{fieldError("document", FieldInvalid, err)})
+		}
+		pubs = parsed
+	}
+
+	var fields []FieldError
+	var lookups []string
+	for _, value := range strings.FieldsFunc(dois, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t' }) {
+		doi := citation.DOI(value)
+		if doi == "" {
+			fields = append(fields, FieldError{Field: "dois", Code: FieldInvalid, Message: value + ": " + ErrDOIInvalid.Error(), err: ErrDOIInvalid})
+			continue
+		}
+		lookups = append(lookups, doi)
+	}
+	if len(lookups) > MaxDOIs {
+		fields = append(fields, fieldError("dois", FieldOutOfRange, ErrTooManyDOIs))
+	}
+	if err := validationResult(fields); err != nil {
+		return nil, err
+	}
+
+	for _, doi := range lookups {
+		pub, err := s.resolver.Resolve(ctx, doi)
+		if errors.Is(err, citation.ErrDOINotFound) {
+			fields = append(fields, FieldError{Field: "dois", Code: FieldInvalid, Message: doi + ": " + citation.ErrDOINotFound.Error(), err: err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pubs = append(pubs, pub)
+	}
+	if err := validationResult(fields); err != nil {
+		return nil, err
+	}
+
+	if len(pubs) == 0 {
+		return nil, validationResult([]FieldError{fieldError("document", FieldRequired, ErrImportEmpty)})
+	}
+	return pubs, nil
+}
+
+// PreviewPublications reports which publications importing pubs would create and which it would skip
+func (s *PublicationImportService) PreviewPublications(ctx context.Context, pubs []model.Publication) (*ImportPlan, error) {
+	existing, err := s.portfolio.GetAllPublications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := ownerName(ctx, s.portfolio)
+	if err != nil {
+		return nil, err
+	}
+	return planPublicationImport(existing, pubs, owner), nil
+}
+
+// ImportPublications creates the new publications of pubs in one transaction. The plan
+// is rebuilt inside the transaction, so duplicates are checked against the data it was applied to.
+func (s *PublicationImportService) ImportPublications(ctx context.Context, pubs []model.Publication) (*ImportPlan, error) {
+	var plan *ImportPlan
+	err := s.portfolio.UpdateInTransaction(ctx, func(tx PortfolioServiceInterface) error {
+		existing, err := tx.GetAllPublications(ctx)
+		if err != nil {
+			return err
+		}
+		owner, err := ownerName(ctx, tx)
+		if err != nil {
+			return err
+		}
+		plan = planPublicationImport(existing, pubs, owner)
+		if err := plan.Err(); err != nil {
+			return err
+		}
+		for _, c := range plan.Changes {
+			if c.apply == nil {
+				continue
+			}
+			if err := c.apply(ctx, tx); err != nil {
+				return fmt.Errorf("failed to import %s %q: %w", c.Entity, c.Label, err)
+			}
+		}
+		return nil
+	})
+	return plan, err
+}
+
+// ownerName returns the profile name, "" before a profile exists
+func ownerName(ctx context.Context, svc PortfolioServiceInterface) (string, error) {
+	profile, err := svc.GetProfile(ctx)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return profile.Name, nil
+}
+
+// planPublicationImport plans a create for each incoming publication that matches
+// neither an existing publication nor an earlier incoming one by DOI or title.
+// The author named like owner is marked as the portfolio owner.
+func planPublicationImport(existing, incoming []model.Publication, owner string) *ImportPlan {
+	byDOI := map[string]model.Publication{}
+	byTitle := map[string]model.Publication{}
+	index := func(pub model.Publication) {
+		if doi := publicationDOI(pub); doi != "" {
+			byDOI[strings.ToLower(doi)] = pub
+		}
+		byTitle[importKey(pub.Title)] = pub
+	}
+	for _, pub := range existing {
+		index(pub)
+	}
+
+	plan := &ImportPlan{}
+	for _, in := range incoming {
+		if reason := duplicateOf(in, byDOI, byTitle); reason != "" {
+			plan.Changes = append(plan.Changes, ImportChange{Entity: "publication", Label: in.Title, Action: ImportSkip,
+				Fields: []ImportField{{Name: "duplicate", New: reason}}})
+			continue
+		}
+		// Later copies in the same text are duplicates of this one
+		index(model.Publication{Title: in.Title, DOI: in.DOI, PublicationURL: in.PublicationURL})
+
+		req := dto.PublicationRequest{}
+		var d fieldDiff
+		d.set("title", &req.Title, in.Title)
+		d.setAuthors("authors", &req.Authors, markOwner(in.Authors, owner))
+		d.set("journal", &req.Journal, in.Journal)
+		d.set("venue_type", &req.VenueType, in.VenueType)
+		d.set("volume", &req.Volume, in.Volume)
+		d.set("pages", &req.Pages, in.Pages)
+		d.setInt("year", &req.Year, in.Year)
+		d.set("doi", &req.DOI, in.DOI)
+		d.set("publication_url", &req.PublicationURL, in.PublicationURL)
+		d.set("description", &req.Description, in.Description)
+
+		plan.add("publication", in.Title, false, d, ValidatePublicationRequest(&req), func(ctx context.Context, svc PortfolioServiceInterface) error {
+			_, err := svc.CreatePublication(ctx, &req)
+			return err
+		})
+	}
+	return plan
+}
+
+// duplicateOf explains which publication in matches, "" when it is new.
+// A match without an ID came earlier in the same import.
+func duplicateOf(in model.Publication, byDOI, byTitle map[string]model.Publication) string {
+	describe := func(match model.Publication, by string) string {
+		if match.ID == 0 {
+			return "same " + by + " as an earlier entry"
+		}
+		return fmt.Sprintf("same %s as %q", by, match.Title)
+	}
+	if doi := publicationDOI(in); doi != "" {
+		if match, ok := byDOI[strings.ToLower(doi)]; ok {
+			return describe(match, "DOI")
+		}
+	}
+	if match, ok := byTitle[importKey(in.Title)]; ok && in.Title != "" {
+		return describe(match, "title")
+	}
+	return ""
+}
+
+// publicationDOI returns the DOI of pub, falling back to a DOI in its URL
+func publicationDOI(pub model.Publication) string {
+	if pub.DOI != "" {
+		return pub.DOI
+	}
+	return citation.DOI(pub.PublicationURL)
+}
+
+// markOwner marks the author named like the portfolio owner as them. Names are
+// matched case- and whitespace-insensitively; without a match authors is returned as is.
+func markOwner(authors model.Authors, owner string) model.Authors {
+	if owner == "" {
+		return authors
+	}
+	marked := slices.Clone(authors)
+	for i, a := range marked {
+		if importKey(a.Name) == importKey(owner) {
+			marked[i].IsMe = true
+			break
+		}
+	}
+	return marked
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"session-19/citation"
+	"session-19/model"
+	"session-19/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver answers DOI lookups from a map
+type fakeResolver map[string]model.Publication
+
+func (f fakeResolver) Resolve(_ context.Context, doi string) (model.Publication, error) {
+	if doi == "10.1000/down" {
+		return model.Publication{}, errors.New("connection refused")
+	}
+	pub, ok := f[doi]
+	if !ok {
+		return model.Publication{}, citation.ErrDOINotFound
+	}
+	return pub, nil
+}
+
+func newTestPublicationImportService() (PublicationImportServiceInterface, *repository.MockPortfolioRepository) {
+	mockRepo := new(repository.MockPortfolioRepository)
+	mockRepo.On("GetAllPublications", mock.Anything).Return([]model.Publication{
+		{ID: 2, Title: "On Caching", PublicationURL: "https://doi.org/10.1000/CACHE"},
+		{ID: 3, Title: "Microservices in Practice"},
+	}, nil).Maybe()
+	mockRepo.On("GetProfile", mock.Anything).Return(&model.Profile{ID: 1, Name: "Jane Doe"}, nil).Maybe()
+	resolver := fakeResolver{"10.1000/new": {Title: "Fresh Paper", Authors: model.Authors{{Name: "Jane Doe"}}, Journal: "JSys", VenueType: model.VenueJournal, Year: 2025, DOI: "10.1000/new"}}
+	return NewPublicationImportService(NewPortfolioService(mockRepo), resolver), mockRepo
+}
+
+const importBib = `@article{a, title = {A Different Title}, author = {Doe, Jane}, journal = {JSys}, year = 2024, doi = {10.1000/cache}}
+@article{b, title = {MICROSERVICES  in practice}, author = {Doe, Jane}, journal = {JSys}, year = 2023}
+@article{c, title = {Brand New}, author = {Doe, Jane and Roe, Rich}, journal = {JSys}, year = 2024}
+@article{d, title = {Brand new}, author = {Doe, Jane}, journal = {JSys}, year = 2024}
+@misc{e, title = {No Venue}, author = {Doe, Jane}, year = 2024}`
+
+func TestPublicationImportService_Preview_SkipsDuplicates(t *testing.T) {
+	svc, mockRepo := newTestPublicationImportService()
+	ctx := context.Background()
+
+	pubs, err := svc.ReadPublications(ctx, importBib, "https://doi.org/10.1000/new")
+	require.NoError(t, err)
+	require.Len(t, pubs, 6)
+
+	plan, err := svc.PreviewPublications(ctx, pubs)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{ImportSkip, ImportSkip, ImportCreate, ImportSkip, ImportCreate, ImportCreate},
+		[]string{plan.Changes[0].Action, plan.Changes[1].Action, plan.Changes[2].Action, plan.Changes[3].Action, plan.Changes[4].Action, plan.Changes[5].Action})
+	assert.Equal(t, []ImportField{{Name: "duplicate", New: `same DOI as "On Caching"`}}, plan.Changes[0].Fields)
+	assert.Equal(t, []ImportField{{Name: "duplicate", New: `same title as "Microservices in Practice"`}}, plan.Changes[1].Fields)
+	assert.Equal(t, []ImportField{{Name: "duplicate", New: "same title as an earlier entry"}}, plan.Changes[3].Fields)
+	assert.Equal(t, ImportChange{Entity: "publication", Label: "Brand New", Action: ImportCreate, Fields: []ImportField{
+		{Name: "title", New: "Brand New"}, {Name: "authors", New: "Jane Doe, Rich Roe"}, {Name: "journal", New: "JSys"},
+		{Name: "venue_type", New: "journal"}, {Name: "year", New: "2024"},
+	}}, withoutApply(plan.Changes[2]))
+	assert.Equal(t, "journal is required", plan.Changes[4].Error)
+	assert.Equal(t, "Fresh Paper", plan.Changes[5].Label)
+	assert.ErrorIs(t, plan.Err(), ErrJournalRequired)
+	mockRepo.AssertNotCalled(t, "UpdateInTransaction", mock.Anything, mock.Anything)
+}
+
+func TestPublicationImportService_Import_CreatesNewOnly(t *testing.T) {
+	svc, mockRepo := newTestPublicationImportService()
+	ctx := context.Background()
+
+	mockRepo.On("UpdateInTransaction", ctx, mock.Anything).Return(nil).Once()
+	mockRepo.On("PublicationSlugTaken", ctx, "fresh-paper", int64(0)).Return(false, nil).Once()
+	mockRepo.On("CreatePublication", ctx, mock.MatchedBy(func(p *model.Publication) bool {
+		return p.Title == "Fresh Paper" && p.DOI == "10.1000/new" && p.Authors[0].IsMe
+	})).Return(nil).Once()
+
+	pubs, err := svc.ReadPublications(ctx, "", "10.1000/new")
+	require.NoError(t, err)
+	plan, err := svc.ImportPublications(ctx, pubs)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(ImportCreate))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPublicationImportService_Read_Errors(t *testing.T) {
+	svc, _ := newTestPublicationImportService()
+	ctx := context.Background()
+
+	_, err := svc.ReadPublications(ctx, "  ", "")
+	assert.ErrorIs(t, err, ErrImportEmpty)
+
+	_, err = svc.ReadPublications(ctx, "Just some text", "")
+	assert.ErrorIs(t, err, citation.ErrUnknownFormat)
+	assert.Equal(t, "document", FieldErrors(err)[0].Field)
+
+	_, err = svc.ReadPublications(ctx, "", "10.1000/new, not-a-doi")
+	assert.ErrorIs(t, err, ErrDOIInvalid)
+	assert.Equal(t, "not-a-doi: "+ErrDOIInvalid.Error(), FieldErrors(err)[0].Message)
+
+	_, err = svc.ReadPublications(ctx, "", "10.1000/new\n10.1000/missing")
+	assert.ErrorIs(t, err, citation.ErrDOINotFound)
+	assert.Equal(t, CodeValidation, ErrorCode(err))
+
+	_, err = svc.ReadPublications(ctx, "", "10.1000/down")
+	assert.Error(t, err)
+	assert.Equal(t, CodeInternal, ErrorCode(err))
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/repository"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SEOServiceInterface defines the interface for the SEO settings service
+type SEOServiceInterface interface {
+	GetSEOSettings(ctx context.Context) (*model.SEOSettings, error)
+	UpdateSEOSettings(ctx context.Context, req *dto.SEORequest) (*model.SEOSettings, error)
+}
+
+// SEOService implements SEOServiceInterface
+type SEOService struct {
+	repo repository.PortfolioRepositoryInterface
+}
+
+// NewSEOService creates a new SEO settings service
+func NewSEOService(repo repository.PortfolioRepositoryInterface) SEOServiceInterface {
+	return &SEOService{
+		repo: repo,
+	}
+}
+
+// GetSEOSettings retrieves the SEO settings, empty when none were saved yet
+func (s *SEOService) GetSEOSettings(ctx context.Context) (*model.SEOSettings, error) {
+	settings, err := s.repo.GetSEOSettings(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.SEOSettings{}, nil
+	}
+	if err != nil {
+		return nil, repoError("SEO settings", 0, err)
+	}
+	return settings, nil
+}
+
+// UpdateSEOSettings replaces the SEO settings
+func (s *SEOService) UpdateSEOSettings(ctx context.Context, req *dto.SEORequest) (*model.SEOSettings, error) {
+	if err := ValidateSEORequest(req); err != nil {
+		return nil, err
+	}
+
+	settings := &model.SEOSettings{
+		// Page URLs are appended to the site URL, so it never ends in a slash
+		SiteURL:       strings.TrimRight(strings.TrimSpace(req.SiteURL), "/"),
+		Title:         strings.TrimSpace(req.Title),
+		Description:   strings.TrimSpace(req.Description),
+		ImageURL:      strings.TrimSpace(req.ImageURL),
+		TwitterHandle: strings.TrimPrefix(strings.TrimSpace(req.TwitterHandle), "@"),
+		Robots:        robotsRules(req.Robots),
+	}
+	if settings.TwitterHandle != "" {
+		settings.TwitterHandle = "@" + settings.TwitterHandle
+	}
+
+	if err := s.repo.UpdateSEOSettings(ctx, settings); err != nil {
+		return nil, repoError("SEO settings", 0, err)
+	}
+	return settings, nil
+}
+
+// robotsRules trims each robots.txt line and drops blank ones, normalizing line endings
+func robotsRules(rules string) string {
+	var lines []string
+	for _, line := range strings.Split(rules, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
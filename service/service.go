@@ -1,17 +1,39 @@
 package service
 
-import "session-19/repository"
+import (
+	"session-19/citation"
+	"session-19/repository"
+	"session-19/storage"
+	"time"
+)
+
+// portfolioCacheTTL bounds how long portfolio data is served from memory
+// when it is changed outside the application (e.g. with raw SQL)
+const portfolioCacheTTL = 5 * time.Minute
 
 // Service contains all services
 type Service struct {
-	PortfolioService PortfolioServiceInterface
-	AuthService      AuthServiceInterface
+	PortfolioService  PortfolioServiceInterface
+	AuthService       AuthServiceInterface
+	MediaService      MediaServiceInterface
+	CVService         CVServiceInterface
+	JSONResumeService JSONResumeServiceInterface
+	BackupService     BackupServiceInterface
+	// PublicationImportService looks DOIs up with Crossref, see CROSSREF_URL and CROSSREF_MAILTO
+	PublicationImportService PublicationImportServiceInterface
 }
 
 // NewService creates a new service with all sub-services
-func NewService(repo repository.Repository) Service {
+func NewService(repo repository.Repository, store storage.Storage) Service {
+	portfolio := NewCachedPortfolioService(NewPortfolioService(repo.PortfolioRepo), portfolioCacheTTL)
 	return Service{
-		PortfolioService: NewPortfolioService(repo.PortfolioRepo),
-		AuthService:      NewAuthService(repo.UserRepo),
+		PortfolioService:  portfolio,
+		AuthService:       NewAuthService(repo.UserRepo),
+		MediaService:      NewMediaService(repo.PortfolioRepo, repo.MediaRepo, repo.CVRepo, store),
+		CVService:         NewCVService(repo.CVRepo, portfolio, store),
+		JSONResumeService: NewJSONResumeService(portfolio),
+		BackupService:     NewBackupService(repo.BackupRepo, portfolio, store),
+
+		PublicationImportService: NewPublicationImportService(portfolio, citation.DefaultCrossref()),
 	}
 }
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"session-19/dto"
 	"session-19/model"
 	"session-19/repository"
@@ -38,22 +37,30 @@ func (s *SkillService) GetAllSkills(ctx context.Context) ([]model.Skill, error)
 
 // GetSkillsByCategory retrieves skills by category
 func (s *SkillService) GetSkillsByCategory(ctx context.Context, category string) ([]model.Skill, error) {
-	if category == "" {
-		return nil, errors.New("category is required")
+	if err := ValidateCategory(category); err != nil {
+		return nil, err
 	}
 	return s.repo.GetSkillsByCategory(ctx, category)
 }
 
 // GetSkillByID retrieves a skill by ID
 func (s *SkillService) GetSkillByID(ctx context.Context, id int64) (*model.Skill, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid skill ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	skill, err := s.repo.GetSkillByID(ctx, id)
+	if err != nil {
+		return nil, repoError("skill", id, err)
 	}
-	return s.repo.GetSkillByID(ctx, id)
+	return skill, nil
 }
 
 // CreateSkill creates a new skill
 func (s *SkillService) CreateSkill(ctx context.Context, req *dto.SkillRequest) (*model.Skill, error) {
+	if err := ValidateSkillRequest(req); err != nil {
+		return nil, err
+	}
+
 	skill := &model.Skill{
 		Category: strings.TrimSpace(req.Category),
 		Name:     strings.TrimSpace(req.Name),
@@ -62,7 +69,7 @@ func (s *SkillService) CreateSkill(ctx context.Context, req *dto.SkillRequest) (
 	}
 
 	if err := s.repo.CreateSkill(ctx, skill); err != nil {
-		return nil, err
+		return nil, repoError("skill", 0, err)
 	}
 
 	return skill, nil
@@ -70,8 +77,11 @@ func (s *SkillService) CreateSkill(ctx context.Context, req *dto.SkillRequest) (
 
 // UpdateSkill updates a skill
 func (s *SkillService) UpdateSkill(ctx context.Context, id int64, req *dto.SkillRequest) (*model.Skill, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid skill ID")
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateSkillRequest(req); err != nil {
+		return nil, err
 	}
 
 	skill := &model.Skill{
@@ -83,7 +93,7 @@ func (s *SkillService) UpdateSkill(ctx context.Context, id int64, req *dto.Skill
 	}
 
 	if err := s.repo.UpdateSkill(ctx, skill); err != nil {
-		return nil, err
+		return nil, repoError("skill", id, err)
 	}
 
 	return skill, nil
@@ -91,10 +101,10 @@ func (s *SkillService) UpdateSkill(ctx context.Context, id int64, req *dto.Skill
 
 // DeleteSkill deletes a skill
 func (s *SkillService) DeleteSkill(ctx context.Context, id int64) error {
-	if id <= 0 {
-		return errors.New("invalid skill ID")
+	if err := ValidateID(id); err != nil {
+		return err
 	}
-	return s.repo.DeleteSkill(ctx, id)
+	return repoError("skill", id, s.repo.DeleteSkill(ctx, id))
 }
 
 // getColorForLevel returns a color based on skill level
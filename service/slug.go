@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"session-19/utils"
+)
+
+// slugTakenFunc reports whether slug belongs, now or before, to a record other than excludeID
+type slugTakenFunc func(ctx context.Context, slug string, excludeID int64) (bool, error)
+
+// resolveSlug returns the slug to store for the record id (0 when creating).
+// A requested slug is normalized and must be free. Without one, a new record gets
+// a slug generated from its title, numbered until free, and an existing record
+// gets "" to keep its current slug.
+func resolveSlug(ctx context.Context, resource, requested, title string, id int64, taken slugTakenFunc) (string, error) {
+	if slug := utils.Slugify(requested); slug != "" {
+		exists, err := taken(ctx, slug, id)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return "", &ConflictError{Resource: resource, Message: resource + " with this slug already exists"}
+		}
+		return slug, nil
+	}
+	if id != 0 {
+		return "", nil
+	}
+
+	base := utils.Slugify(title)
+	if base == "" {
+		base = resource
+	}
+	return utils.UniqueSlug(base, func(slug string) (bool, error) {
+		return taken(ctx, slug, 0)
+	})
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"session-19/dto"
+	"session-19/model"
+	"session-19/repository"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TestimonialInviteTTL is how long a testimonial invite link can be used
+const TestimonialInviteTTL = 30 * 24 * time.Hour
+
+// ErrInviteClosed is returned for a testimonial invite link that is unknown, used or expired.
+// The three are not told apart, so a guessed token reveals nothing.
+var ErrInviteClosed = &NotFoundError{Resource: "testimonial invite"}
+
+// TestimonialServiceInterface defines the interface for testimonial service
+type TestimonialServiceInterface interface {
+	GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error)
+	CreateTestimonial(ctx context.Context, req *dto.TestimonialRequest) (*model.Testimonial, error)
+	UpdateTestimonial(ctx context.Context, id int64, req *dto.TestimonialRequest) (*model.Testimonial, error)
+	SetTestimonialStatus(ctx context.Context, id int64, status string) error
+	DeleteTestimonial(ctx context.Context, id int64) error
+
+	GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error)
+	IssueTestimonialInvite(ctx context.Context, req *dto.TestimonialInviteRequest) (*model.TestimonialInvite, error)
+	RevokeTestimonialInvite(ctx context.Context, id int64) error
+	GetOpenTestimonialInvite(ctx context.Context, token string) (*model.TestimonialInvite, error)
+	SubmitTestimonial(ctx context.Context, token string, req *dto.TestimonialRequest) (*model.Testimonial, error)
+}
+
+// TestimonialService implements TestimonialServiceInterface
+type TestimonialService struct {
+	repo repository.PortfolioRepositoryInterface
+}
+
+// NewTestimonialService creates a new testimonial service
+func NewTestimonialService(repo repository.PortfolioRepositoryInterface) TestimonialServiceInterface {
+	return &TestimonialService{
+		repo: repo,
+	}
+}
+
+// GetAllTestimonials retrieves all testimonials, the moderation queue first
+func (s *TestimonialService) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
+	return s.repo.GetAllTestimonials(ctx)
+}
+
+// GetTestimonialByID retrieves a testimonial by ID
+func (s *TestimonialService) GetTestimonialByID(ctx context.Context, id int64) (*model.Testimonial, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	t, err := s.repo.GetTestimonialByID(ctx, id)
+	if err != nil {
+		return nil, repoError("testimonial", id, err)
+	}
+	return t, nil
+}
+
+// CreateTestimonial creates a testimonial added by the owner, approved unless another status is given
+func (s *TestimonialService) CreateTestimonial(ctx context.Context, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	if err := ValidateTestimonialRequest(req); err != nil {
+		return nil, err
+	}
+
+	t := newTestimonial(req)
+	if t.Status == "" {
+		t.Status = model.TestimonialApproved
+	}
+	if err := s.repo.CreateTestimonial(ctx, t); err != nil {
+		return nil, repoError("testimonial", 0, err)
+	}
+
+	return t, nil
+}
+
+// UpdateTestimonial updates a testimonial; an empty status keeps the current one
+func (s *TestimonialService) UpdateTestimonial(ctx context.Context, id int64, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateTestimonialRequest(req); err != nil {
+		return nil, err
+	}
+
+	t := newTestimonial(req)
+	t.ID = id
+	if t.Status == "" {
+		existing, err := s.repo.GetTestimonialByID(ctx, id)
+		if err != nil {
+			return nil, repoError("testimonial", id, err)
+		}
+		t.Status = existing.Status
+	}
+	if err := s.repo.UpdateTestimonial(ctx, t); err != nil {
+		return nil, repoError("testimonial", id, err)
+	}
+
+	return t, nil
+}
+
+// SetTestimonialStatus moderates a testimonial: approving it shows it on the portfolio
+func (s *TestimonialService) SetTestimonialStatus(ctx context.Context, id int64, status string) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	if !slices.Contains(model.TestimonialStatuses, status) {
+		return validationResult([]FieldError{fieldError("status", FieldInvalid, ErrStatusInvalid)})
+	}
+	return repoError("testimonial", id, s.repo.UpdateTestimonialStatus(ctx, id, status))
+}
+
+// DeleteTestimonial deletes a testimonial
+func (s *TestimonialService) DeleteTestimonial(ctx context.Context, id int64) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	return repoError("testimonial", id, s.repo.DeleteTestimonial(ctx, id))
+}
+
+// GetAllTestimonialInvites retrieves all testimonial invites, newest first
+func (s *TestimonialService) GetAllTestimonialInvites(ctx context.Context) ([]model.TestimonialInvite, error) {
+	return s.repo.GetAllTestimonialInvites(ctx)
+}
+
+// IssueTestimonialInvite creates a link the owner can send to a colleague, valid for TestimonialInviteTTL
+func (s *TestimonialService) IssueTestimonialInvite(ctx context.Context, req *dto.TestimonialInviteRequest) (*model.TestimonialInvite, error) {
+	if err := ValidateTestimonialInviteRequest(req); err != nil {
+		return nil, err
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+	invite := &model.TestimonialInvite{
+		Token:     token,
+		Note:      strings.TrimSpace(req.Note),
+		ExpiresAt: time.Now().Add(TestimonialInviteTTL),
+	}
+	if err := s.repo.CreateTestimonialInvite(ctx, invite); err != nil {
+		return nil, repoError("testimonial invite", 0, err)
+	}
+
+	return invite, nil
+}
+
+// RevokeTestimonialInvite deletes a testimonial invite; testimonials submitted through it are kept
+func (s *TestimonialService) RevokeTestimonialInvite(ctx context.Context, id int64) error {
+	if err := ValidateID(id); err != nil {
+		return err
+	}
+	return repoError("testimonial invite", id, s.repo.DeleteTestimonialInvite(ctx, id))
+}
+
+// GetOpenTestimonialInvite retrieves the invite with the token, ErrInviteClosed unless it can still be used
+func (s *TestimonialService) GetOpenTestimonialInvite(ctx context.Context, token string) (*model.TestimonialInvite, error) {
+	invite, err := s.repo.GetTestimonialInviteByToken(ctx, token)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !invite.Open()) {
+		return nil, ErrInviteClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// SubmitTestimonial stores a testimonial submitted through the invite with the token.
+// It waits in the moderation queue whatever status the request asks for, and uses up the invite.
+func (s *TestimonialService) SubmitTestimonial(ctx context.Context, token string, req *dto.TestimonialRequest) (*model.Testimonial, error) {
+	submitted := *req
+	submitted.Status = ""
+	submitted.Color = ""
+	if err := ValidateTestimonialRequest(&submitted); err != nil {
+		return nil, err
+	}
+
+	t := newTestimonial(&submitted)
+	err := s.repo.SubmitTestimonial(ctx, token, t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInviteClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// newTestimonial builds the testimonial of a validated request
+func newTestimonial(req *dto.TestimonialRequest) *model.Testimonial {
+	return &model.Testimonial{
+		Author:       strings.TrimSpace(req.Author),
+		Role:         strings.TrimSpace(req.Role),
+		Company:      strings.TrimSpace(req.Company),
+		Quote:        strings.TrimSpace(req.Quote),
+		AvatarURL:    strings.TrimSpace(req.AvatarURL),
+		Relationship: strings.TrimSpace(req.Relationship),
+		Status:       strings.TrimSpace(req.Status),
+		Color:        getDefaultColor(req.Color, "yellow"),
+	}
+}
+
+// newInviteToken returns a random URL-safe token of 32 characters
+func newInviteToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -2,9 +2,17 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"regexp"
+	"session-19/citation"
 	"session-19/dto"
+	"session-19/model"
+	"session-19/utils"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // Validation errors
@@ -14,9 +22,10 @@ var (
 	ErrEmailInvalid         = errors.New("email format is invalid")
 	ErrTitleRequired        = errors.New("title is required")
 	ErrOrganizationRequired = errors.New("organization is required")
+	ErrTypeInvalid          = errors.New("type must be one of work, internship, campus, competition")
 	ErrCategoryRequired     = errors.New("category is required")
 	ErrSkillNameRequired    = errors.New("skill name is required")
-	ErrLevelRequired        = errors.New("level is required")
+	ErrLevelInvalid         = errors.New("level must be one of beginner, intermediate, advanced")
 	ErrDescriptionRequired  = errors.New("description is required")
 	ErrMessageRequired      = errors.New("message is required")
 	ErrAuthorsRequired      = errors.New("authors is required")
@@ -24,102 +33,324 @@ var (
 	ErrYearRequired         = errors.New("year is required")
 	ErrYearInvalid          = errors.New("year must be between 1900 and 2100")
 	ErrInvalidID            = errors.New("invalid ID")
+	ErrAltTextTooLong       = errors.New("alt text must be at most 300 characters")
+	ErrSlugInvalid          = errors.New("slug must contain letters or digits")
+	ErrSiteURLInvalid       = errors.New("site URL must be an absolute http(s) URL without query or fragment")
+	ErrImageURLInvalid      = errors.New("image URL must be an absolute http(s) URL or a path starting with /")
+	ErrTwitterInvalid       = errors.New("twitter handle must be up to 15 letters, digits or underscores")
+	ErrSEOTitleTooLong      = errors.New("title must be at most 255 characters")
+	ErrCitationFormat       = errors.New("format must be one of " + strings.Join(citation.Names(), ", "))
+	ErrRobotsInvalid        = errors.New(`robots rules must be "Field: value" lines or # comments`)
+	ErrAuthorNameRequired   = errors.New("name is required")
+	ErrORCIDInvalid         = errors.New("ORCID iD must look like 0000-0002-1825-0097 and have a valid check digit")
+	ErrAuthorURLInvalid     = errors.New("URL must be an absolute http(s) URL")
+	ErrAuthorMeTwice        = errors.New("only one author can be marked as you")
+	ErrDOIInvalid           = errors.New("DOI must look like 10.1000/xyz123")
+	ErrVenueTypeInvalid     = errors.New("venue type must be one of " + strings.Join(model.VenueTypes, ", "))
+	ErrVolumeTooLong        = errors.New("volume must be at most 50 characters")
+	ErrPagesTooLong         = errors.New("pages must be at most 50 characters")
+	ErrInstitutionRequired  = errors.New("institution is required")
+	ErrIssuerRequired       = errors.New("issuer is required")
+	ErrDateInvalid          = errors.New("date must be in YYYY-MM-DD format")
+	ErrEndBeforeStart       = errors.New("end date must not be before the start date")
+	ErrExpiryBeforeIssue    = errors.New("expiry date must not be before the issue date")
+	ErrGPATooLong           = errors.New("GPA must be at most 20 characters")
+	ErrHonorsTooLong        = errors.New("honors must be at most 200 characters")
+	ErrCredentialIDTooLong  = errors.New("credential ID must be at most 100 characters")
+	ErrVerificationURL      = errors.New("verification URL must be an absolute http(s) URL")
+	ErrAuthorRequired       = errors.New("author is required")
+	ErrAuthorTooLong        = errors.New("author must be at most 100 characters")
+	ErrRoleTooLong          = errors.New("role must be at most 100 characters")
+	ErrCompanyTooLong       = errors.New("company must be at most 100 characters")
+	ErrQuoteRequired        = errors.New("quote is required")
+	ErrQuoteTooLong         = errors.New("quote must be at most 2000 characters")
+	ErrRelationshipTooLong  = errors.New("relationship must be at most 200 characters")
+	ErrAvatarURLInvalid     = errors.New("avatar URL must be an absolute http(s) URL or a path starting with /")
+	ErrStatusInvalid        = errors.New("status must be one of " + strings.Join(model.TestimonialStatuses, ", "))
+	ErrNoteTooLong          = errors.New("note must be at most 200 characters")
 )
 
+// maxAltTextLength mirrors media.alt_text in migrations.sql
+const maxAltTextLength = 300
+
+// maxVolumeLength and maxPagesLength mirror publications.volume and publications.pages in migrations.sql
+const (
+	maxVolumeLength = 50
+	maxPagesLength  = 50
+)
+
+// maxGPALength, maxHonorsLength and maxCredentialIDLength mirror education.gpa,
+// education.honors and certifications.credential_id in migrations.sql
+const (
+	maxGPALength          = 20
+	maxHonorsLength       = 200
+	maxCredentialIDLength = 100
+)
+
+// maxAuthorLength, maxRoleLength, maxCompanyLength, maxRelationshipLength and maxNoteLength
+// mirror the testimonials and testimonial_invites columns in migrations.sql; maxQuoteLength
+// keeps submissions through an invite to a readable size
+const (
+	maxAuthorLength       = 100
+	maxRoleLength         = 100
+	maxCompanyLength      = 100
+	maxQuoteLength        = 2000
+	maxRelationshipLength = 200
+	maxNoteLength         = 200
+)
+
+// orcidRegex matches a bare ORCID iD; the last character is a check digit or X
+var orcidRegex = regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{3}[\dX]$`)
+
+// maxSEOTitleLength mirrors seo_settings.title in migrations.sql
+const maxSEOTitleLength = 255
+
 // emailRegex is a simple regex for email validation
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
+// twitterRegex matches a Twitter/X handle with or without the leading @
+var twitterRegex = regexp.MustCompile(`^@?[A-Za-z0-9_]{1,15}$`)
+
+// robotsLineRegex matches a robots.txt record line such as "Disallow: /drafts"
+var robotsLineRegex = regexp.MustCompile(`^[A-Za-z-]+\s*:.*$`)
+
+// experienceTypes and skillLevels mirror the CHECK constraints in migrations.sql
+var (
+	experienceTypes = []string{"work", "internship", "campus", "competition"}
+	skillLevels     = []string{"beginner", "intermediate", "advanced"}
+)
+
 // ValidateProfileRequest validates a profile request
 func ValidateProfileRequest(req *dto.ProfileRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Name) == "" {
-		return ErrNameRequired
-	}
-	if strings.TrimSpace(req.Email) == "" {
-		return ErrEmailRequired
+		fields = append(fields, fieldError("name", FieldRequired, ErrNameRequired))
 	}
-	if !emailRegex.MatchString(req.Email) {
-		return ErrEmailInvalid
-	}
-	return nil
+	fields = appendEmailErrors(fields, req.Email)
+	return validationResult(fields)
 }
 
 // ValidateExperienceRequest validates an experience request
 func ValidateExperienceRequest(req *dto.ExperienceRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Title) == "" {
-		return ErrTitleRequired
+		fields = append(fields, fieldError("title", FieldRequired, ErrTitleRequired))
 	}
 	if strings.TrimSpace(req.Organization) == "" {
-		return ErrOrganizationRequired
+		fields = append(fields, fieldError("organization", FieldRequired, ErrOrganizationRequired))
 	}
-	return nil
+	if t := strings.TrimSpace(req.Type); t != "" && !slices.Contains(experienceTypes, t) {
+		fields = append(fields, fieldError("type", FieldInvalid, ErrTypeInvalid))
+	}
+	return validationResult(fields)
 }
 
 // ValidateSkillRequest validates a skill request
 func ValidateSkillRequest(req *dto.SkillRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Category) == "" {
-		return ErrCategoryRequired
+		fields = append(fields, fieldError("category", FieldRequired, ErrCategoryRequired))
 	}
 	if strings.TrimSpace(req.Name) == "" {
-		return ErrSkillNameRequired
+		fields = append(fields, fieldError("name", FieldRequired, ErrSkillNameRequired))
 	}
-	if strings.TrimSpace(req.Level) == "" {
-		return ErrLevelRequired
+	// Level is optional, but when given it must be one the database accepts
+	if l := strings.TrimSpace(req.Level); l != "" && !slices.Contains(skillLevels, l) {
+		fields = append(fields, fieldError("level", FieldInvalid, ErrLevelInvalid))
 	}
-	return nil
+	return validationResult(fields)
 }
 
 // ValidateProjectRequest validates a project request
 func ValidateProjectRequest(req *dto.ProjectRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Title) == "" {
-		return ErrTitleRequired
+		fields = append(fields, fieldError("title", FieldRequired, ErrTitleRequired))
 	}
 	if strings.TrimSpace(req.Description) == "" {
-		return ErrDescriptionRequired
+		fields = append(fields, fieldError("description", FieldRequired, ErrDescriptionRequired))
 	}
-	return nil
+	fields = appendSlugErrors(fields, req.Slug)
+	return validationResult(fields)
 }
 
 // ValidatePublicationRequest validates a publication request
 func ValidatePublicationRequest(req *dto.PublicationRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Title) == "" {
-		return ErrTitleRequired
-	}
-	if strings.TrimSpace(req.Authors) == "" {
-		return ErrAuthorsRequired
+		fields = append(fields, fieldError("title", FieldRequired, ErrTitleRequired))
 	}
+	fields = appendAuthorErrors(fields, req.Authors)
 	if strings.TrimSpace(req.Journal) == "" {
-		return ErrJournalRequired
+		fields = append(fields, fieldError("journal", FieldRequired, ErrJournalRequired))
+	}
+	if req.VenueType != "" && !slices.Contains(model.VenueTypes, req.VenueType) {
+		fields = append(fields, fieldError("venue_type", FieldInvalid, ErrVenueTypeInvalid))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Volume)) > maxVolumeLength {
+		fields = append(fields, fieldError("volume", FieldOutOfRange, ErrVolumeTooLong))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Pages)) > maxPagesLength {
+		fields = append(fields, fieldError("pages", FieldOutOfRange, ErrPagesTooLong))
+	}
+	if doi := strings.TrimSpace(req.DOI); doi != "" && citation.DOI(doi) == "" {
+		fields = append(fields, fieldError("doi", FieldInvalid, ErrDOIInvalid))
+	}
+	switch {
+	case req.Year == 0:
+		fields = append(fields, fieldError("year", FieldRequired, ErrYearRequired))
+	case req.Year < 1900 || req.Year > 2100:
+		fields = append(fields, fieldError("year", FieldOutOfRange, ErrYearInvalid))
+	}
+	fields = appendSlugErrors(fields, req.Slug)
+	return validationResult(fields)
+}
+
+// ValidateEducationRequest validates an education request
+func ValidateEducationRequest(req *dto.EducationRequest) error {
+	var fields []FieldError
+	if strings.TrimSpace(req.Institution) == "" {
+		fields = append(fields, fieldError("institution", FieldRequired, ErrInstitutionRequired))
+	}
+	fields = appendDateRangeErrors(fields, "start_date", req.StartDate, "end_date", req.EndDate, ErrEndBeforeStart)
+	if utf8.RuneCountInString(strings.TrimSpace(req.GPA)) > maxGPALength {
+		fields = append(fields, fieldError("gpa", FieldOutOfRange, ErrGPATooLong))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Honors)) > maxHonorsLength {
+		fields = append(fields, fieldError("honors", FieldOutOfRange, ErrHonorsTooLong))
+	}
+	return validationResult(fields)
+}
+
+// ValidateCertificationRequest validates a certification request
+func ValidateCertificationRequest(req *dto.CertificationRequest) error {
+	var fields []FieldError
+	if strings.TrimSpace(req.Name) == "" {
+		fields = append(fields, fieldError("name", FieldRequired, ErrNameRequired))
+	}
+	if strings.TrimSpace(req.Issuer) == "" {
+		fields = append(fields, fieldError("issuer", FieldRequired, ErrIssuerRequired))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.CredentialID)) > maxCredentialIDLength {
+		fields = append(fields, fieldError("credential_id", FieldOutOfRange, ErrCredentialIDTooLong))
+	}
+	fields = appendDateRangeErrors(fields, "issue_date", req.IssueDate, "expiry_date", req.ExpiryDate, ErrExpiryBeforeIssue)
+	if link := strings.TrimSpace(req.VerificationURL); link != "" {
+		if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fields = append(fields, fieldError("verification_url", FieldInvalid, ErrVerificationURL))
+		}
+	}
+	return validationResult(fields)
+}
+
+// ValidateTestimonialRequest validates a testimonial request
+func ValidateTestimonialRequest(req *dto.TestimonialRequest) error {
+	var fields []FieldError
+	switch author := strings.TrimSpace(req.Author); {
+	case author == "":
+		fields = append(fields, fieldError("author", FieldRequired, ErrAuthorRequired))
+	case utf8.RuneCountInString(author) > maxAuthorLength:
+		fields = append(fields, fieldError("author", FieldOutOfRange, ErrAuthorTooLong))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Role)) > maxRoleLength {
+		fields = append(fields, fieldError("role", FieldOutOfRange, ErrRoleTooLong))
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Company)) > maxCompanyLength {
+		fields = append(fields, fieldError("company", FieldOutOfRange, ErrCompanyTooLong))
+	}
+	switch quote := strings.TrimSpace(req.Quote); {
+	case quote == "":
+		fields = append(fields, fieldError("quote", FieldRequired, ErrQuoteRequired))
+	case utf8.RuneCountInString(quote) > maxQuoteLength:
+		fields = append(fields, fieldError("quote", FieldOutOfRange, ErrQuoteTooLong))
 	}
-	if req.Year == 0 {
-		return ErrYearRequired
+	if avatar := strings.TrimSpace(req.AvatarURL); avatar != "" && !strings.HasPrefix(avatar, "/") {
+		u, err := url.Parse(avatar)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fields = append(fields, fieldError("avatar_url", FieldInvalid, ErrAvatarURLInvalid))
+		}
 	}
-	if req.Year < 1900 || req.Year > 2100 {
-		return ErrYearInvalid
+	if utf8.RuneCountInString(strings.TrimSpace(req.Relationship)) > maxRelationshipLength {
+		fields = append(fields, fieldError("relationship", FieldOutOfRange, ErrRelationshipTooLong))
+	}
+	if status := strings.TrimSpace(req.Status); status != "" && !slices.Contains(model.TestimonialStatuses, status) {
+		fields = append(fields, fieldError("status", FieldInvalid, ErrStatusInvalid))
+	}
+	return validationResult(fields)
+}
+
+// ValidateTestimonialInviteRequest validates a testimonial invite request
+func ValidateTestimonialInviteRequest(req *dto.TestimonialInviteRequest) error {
+	if utf8.RuneCountInString(strings.TrimSpace(req.Note)) > maxNoteLength {
+		return validationResult([]FieldError{fieldError("note", FieldOutOfRange, ErrNoteTooLong)})
+	}
+	return nil
+}
+
+// ValidateAltText validates the alt text of a media item
+func ValidateAltText(altText string) error {
+	if utf8.RuneCountInString(strings.TrimSpace(altText)) > maxAltTextLength {
+		return validationResult([]FieldError{fieldError("alt_text", FieldOutOfRange, ErrAltTextTooLong)})
 	}
 	return nil
 }
 
+// ValidateSEORequest validates the SEO settings; every field is optional
+func ValidateSEORequest(req *dto.SEORequest) error {
+	var fields []FieldError
+	if site := strings.TrimSpace(req.SiteURL); site != "" {
+		u, err := url.Parse(site)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+			fields = append(fields, fieldError("site_url", FieldInvalid, ErrSiteURLInvalid))
+		}
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Title)) > maxSEOTitleLength {
+		fields = append(fields, fieldError("title", FieldOutOfRange, ErrSEOTitleTooLong))
+	}
+	if image := strings.TrimSpace(req.ImageURL); image != "" && !strings.HasPrefix(image, "/") {
+		u, err := url.Parse(image)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fields = append(fields, fieldError("image_url", FieldInvalid, ErrImageURLInvalid))
+		}
+	}
+	if handle := strings.TrimSpace(req.TwitterHandle); handle != "" && !twitterRegex.MatchString(handle) {
+		fields = append(fields, fieldError("twitter_handle", FieldInvalid, ErrTwitterInvalid))
+	}
+	for _, line := range strings.Split(req.Robots, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !robotsLineRegex.MatchString(line) {
+			fields = append(fields, fieldError("robots", FieldInvalid, ErrRobotsInvalid))
+			break
+		}
+	}
+	return validationResult(fields)
+}
+
 // ValidateContactRequest validates a contact form request
 func ValidateContactRequest(req *dto.ContactRequest) error {
+	var fields []FieldError
 	if strings.TrimSpace(req.Name) == "" {
-		return ErrNameRequired
-	}
-	if strings.TrimSpace(req.Email) == "" {
-		return ErrEmailRequired
-	}
-	if !emailRegex.MatchString(req.Email) {
-		return ErrEmailInvalid
+		fields = append(fields, fieldError("name", FieldRequired, ErrNameRequired))
 	}
+	fields = appendEmailErrors(fields, req.Email)
 	if strings.TrimSpace(req.Message) == "" {
-		return ErrMessageRequired
+		fields = append(fields, fieldError("message", FieldRequired, ErrMessageRequired))
 	}
-	return nil
+	return validationResult(fields)
 }
 
 // ValidateID validates that an ID is valid (positive)
 func ValidateID(id int64) error {
 	if id <= 0 {
-		return ErrInvalidID
+		return validationResult([]FieldError{fieldError("id", FieldInvalid, ErrInvalidID)})
+	}
+	return nil
+}
+
+// ValidateCitationFormat validates the format of a citation export
+func ValidateCitationFormat(format string) error {
+	if _, ok := citation.Lookup(format); !ok {
+		return validationResult([]FieldError{fieldError("format", FieldInvalid, ErrCitationFormat)})
 	}
 	return nil
 }
@@ -127,7 +358,109 @@ func ValidateID(id int64) error {
 // ValidateCategory validates that a category is not empty
 func ValidateCategory(category string) error {
 	if strings.TrimSpace(category) == "" {
-		return ErrCategoryRequired
+		return validationResult([]FieldError{fieldError("category", FieldRequired, ErrCategoryRequired)})
 	}
 	return nil
 }
+
+// appendAuthorErrors validates the author list. Errors of one author name its
+// position, e.g. field "authors[1].orcid" with message "author 2: ORCID iD must...".
+func appendAuthorErrors(fields []FieldError, authors model.Authors) []FieldError {
+	if len(authors) == 0 {
+		return append(fields, fieldError("authors", FieldRequired, ErrAuthorsRequired))
+	}
+	authorError := func(i int, name, code string, err error) FieldError {
+		return FieldError{Field: fmt.Sprintf("authors[%d].%s", i, name), Code: code, Message: fmt.Sprintf("author %d: %s", i+1, err), err: err}
+	}
+	me := 0
+	for i, a := range authors {
+		if strings.TrimSpace(a.Name) == "" {
+			fields = append(fields, authorError(i, "name", FieldRequired, ErrAuthorNameRequired))
+		}
+		if orcid := orcidID(a.ORCID); orcid != "" && !validORCID(orcid) {
+			fields = append(fields, authorError(i, "orcid", FieldInvalid, ErrORCIDInvalid))
+		}
+		if link := strings.TrimSpace(a.URL); link != "" {
+			if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				fields = append(fields, authorError(i, "url", FieldInvalid, ErrAuthorURLInvalid))
+			}
+		}
+		if a.IsMe {
+			if me++; me == 2 {
+				fields = append(fields, authorError(i, "is_me", FieldInvalid, ErrAuthorMeTwice))
+			}
+		}
+	}
+	return fields
+}
+
+// orcidID strips the orcid.org prefix from an ORCID iD and upper-cases the check digit
+func orcidID(orcid string) string {
+	orcid = strings.TrimSpace(orcid)
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/", "orcid.org/"} {
+		orcid = strings.TrimPrefix(orcid, prefix)
+	}
+	return strings.ToUpper(orcid)
+}
+
+// validORCID checks the format and the ISO 7064 MOD 11-2 check digit of a bare ORCID iD
+func validORCID(orcid string) bool {
+	if !orcidRegex.MatchString(orcid) {
+		return false
+	}
+	digits := strings.ReplaceAll(orcid, "-", "")
+	total := 0
+	for _, d := range digits[:15] {
+		total = (total + int(d-'0')) * 2
+	}
+	check := (12 - total%11) % 11
+	want := byte('0' + check)
+	if check == 10 {
+		want = 'X'
+	}
+	return digits[15] == want
+}
+
+// appendEmailErrors validates a required email field
+func appendEmailErrors(fields []FieldError, email string) []FieldError {
+	email = strings.TrimSpace(email)
+	switch {
+	case email == "":
+		return append(fields, fieldError("email", FieldRequired, ErrEmailRequired))
+	case !emailRegex.MatchString(email):
+		return append(fields, fieldError("email", FieldInvalid, ErrEmailInvalid))
+	}
+	return fields
+}
+
+// appendDateRangeErrors validates two optional dates in model.DateLayout, the second
+// of which must not be before the first when both are given
+func appendDateRangeErrors(fields []FieldError, startField, start, endField, end string, errOrder error) []FieldError {
+	parse := func(field, value string) (time.Time, bool) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return time.Time{}, false
+		}
+		t, err := time.Parse(model.DateLayout, value)
+		if err != nil {
+			fields = append(fields, fieldError(field, FieldInvalid, ErrDateInvalid))
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	from, hasFrom := parse(startField, start)
+	to, hasTo := parse(endField, end)
+	if hasFrom && hasTo && to.Before(from) {
+		fields = append(fields, fieldError(endField, FieldOutOfRange, errOrder))
+	}
+	return fields
+}
+
+// appendSlugErrors validates an optional slug, which is normalized before use
+// and must keep at least one letter or digit
+func appendSlugErrors(fields []FieldError, slug string) []FieldError {
+	if strings.TrimSpace(slug) != "" && utils.Slugify(slug) == "" {
+		return append(fields, fieldError("slug", FieldInvalid, ErrSlugInvalid))
+	}
+	return fields
+}
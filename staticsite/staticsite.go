@@ -0,0 +1,301 @@
+package staticsite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// markerFile identifies a directory written by Export, which may be cleared by the next export
+const markerFile = ".export-static"
+
+// ErrOutputNotEmpty is returned when the output directory holds files Export did not write
+var ErrOutputNotEmpty = errors.New("output directory is not empty and was not written by export-static")
+
+// Page is a route rendered to a file
+type Page struct {
+	// URL is the request path, with the query string if any, e.g. "/resume.pdf?layout=sidebar"
+	URL string
+	// File is the output path relative to the output directory, e.g. "index.html"
+	File string
+	// Optional pages are skipped when the route answers 404, e.g. /cv without an active CV
+	Optional bool
+}
+
+// File is content written as is and linked from the pages in place of a route,
+// e.g. the active CV, which must not be requested through its counting route
+type File struct {
+	URL  string
+	File string
+	Data []byte
+}
+
+// Options configures an export
+type Options struct {
+	// Handler serves the public routes the pages are rendered from
+	Handler http.Handler
+	// Pages are the routes to render. HTML pages have their links rewritten.
+	Pages []Page
+	// Files are written next to the pages
+	Files []File
+	// PublicDir is copied to OutDir with fingerprinted filenames and is served from /public/
+	PublicDir string
+	// Exclude lists slash-separated directories under PublicDir that are not copied
+	Exclude []string
+	// OutDir receives the site
+	OutDir string
+	// APIBase, when set, points the pages' /api/v1 calls (the contact form) at a running server
+	APIBase string
+}
+
+// Result summarizes an export
+type Result struct {
+	Pages  []string `json:"pages"`
+	Assets int      `json:"assets"`
+	// Unresolved lists root-relative links that point at neither a page nor an asset;
+	// they only work when the site is served next to the Go server
+	Unresolved []string `json:"unresolved"`
+}
+
+// Export renders the pages through the handler and writes them, with the public
+// assets, to a directory that can be served by any static host or opened from disk
+func Export(ctx context.Context, opts Options) (*Result, error) {
+	if err := prepareOutDir(opts.OutDir); err != nil {
+		return nil, err
+	}
+
+	assets, err := copyAssets(opts.PublicDir, opts.OutDir, opts.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy assets: %w", err)
+	}
+
+	// Every page URL maps to its output file, so pages can link to each other
+	links := make(map[string]string, len(assets)+len(opts.Pages))
+	for url, file := range assets {
+		links[url] = file
+	}
+	rendered := map[string][]byte{}
+	result := &Result{Assets: len(assets), Pages: []string{}, Unresolved: []string{}}
+	for _, f := range opts.Files {
+		if err := writeFile(opts.OutDir, f.File, f.Data); err != nil {
+			return nil, err
+		}
+		links[f.URL] = f.File
+		result.Pages = append(result.Pages, f.File)
+	}
+	for _, page := range opts.Pages {
+		body, contentType, err := render(ctx, opts.Handler, page)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			continue
+		}
+		links[page.URL] = page.File
+		result.Pages = append(result.Pages, page.File)
+
+		// HTML is written once every link target is known
+		if strings.HasPrefix(contentType, "text/html") {
+			rendered[page.File] = body
+			continue
+		}
+		if err := writeFile(opts.OutDir, page.File, body); err != nil {
+			return nil, err
+		}
+	}
+
+	unresolved := map[string]bool{}
+	for file, body := range rendered {
+		body = rewriteLinks(body, file, links, unresolved)
+		if opts.APIBase != "" {
+			body = rewriteAPI(body, opts.APIBase)
+		}
+		if err := writeFile(opts.OutDir, file, body); err != nil {
+			return nil, err
+		}
+	}
+
+	for url := range unresolved {
+		result.Unresolved = append(result.Unresolved, url)
+	}
+	sort.Strings(result.Unresolved)
+	return result, nil
+}
+
+// prepareOutDir creates the output directory, clearing it when a previous export wrote it
+func prepareOutDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		if _, err := os.Stat(filepath.Join(dir, markerFile)); err != nil {
+			return fmt.Errorf("%w: %s", ErrOutputNotEmpty, dir)
+		}
+		for _, e := range entries {
+			if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return os.WriteFile(filepath.Join(dir, markerFile), nil, 0o644)
+}
+
+// render requests a page in-process and returns its body, nil for a skipped optional page
+func render(ctx context.Context, handler http.Handler, page Page) ([]byte, string, error) {
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, page.URL, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code == http.StatusNotFound && page.Optional {
+		return nil, "", nil
+	}
+	if rec.Code != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to render %s: status %d: %s", page.URL, rec.Code, strings.TrimSpace(rec.Body.String()))
+	}
+	return rec.Body.Bytes(), rec.Header().Get("Content-Type"), nil
+}
+
+// copyAssets copies every file under publicDir to OutDir/public with a content hash
+// in its name, e.g. public/assets/profile.jpg to public/assets/profile.3f2a9c1d.jpg,
+// so the files can be cached forever. It returns the output file of each /public URL.
+func copyAssets(publicDir, outDir string, exclude []string) (map[string]string, error) {
+	assets := map[string]string{}
+	if publicDir == "" {
+		return assets, nil
+	}
+	err := filepath.WalkDir(publicDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || strings.HasPrefix(d.Name(), ".") {
+			return err
+		}
+		rel, err := filepath.Rel(publicDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if slices.Contains(exclude, rel) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		file := "public/" + fingerprint(rel, hex.EncodeToString(sum[:])[:8])
+		assets["/public/"+rel] = file
+		return writeFile(outDir, file, data)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return assets, nil
+	}
+	return assets, err
+}
+
+// fingerprint inserts hash before the extension, e.g. "a/b.css" -> "a/b.<hash>.css"
+func fingerprint(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+func writeFile(outDir, file string, data []byte) error {
+	target := filepath.Join(outDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0o644)
+}
+
+// urlAttr matches attributes holding one URL; srcsetAttr matches srcset lists
+var (
+	urlAttr    = regexp.MustCompile(`(\s(?:href|src|action|poster)=")([^"]*)(")`)
+	srcsetAttr = regexp.MustCompile(`(\ssrcset=")([^"]*)(")`)
+)
+
+// rewriteLinks turns root-relative links to pages and assets into links relative
+// to the page at file, and records the root-relative links it cannot resolve
+func rewriteLinks(body []byte, file string, links map[string]string, unresolved map[string]bool) []byte {
+	resolve := func(raw string) string {
+		url := html.UnescapeString(raw)
+		if !strings.HasPrefix(url, "/") || strings.HasPrefix(url, "//") {
+			return raw
+		}
+		target, fragment, _ := strings.Cut(url, "#")
+		if fragment != "" {
+			fragment = "#" + fragment
+		}
+		out, ok := links[target]
+		if !ok {
+			// Templates percent-encode paths, the asset map holds them as stored
+			if decoded, err := neturl.PathUnescape(target); err == nil {
+				out, ok = links[decoded]
+			}
+		}
+		if ok {
+			return html.EscapeString(relative(file, out) + fragment)
+		}
+		unresolved[target] = true
+		return raw
+	}
+
+	body = urlAttr.ReplaceAllFunc(body, func(m []byte) []byte {
+		parts := urlAttr.FindSubmatch(m)
+		return concat(parts[1], []byte(resolve(string(parts[2]))), parts[3])
+	})
+	return srcsetAttr.ReplaceAllFunc(body, func(m []byte) []byte {
+		parts := srcsetAttr.FindSubmatch(m)
+		candidates := strings.Split(string(parts[2]), ",")
+		for i, c := range candidates {
+			fields := strings.Fields(c)
+			if len(fields) == 0 {
+				continue
+			}
+			fields[0] = resolve(fields[0])
+			candidates[i] = strings.Join(fields, " ")
+		}
+		return concat(parts[1], []byte(strings.Join(candidates, ", ")), parts[3])
+	})
+}
+
+// relative returns the path of target as seen from the page at file, both relative to the site root
+func relative(file, target string) string {
+	depth := strings.Count(file, "/")
+	return strings.Repeat("../", depth) + target
+}
+
+// rewriteAPI points the page's /api/v1 calls at base
+func rewriteAPI(body []byte, base string) []byte {
+	base = strings.TrimSuffix(base, "/")
+	for _, quote := range []string{`"`, `'`, "`"} {
+		body = []byte(strings.ReplaceAll(string(body), quote+"/api/v1/", quote+base+"/api/v1/"))
+	}
+	return body
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package staticsite
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPage = `<html><head><link href="/public/css/site.css" rel="stylesheet"></head><body>
+<img src="/public/assets/me%20photo.jpg" srcset="/public/assets/me%20photo.jpg 1x, /public/css/site.css 2x">
+<a href="/#projects">Projects</a> <a href="/resume.pdf">Resume</a> <a href="/cv">CV</a>
+<a href="https://example.com/x">Out</a> <a href="/admin">Admin</a>
+<script>fetch("/api/v1/contact")</script></body></html>`
+
+func testSite(t *testing.T) (Options, string) {
+	t.Helper()
+	public := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(public, "css"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(public, "assets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(public, "css", "site.css"), []byte("body{}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(public, "assets", "me photo.jpg"), []byte("jpeg"), 0o644))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/", "/projects/demo":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(testPage))
+		case "/resume.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	out := filepath.Join(t.TempDir(), "dist")
+	return Options{
+		Handler: handler,
+		Pages: []Page{
+			{URL: "/", File: "index.html"},
+			{URL: "/projects/demo", File: "projects/demo/index.html"},
+			{URL: "/resume.pdf", File: "resume.pdf"},
+			{URL: "/missing", File: "missing.html", Optional: true},
+		},
+		Files:     []File{{URL: "/cv", File: "cv.pdf", Data: []byte("%PDF cv")}},
+		PublicDir: public,
+		OutDir:    out,
+	}, out
+}
+
+func readOut(t *testing.T, out, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(file)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExport(t *testing.T) {
+	opts, out := testSite(t)
+
+	result, err := Export(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Assets)
+	assert.ElementsMatch(t, []string{"cv.pdf", "index.html", "projects/demo/index.html", "resume.pdf"}, result.Pages)
+	assert.Equal(t, []string{"/admin"}, result.Unresolved)
+
+	matches, err := filepath.Glob(filepath.Join(out, "public", "css", "site.*.css"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	cssFile := "public/css/" + filepath.Base(matches[0])
+
+	index := readOut(t, out, "index.html")
+	assert.Contains(t, index, `href="`+cssFile+`"`)
+	assert.Contains(t, index, `href="index.html#projects"`)
+	assert.Contains(t, index, `href="resume.pdf"`)
+	assert.Contains(t, index, `href="cv.pdf"`)
+	assert.Contains(t, index, `href="https://example.com/x"`)
+	assert.Contains(t, index, `href="/admin"`)
+	assert.Regexp(t, `src="public/assets/me photo\.[0-9a-f]{8}\.jpg"`, index)
+	assert.Regexp(t, `srcset="public/assets/me photo\.[0-9a-f]{8}\.jpg 1x, `+cssFile+` 2x"`, index)
+	assert.Contains(t, index, `fetch("/api/v1/contact")`)
+
+	nested := readOut(t, out, "projects/demo/index.html")
+	assert.Contains(t, nested, `href="../../`+cssFile+`"`)
+	assert.Contains(t, nested, `href="../../index.html#projects"`)
+
+	assert.Equal(t, "%PDF", readOut(t, out, "resume.pdf"))
+	assert.Equal(t, "%PDF cv", readOut(t, out, "cv.pdf"))
+	assert.NoFileExists(t, filepath.Join(out, "missing.html"))
+}
+
+func TestExport_Exclude(t *testing.T) {
+	opts, out := testSite(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(opts.PublicDir, "assets", "private"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(opts.PublicDir, "assets", "private", "cv.pdf"), []byte("%PDF"), 0o644))
+	opts.Exclude = []string{"assets/private"}
+
+	result, err := Export(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Assets)
+	assert.NoDirExists(t, filepath.Join(out, "public", "assets", "private"))
+}
+
+func TestExport_APIBase(t *testing.T) {
+	opts, out := testSite(t)
+	opts.APIBase = "https://portfolio.example.com/"
+
+	_, err := Export(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, readOut(t, out, "index.html"), `fetch("https://portfolio.example.com/api/v1/contact")`)
+}
+
+func TestExport_PageError(t *testing.T) {
+	opts, _ := testSite(t)
+	opts.Pages = append(opts.Pages, Page{URL: "/broken", File: "broken.html"})
+
+	_, err := Export(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/broken")
+}
+
+func TestExport_OutputDir(t *testing.T) {
+	opts, out := testSite(t)
+
+	// A directory export-static did not write is never cleared
+	require.NoError(t, os.MkdirAll(out, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(out, "notes.txt"), []byte("keep"), 0o644))
+	_, err := Export(context.Background(), opts)
+	assert.ErrorIs(t, err, ErrOutputNotEmpty)
+	assert.FileExists(t, filepath.Join(out, "notes.txt"))
+
+	// A previous export is replaced
+	require.NoError(t, os.Remove(filepath.Join(out, "notes.txt")))
+	_, err = Export(context.Background(), opts)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(out, "stale.html"), []byte("old"), 0o644))
+	_, err = Export(context.Background(), opts)
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(out, "stale.html"))
+	assert.FileExists(t, filepath.Join(out, "index.html"))
+}
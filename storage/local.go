@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores objects as files under a directory served at a base URL.
+// Objects under PrivatePrefix are kept in a separate directory that is not served.
+type Local struct {
+	dir        string
+	privateDir string
+	baseURL    string
+}
+
+// NewLocal creates a local storage rooted at dir whose files are served under baseURL,
+// keeping private objects under privateDir
+func NewLocal(dir, privateDir, baseURL string) *Local {
+	return &Local{dir: dir, privateDir: privateDir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Put writes the object to a temporary file and renames it into place,
+// so a failed upload never leaves a truncated file behind
+func (s *Local) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create upload directory: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	return nil
+}
+
+// Get opens the file stored under key
+func (s *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete removes the file stored under key
+func (s *Local) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List walks the directories under prefix. Temporary files of uploads in progress are skipped.
+func (s *Local) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects, err := walk(s.dir, "", prefix)
+	if err != nil {
+		return nil, err
+	}
+	private, err := walk(s.privateDir, PrivatePrefix, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return append(objects, private...), nil
+}
+
+// walk lists the files under root, keyed by keyPrefix plus their path relative to root,
+// whose key starts with prefix
+func walk(root, keyPrefix, prefix string) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := keyPrefix + filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// URL returns the URL path the file is served from. Private objects are not served,
+// so their URL only identifies them.
+func (s *Local) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Key maps a URL path under the base URL back to its key
+func (s *Local) Key(url string) (string, bool) {
+	return keyFromURL(s.baseURL, url)
+}
+
+// path converts a key to a file path inside the storage directory
+func (s *Local) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	if rest, ok := strings.CutPrefix(key, PrivatePrefix); ok {
+		return filepath.Join(s.privateDir, filepath.FromSlash(rest)), nil
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal_PutGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocal(dir, t.TempDir(), "/public/assets/")
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "uploads/projects/1_cover.png", strings.NewReader("png bytes"), "image/png"))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "uploads", "projects", "1_cover.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(saved))
+
+	rc, err := s.Get(ctx, "uploads/projects/1_cover.png")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "png bytes", string(body))
+
+	require.NoError(t, s.Delete(ctx, "uploads/projects/1_cover.png"))
+	_, err = s.Get(ctx, "uploads/projects/1_cover.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting again is not an error
+	assert.NoError(t, s.Delete(ctx, "uploads/projects/1_cover.png"))
+}
+
+func TestLocal_List(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocal(dir, t.TempDir(), "/public/assets")
+	ctx := context.Background()
+	require.NoError(t, s.Put(ctx, "uploads/projects/a.png", strings.NewReader("aa"), "image/png"))
+	require.NoError(t, s.Put(ctx, "profile.jpg", strings.NewReader("p"), "image/jpeg"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "uploads", "projects", ".upload-123"), nil, 0644))
+
+	objects, err := s.List(ctx, "uploads/")
+
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "uploads/projects/a.png", objects[0].Key)
+	assert.Equal(t, int64(2), objects[0].Size)
+	assert.False(t, objects[0].ModTime.IsZero())
+
+	// A storage directory that does not exist yet is simply empty
+	objects, err = NewLocal(filepath.Join(dir, "missing"), filepath.Join(dir, "missing-private"), "/x").List(ctx, "")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestLocal_KeepsPrivateObjectsOutsideServedDir(t *testing.T) {
+	dir, privateDir := t.TempDir(), t.TempDir()
+	s := NewLocal(dir, privateDir, "/public/assets")
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "private/cv/1_cv.pdf", strings.NewReader("%PDF"), "application/pdf"))
+	require.NoError(t, s.Put(ctx, "uploads/a.png", strings.NewReader("png"), "image/png"))
+
+	assert.FileExists(t, filepath.Join(privateDir, "cv", "1_cv.pdf"))
+	assert.NoDirExists(t, filepath.Join(dir, "private"))
+
+	rc, err := s.Get(ctx, "private/cv/1_cv.pdf")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "%PDF", string(body))
+
+	objects, err := s.List(ctx, "private/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "private/cv/1_cv.pdf", objects[0].Key)
+
+	key, ok := s.Key(s.URL("private/cv/1_cv.pdf"))
+	assert.True(t, ok)
+	assert.Equal(t, "private/cv/1_cv.pdf", key)
+
+	require.NoError(t, s.Delete(ctx, key))
+	assert.NoFileExists(t, filepath.Join(privateDir, "cv", "1_cv.pdf"))
+}
+
+func TestLocal_RejectsKeysOutsideRoot(t *testing.T) {
+	s := NewLocal(t.TempDir(), t.TempDir(), "/public/assets")
+	ctx := context.Background()
+
+	for _, key := range []string{"", "../secret", "uploads/../../secret", "/etc/passwd", "uploads//a.png", `uploads\a.png`} {
+		assert.ErrorIs(t, s.Put(ctx, key, strings.NewReader("x"), "image/png"), ErrInvalidKey, key)
+		assert.ErrorIs(t, s.Delete(ctx, key), ErrInvalidKey, key)
+	}
+}
+
+func TestLocal_URLAndKey(t *testing.T) {
+	s := NewLocal(t.TempDir(), t.TempDir(), "/public/assets")
+
+	assert.Equal(t, "/public/assets/uploads/a.png", s.URL("uploads/a.png"))
+
+	key, ok := s.Key("/public/assets/uploads/a.png")
+	assert.True(t, ok)
+	assert.Equal(t, "uploads/a.png", key)
+
+	for _, url := range []string{"https://example.com/a.png", "/public/assets/../../main.go", "/public/other/a.png"} {
+		_, ok := s.Key(url)
+		assert.False(t, ok, url)
+	}
+}
+
+func TestNew_SelectsDriver(t *testing.T) {
+	s, err := New(Config{Driver: DriverLocal, LocalDir: t.TempDir(), LocalURL: DefaultLocalURL})
+	require.NoError(t, err)
+	assert.IsType(t, &Local{}, s)
+
+	s, err = New(Config{Driver: DriverS3, S3Endpoint: "http://localhost:9000", S3Region: "us-east-1", S3Bucket: "media", S3AccessKey: "k", S3SecretKey: "s"})
+	require.NoError(t, err)
+	assert.IsType(t, &S3{}, s)
+
+	_, err = New(Config{Driver: "ftp"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Storage keeps uploaded media under slash-separated keys such as "uploads/projects/1_cover.png"
+type Storage interface {
+	// Put stores the content of r under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the object stored under key; it returns ErrNotFound when there is none
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the object under key is served from
+	URL(key string) string
+	// Key maps a URL returned by URL back to its key, reporting false for any other URL
+	Key(url string) (string, bool)
+	// List returns every object whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object describes a stored object
+type Object struct {
+	Key     string    `json:"key"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Storage errors
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Supported drivers
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Local storage defaults. The directory is served by the router under /public;
+// the private directory is not served at all.
+const (
+	DefaultLocalDir        = "public/assets"
+	DefaultLocalPrivateDir = "private"
+	DefaultLocalURL        = "/public/assets"
+)
+
+// PrivatePrefix marks keys whose objects must never be publicly reachable. The local
+// driver keeps them outside the served directory; an S3 bucket policy must only make
+// the other prefixes public.
+const PrivatePrefix = "private/"
+
+// Config holds storage configuration
+type Config struct {
+	Driver          string
+	LocalDir        string
+	LocalPrivateDir string
+	LocalURL        string
+	S3Endpoint      string
+	S3Region        string
+	S3Bucket        string
+	S3AccessKey     string
+	S3SecretKey     string
+	S3PublicURL     string
+}
+
+// GetDefaultConfig returns the storage configuration from the environment
+func GetDefaultConfig() Config {
+	return Config{
+		Driver:          getEnv("STORAGE_DRIVER", DriverLocal),
+		LocalDir:        DefaultLocalDir,
+		LocalPrivateDir: DefaultLocalPrivateDir,
+		LocalURL:        DefaultLocalURL,
+		S3Endpoint:      getEnv("S3_ENDPOINT", ""),
+		S3Region:        getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:        getEnv("S3_BUCKET", ""),
+		S3AccessKey:     getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretKey:     getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3PublicURL:     getEnv("S3_PUBLIC_URL", ""),
+	}
+}
+
+// New creates the storage selected by config.Driver
+func New(config Config) (Storage, error) {
+	switch config.Driver {
+	case DriverLocal, "":
+		return NewLocal(config.LocalDir, config.LocalPrivateDir, config.LocalURL), nil
+	case DriverS3:
+		return NewS3(S3Config{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			PublicURL: config.S3PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Driver)
+	}
+}
+
+// validKey rejects keys that could escape the storage root
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// keyFromURL strips base from url and validates what is left
+func keyFromURL(base, url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, strings.TrimSuffix(base, "/")+"/")
+	if !ok || validKey(key) != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// getEnv gets environment variable with default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
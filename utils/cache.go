@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PublicCacheControl makes shared caches and browsers revalidate on every use,
+// so a mutation is visible immediately while unchanged content costs a 304
+const PublicCacheControl = "public, no-cache"
+
+// ComputeETag returns a strong ETag for the JSON encoding of data.
+// The variant keeps different representations (e.g. HTML and JSON) of the same data apart.
+func ComputeETag(variant string, data any) (string, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return `"` + variant + "-" + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// CheckNotModified sets the cache validators on the response and answers with
+// 304 Not Modified when the request preconditions show the client copy is current.
+// It reports whether the 304 was written, in which case the caller must stop.
+func CheckNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	w.Header().Set("Cache-Control", PublicCacheControl)
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	// If-None-Match takes precedence over If-Modified-Since (RFC 9110 section 13.2.2)
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" || !etagMatches(inm, etag) {
+			return false
+		}
+		writeNotModified(w)
+		return true
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil || lastModified.After(since) {
+			return false
+		}
+		writeNotModified(w)
+		return true
+	}
+
+	return false
+}
+
+// etagMatches performs the weak comparison used by If-None-Match
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeNotModified writes a 304 response without representation headers
+func writeNotModified(w http.ResponseWriter) {
+	h := w.Header()
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeETag_StableAndVariantSpecific(t *testing.T) {
+	data := map[string]string{"name": "John Doe"}
+
+	first, err := ComputeETag("json", data)
+	assert.NoError(t, err)
+	second, _ := ComputeETag("json", data)
+	html, _ := ComputeETag("html", data)
+	changed, _ := ComputeETag("json", map[string]string{"name": "Jane Doe"})
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, html)
+	assert.NotEqual(t, first, changed)
+}
+
+func TestCheckNotModified(t *testing.T) {
+	modified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	etag := `"json-abc"`
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    bool
+	}{
+		{"no preconditions", http.MethodGet, nil, false},
+		{"matching etag", http.MethodGet, map[string]string{"If-None-Match": etag}, true},
+		{"weak matching etag in list", http.MethodGet, map[string]string{"If-None-Match": `"other", W/"json-abc"`}, true},
+		{"wildcard etag", http.MethodGet, map[string]string{"If-None-Match": "*"}, true},
+		{"stale etag", http.MethodGet, map[string]string{"If-None-Match": `"json-old"`}, false},
+		{"stale etag wins over fresh date", http.MethodGet, map[string]string{
+			"If-None-Match":     `"json-old"`,
+			"If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat),
+		}, false},
+		{"not modified since", http.MethodGet, map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, true},
+		{"modified since", http.MethodGet, map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)}, false},
+		{"unparseable date", http.MethodGet, map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"unsafe method", http.MethodPost, map[string]string{"If-None-Match": etag}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			got := CheckNotModified(w, r, etag, modified.Add(300*time.Millisecond))
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Equal(t, modified.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+			assert.Equal(t, PublicCacheControl, w.Header().Get("Cache-Control"))
+			if tt.want {
+				assert.Equal(t, http.StatusNotModified, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// errGIFMalformed reports a GIF whose block structure cannot be walked
+var errGIFMalformed = errors.New("malformed GIF")
+
+// gifFrames walks the block structure of a GIF without decompressing anything and
+// returns its number of frames and the pixels decoding all of them would allocate
+func gifFrames(data []byte) (frames, pixels int, err error) {
+	if len(data) < 13 || !(bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))) {
+		return 0, 0, errGIFMalformed
+	}
+
+	// Logical screen descriptor, then the optional global color table
+	i := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		i += 3 << ((flags & 0x07) + 1)
+	}
+
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: // Extension: label, then data sub-blocks
+			if i+2 > len(data) {
+				return 0, 0, errGIFMalformed
+			}
+			if i, err = skipSubBlocks(data, i+2); err != nil {
+				return 0, 0, err
+			}
+		case 0x2C: // Image descriptor, optional local color table, LZW code size, then image data sub-blocks
+			if i+10 > len(data) {
+				return 0, 0, errGIFMalformed
+			}
+			width := int(binary.LittleEndian.Uint16(data[i+5:]))
+			height := int(binary.LittleEndian.Uint16(data[i+7:]))
+			frames++
+			pixels += width * height
+
+			flags := data[i+9]
+			i += 10
+			if flags&0x80 != 0 {
+				i += 3 << ((flags & 0x07) + 1)
+			}
+			if i, err = skipSubBlocks(data, i+1); err != nil {
+				return 0, 0, err
+			}
+		case 0x3B: // Trailer
+			return frames, pixels, nil
+		default:
+			return 0, 0, errGIFMalformed
+		}
+	}
+	return 0, 0, errGIFMalformed
+}
+
+// skipSubBlocks returns the offset following the data sub-blocks starting at i
+func skipSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errGIFMalformed
+		}
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"path/filepath"
+	"session-19/storage"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPDFSize is the maximum PDF size (10MB)
+const MaxPDFSize = 10 * 1024 * 1024
+
+// MaxPDFRequestSize bounds a whole multipart request carrying one PDF
+const MaxPDFRequestSize = MaxPDFSize + 1024*1024
+
+// PDF upload errors
+var (
+	ErrPDFTooLarge = errors.New("file size exceeds maximum allowed size (10MB)")
+	ErrPDFInvalid  = errors.New("file is not a valid PDF document")
+)
+
+// pdfTrailerWindow is how far from the end of the file the %%EOF marker is looked for.
+// Writers may append whitespace or a few bytes of garbage after it.
+const pdfTrailerWindow = 1024
+
+// PDFUpload is a validated PDF ready to be saved
+type PDFUpload struct {
+	Filename string // sanitized client filename with a .pdf extension
+	Data     []byte
+	Hash     string // hex SHA-256 of the file
+}
+
+// ReadPDF validates an uploaded PDF.
+//
+// As with images, the client-supplied name and type are not trusted. The file must
+// sniff as a PDF, start with a %PDF-1.x or %PDF-2.x header, end with an %%EOF
+// trailer, and its startxref offset must point at a cross-reference table or stream.
+// That rejects renamed files and truncated uploads without parsing the whole document.
+func ReadPDF(file multipart.File, header *multipart.FileHeader) (*PDFUpload, error) {
+	data, err := io.ReadAll(io.LimitReader(file, MaxPDFSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	if len(data) > MaxPDFSize {
+		return nil, ErrPDFTooLarge
+	}
+	if err := checkPDF(data); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(sanitizeFilename(header.Filename), filepath.Ext(header.Filename))
+	if name == "" {
+		name = "cv"
+	}
+	sum := sha256.Sum256(data)
+
+	return &PDFUpload{
+		Filename: name + ".pdf",
+		Data:     data,
+		Hash:     hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Save stores the PDF under uploadDir in store with a unique name and returns its URL
+func (pdf *PDFUpload) Save(ctx context.Context, store storage.Storage, uploadDir string) (string, error) {
+	key := path.Join(uploadDir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), pdf.Filename))
+	if err := store.Put(ctx, key, bytes.NewReader(pdf.Data), "application/pdf"); err != nil {
+		return "", fmt.Errorf("failed to save file: %v", err)
+	}
+	return store.URL(key), nil
+}
+
+// checkPDF verifies the header, trailer and cross-reference offset of a PDF
+func checkPDF(data []byte) error {
+	if http.DetectContentType(data) != "application/pdf" {
+		return ErrPDFInvalid
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.")) && !bytes.HasPrefix(data, []byte("%PDF-2.")) {
+		return ErrPDFInvalid
+	}
+
+	tail := data[max(0, len(data)-pdfTrailerWindow):]
+	eof := bytes.LastIndex(tail, []byte("%%EOF"))
+	if eof < 0 {
+		return ErrPDFInvalid
+	}
+	tail = tail[:eof]
+
+	// startxref is followed by the byte offset of the last cross-reference section
+	i := bytes.LastIndex(tail, []byte("startxref"))
+	if i < 0 {
+		return ErrPDFInvalid
+	}
+	offset, err := strconv.Atoi(string(bytes.TrimSpace(tail[i+len("startxref"):])))
+	if err != nil || offset <= 0 || offset >= len(data) {
+		return ErrPDFInvalid
+	}
+
+	// Either a classic "xref" table or a cross-reference stream object ("12 0 obj")
+	section := data[offset:min(len(data), offset+64)]
+	if bytes.HasPrefix(section, []byte("xref")) {
+		return nil
+	}
+	fields := bytes.Fields(section)
+	if len(fields) >= 3 && bytes.HasPrefix(fields[2], []byte("obj")) {
+		if _, err := strconv.Atoi(string(fields[0])); err == nil {
+			return nil
+		}
+	}
+	return ErrPDFInvalid
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minimalPDF builds a one-page PDF with a correct cross-reference table
+func minimalPDF() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] >>",
+	}
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func readPDF(data []byte, filename string) (*PDFUpload, error) {
+	return ReadPDF(memFile{bytes.NewReader(data)}, &multipart.FileHeader{Filename: filename, Size: int64(len(data))})
+}
+
+func TestReadPDF_Valid(t *testing.T) {
+	data := minimalPDF()
+
+	pdf, err := readPDF(data, "My CV (2024).PDF")
+
+	require.NoError(t, err)
+	assert.Equal(t, "My_CV__2024_.pdf", pdf.Filename)
+	assert.Equal(t, data, pdf.Data)
+	assert.Len(t, pdf.Hash, 64)
+}
+
+func TestReadPDF_Rejects(t *testing.T) {
+	valid := minimalPDF()
+	tests := map[string][]byte{
+		"png renamed to pdf": encodePNG(t, 2, 2),
+		"html":               []byte("<html><body>%PDF-1.4</body></html>"),
+		"truncated":          valid[:len(valid)-40],
+		"missing trailer":    bytes.Replace(valid, []byte("%%EOF"), []byte("%%XXX"), 1),
+		"bad xref offset":    bytes.Replace(valid, []byte("startxref\n"), []byte("startxref\n9"), 1),
+		"only a header":      []byte("%PDF-1.7\n%%EOF\n"),
+	}
+	for name, data := range tests {
+		_, err := readPDF(data, "cv.pdf")
+		assert.ErrorIs(t, err, ErrPDFInvalid, name)
+	}
+}
+
+func TestReadPDF_TooLarge(t *testing.T) {
+	data := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("a"), MaxPDFSize)...)
+
+	_, err := readPDF(data, "cv.pdf")
+
+	assert.ErrorIs(t, err, ErrPDFTooLarge)
+}
+
+func TestReadPDF_AcceptsCrossReferenceStream(t *testing.T) {
+	body := "%PDF-1.5\n1 0 obj\n<< /Type /Catalog >>\nendobj\n"
+	xref := len(body)
+	data := fmt.Sprintf("%s2 0 obj\n<< /Type /XRef /Size 3 >>\nstream\nendstream\nendobj\nstartxref\n%d\n%%%%EOF", body, xref)
+
+	_, err := readPDF([]byte(data), "cv")
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix prefixes the error code to form the problem type URI
+const ProblemTypePrefix = "urn:portfolio:problem:"
+
+// Problem is an RFC 9457 problem details object.
+// Code and Errors are extension members shared with the JSON envelope.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	Errors   any    `json:"errors,omitempty"`
+}
+
+// ResponseProblem writes problem details as application/problem+json
+func ResponseProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// WantsProblem reports whether the Accept header asks for application/problem+json.
+// Clients that do not ask keep getting the JSON envelope.
+func WantsProblem(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		return params["q"] != "0"
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWantsProblem(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json, application/problem+json;q=0.9", true},
+		{"application/problem+json;q=0", false},
+		{"*/*", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.want, WantsProblem(r))
+		})
+	}
+}
+
+func TestResponseProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	ResponseProblem(w, Problem{Type: ProblemTypePrefix + "not_found", Title: "Resource not found", Status: http.StatusNotFound, Code: "not_found"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "not_found", body["code"])
+	assert.Equal(t, float64(http.StatusNotFound), body["status"])
+}
@@ -9,6 +9,7 @@ type Reponse struct {
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
 	Data    any    `json:"data,omitempty"`
+	Code    string `json:"code,omitempty"`
 	Errors  any    `json:"errors,omitempty"`
 }
 
@@ -53,3 +54,16 @@ func ResponsePagination(w http.ResponseWriter, code int, message string, data an
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(response)
 }
+
+// ResponseError writes a failed response envelope carrying a stable, machine-readable error code
+func ResponseError(w http.ResponseWriter, code int, message string, errorCode string, errors any) {
+	response := Reponse{
+		Status:  false,
+		Message: message,
+		Code:    errorCode,
+		Errors:  errors,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength bounds generated slugs; the slug columns in migrations.sql allow a little more
+// so a numeric suffix always fits
+const MaxSlugLength = 80
+
+// Slugify turns s into a URL path segment of lowercase ASCII letters, digits and
+// single hyphens, e.g. "Café Über Go!" becomes "cafe-uber-go". Accents are dropped;
+// other characters separate words. The result may be empty.
+func Slugify(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left by NFD, e.g. the accent of "é"
+		default:
+			hyphen = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = slug[:MaxSlugLength]
+		// Cut at a word boundary when there is one
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// UniqueSlug returns base, or base with the first numeric suffix from 2 up,
+// e.g. "portfolio-2", for which taken reports false
+func UniqueSlug(base string, taken func(slug string) (bool, error)) (string, error) {
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Portfolio Website", "portfolio-website"},
+		{"  Go vs. Node.js: a Study!  ", "go-vs-node-js-a-study"},
+		{"Café Über Go", "cafe-uber-go"},
+		{"already-a-slug", "already-a-slug"},
+		{"--a__b--", "a-b"},
+		{"日本語", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Slugify(tt.in), tt.in)
+	}
+}
+
+func TestSlugify_Length(t *testing.T) {
+	slug := Slugify(strings.Repeat("word ", 40))
+	assert.LessOrEqual(t, len(slug), MaxSlugLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	assert.True(t, strings.HasSuffix(slug, "word"))
+}
+
+func TestUniqueSlug(t *testing.T) {
+	taken := map[string]bool{"demo": true, "demo-2": true}
+	slug, err := UniqueSlug("demo", func(s string) (bool, error) { return taken[s], nil })
+	require.NoError(t, err)
+	assert.Equal(t, "demo-3", slug)
+
+	slug, err = UniqueSlug("other", func(s string) (bool, error) { return taken[s], nil })
+	require.NoError(t, err)
+	assert.Equal(t, "other", slug)
+
+	_, err = UniqueSlug("demo", func(string) (bool, error) { return false, errors.New("db down") })
+	assert.Error(t, err)
+}
@@ -1,82 +1,236 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"io"
 	"mime/multipart"
-	"os"
+	"net/http"
+	"path"
 	"path/filepath"
+	"session-19/storage"
 	"strings"
 	"time"
+
+	"github.com/HugoSmits86/nativewebp"
+	_ "golang.org/x/image/webp" // registers the WebP decoder with image.Decode
 )
 
-// AllowedImageExtensions contains allowed image file extensions
-var AllowedImageExtensions = map[string]bool{
-	".jpg":  true,
-	".jpeg": true,
-	".png":  true,
-	".gif":  true,
-	".webp": true,
+// AllowedImageTypes maps the sniffed content type of an allowed upload to the extension it is saved with
+var AllowedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
 }
 
 // MaxFileSize is the maximum file size (5MB)
 const MaxFileSize = 5 * 1024 * 1024
 
-// UploadFile handles file upload and returns the file path
-func UploadFile(file multipart.File, header *multipart.FileHeader, uploadDir string) (string, error) {
-	// Check file size
-	if header.Size > MaxFileSize {
-		return "", fmt.Errorf("file size exceeds maximum allowed size (5MB)")
-	}
+// MaxUploadRequestSize bounds a whole multipart request: one file plus the form fields
+const MaxUploadRequestSize = MaxFileSize + 1024*1024
+
+// Decoded image limits. A small, highly compressed file can expand to gigabytes
+// of pixels, so dimensions are checked from the header before decoding. Every
+// frame of a GIF is decoded, so their pixels are bounded together.
+const (
+	MaxImageDimension = 8192
+	MaxImagePixels    = 40_000_000
+	MaxGIFFrames      = 300
+	MaxGIFPixels      = 100_000_000
+)
 
-	// Check file extension
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if !AllowedImageExtensions[ext] {
-		return "", fmt.Errorf("file type not allowed. Allowed types: jpg, jpeg, png, gif, webp")
-	}
+// UploadsPrefix is the storage key prefix of every uploaded file
+const UploadsPrefix = "uploads/"
+
+// MediaUploadDir holds media library files. They belong to their library entry
+// rather than to the records that pick them, so DeleteUploads leaves them alone.
+const MediaUploadDir = "uploads/media"
+
+// CVUploadDir holds uploaded CV/résumé PDFs. It is private: CVs are only handed out
+// through /cv and the admin, which count downloads and set the filename.
+const CVUploadDir = storage.PrivatePrefix + "cv"
+
+// jpegQuality is used when re-encoding uploaded JPEGs
+const jpegQuality = 90
+
+// Upload errors
+var (
+	ErrFileTooLarge       = errors.New("file size exceeds maximum allowed size (5MB)")
+	ErrFileTypeNotAllowed = errors.New("file type not allowed. Allowed types: jpg, jpeg, png, gif, webp")
+	ErrImageInvalid       = errors.New("file is not a valid image")
+	ErrImageTooLarge      = fmt.Errorf("image dimensions exceed %dx%d or %d pixels", MaxImageDimension, MaxImageDimension, MaxImagePixels)
+)
+
+// ImageUpload is a validated, re-encoded image ready to be saved
+type ImageUpload struct {
+	Filename    string // sanitized client filename, extension following the detected type
+	ContentType string
+	Data        []byte // re-encoded image
+	Width       int
+	Height      int
+	Hash        string // hex SHA-256 of the uploaded bytes
+}
 
-	// Create upload directory if not exists
-	uploadPath := filepath.Join("public", "assets", uploadDir)
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return "", fmt.Errorf("failed to create upload directory: %v", err)
+// ReadImage validates an uploaded image and re-encodes it.
+//
+// The client-supplied filename and size are not trusted: the type is sniffed from the
+// content, the size limit is enforced on the stream, and the image is fully decoded.
+// Keeping the decoded pixels rather than the original bytes drops EXIF/GPS metadata and
+// anything smuggled after the image data (polyglot files).
+func ReadImage(file multipart.File, header *multipart.FileHeader) (*ImageUpload, error) {
+	data, err := io.ReadAll(io.LimitReader(file, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrFileTooLarge
 	}
 
-	// Generate unique filename
-	filename := fmt.Sprintf("%d_%s", time.Now().UnixNano(), sanitizeFilename(header.Filename))
-	filePath := filepath.Join(uploadPath, filename)
+	contentType := http.DetectContentType(data)
+	ext, ok := AllowedImageTypes[contentType]
+	if !ok {
+		return nil, ErrFileTypeNotAllowed
+	}
 
-	// Create destination file
-	dst, err := os.Create(filePath)
+	encoded, err := sanitizeImage(data, contentType)
 	if err != nil {
-		return "", fmt.Errorf("failed to create file: %v", err)
+		return nil, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, ErrImageInvalid
 	}
-	defer dst.Close()
 
-	// Copy file content
-	if _, err := io.Copy(dst, file); err != nil {
+	// The extension follows the detected type, not the client's name
+	name := strings.TrimSuffix(sanitizeFilename(header.Filename), filepath.Ext(header.Filename))
+	sum := sha256.Sum256(data)
+
+	return &ImageUpload{
+		Filename:    name + ext,
+		ContentType: contentType,
+		Data:        encoded,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Hash:        hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Save stores the image under uploadDir in store with a unique name and returns its URL
+func (img *ImageUpload) Save(ctx context.Context, store storage.Storage, uploadDir string) (string, error) {
+	key := path.Join(uploadDir, fmt.Sprintf("%d_%s", time.Now().UnixNano(), img.Filename))
+	if err := store.Put(ctx, key, bytes.NewReader(img.Data), img.ContentType); err != nil {
 		return "", fmt.Errorf("failed to save file: %v", err)
 	}
+	return store.URL(key), nil
+}
 
-	// Return relative path for storage in database (URL format)
-	return "/" + filepath.ToSlash(filePath), nil
+// UploadFile validates an uploaded image and saves a re-encoded copy under uploadDir
+// in store, returning its URL. See ReadImage for the checks applied.
+func UploadFile(ctx context.Context, store storage.Storage, file multipart.File, header *multipart.FileHeader, uploadDir string) (string, error) {
+	img, err := ReadImage(file, header)
+	if err != nil {
+		return "", err
+	}
+	return img.Save(ctx, store, uploadDir)
 }
 
-// DeleteFile removes a file from the filesystem
-func DeleteFile(filePath string) error {
-	if filePath == "" {
-		return nil
+// sanitizeImage decodes data and re-encodes it in the same format
+func sanitizeImage(data []byte, contentType string) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageInvalid
+	}
+	if err := checkDimensions(cfg.Width, cfg.Height); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/gif":
+		// Count the frames before decoding any of them: DecodeAll allocates them all
+		frames, pixels, err := gifFrames(data)
+		if err != nil {
+			return nil, ErrImageInvalid
+		}
+		if frames > MaxGIFFrames || pixels > MaxGIFPixels {
+			return nil, ErrImageTooLarge
+		}
+		// Decode every frame so animations survive the re-encode
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrImageInvalid
+		}
+		if err := gif.EncodeAll(&buf, g); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %v", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageInvalid
+	}
+
+	switch contentType {
+	case "image/jpeg":
+		// Metadata is dropped, so bake the EXIF orientation into the pixels first
+		img = applyOrientation(img, jpegOrientation(data))
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	case "image/webp":
+		err = nativewebp.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %v", err)
 	}
+	return buf.Bytes(), nil
+}
 
-	// Remove leading slash and convert to OS path
-	cleanPath := strings.TrimPrefix(filePath, "/")
-	cleanPath = filepath.FromSlash(cleanPath)
+// checkDimensions rejects images that would take too much memory to decode
+func checkDimensions(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return ErrImageInvalid
+	}
+	if width > MaxImageDimension || height > MaxImageDimension || width*height > MaxImagePixels {
+		return ErrImageTooLarge
+	}
+	return nil
+}
 
-	// Check if file exists
-	if _, err := os.Stat(cleanPath); os.IsNotExist(err) {
-		return nil // File doesn't exist, nothing to delete
+// DeleteUploads removes the uploaded files behind urls from store. URLs outside the
+// uploads area, such as external links or bundled sample assets, and media library
+// files are left alone.
+func DeleteUploads(ctx context.Context, store storage.Storage, urls ...string) error {
+	var errs []error
+	for _, url := range urls {
+		key, ok := uploadKey(store, url)
+		if !ok || strings.HasPrefix(key, MediaUploadDir+"/") {
+			continue
+		}
+		if err := store.Delete(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", url, err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	return os.Remove(cleanPath)
+// uploadKey returns the storage key of a URL pointing into the uploads area
+func uploadKey(store storage.Storage, url string) (string, bool) {
+	key, ok := store.Key(url)
+	if !ok || !strings.HasPrefix(key, UploadsPrefix) {
+		return "", false
+	}
+	return key, true
 }
 
 // sanitizeFilename removes special characters from filename
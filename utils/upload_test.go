@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"session-19/storage"
+	"strings"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memFile is an in-memory multipart.File
+type memFile struct {
+	*bytes.Reader
+}
+
+func (memFile) Close() error { return nil }
+
+// upload runs UploadFile against local storage in a temporary directory and returns the saved bytes
+func upload(t *testing.T, data []byte, filename string, claimedSize int64) ([]byte, string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	store := storage.NewLocal(dir, t.TempDir(), storage.DefaultLocalURL)
+
+	url, err := UploadFile(context.Background(), store, memFile{bytes.NewReader(data)}, &multipart.FileHeader{Filename: filename, Size: claimedSize}, "uploads/test")
+	if err != nil {
+		return nil, "", err
+	}
+	key, ok := store.Key(url)
+	require.True(t, ok)
+	saved, readErr := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+	require.NoError(t, readErr)
+	return saved, url, nil
+}
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// jpegWithOrientation builds a JPEG whose APP1 segment carries an EXIF orientation and a GPS marker
+func jpegWithOrientation(t *testing.T, w, h int, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil))
+	plain := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], exifOrientationTag)
+	binary.BigEndian.PutUint16(entry[2:], 3) // SHORT
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, []byte("\x00\x00\x00\x00GPSLatitude")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(payload)+2))
+	app1 = append(app1, payload...)
+
+	out := append([]byte{}, plain[:2]...)
+	out = append(out, app1...)
+	return append(out, plain[2:]...)
+}
+
+func TestUploadFile_SavesReencodedImageWithSniffedExtension(t *testing.T) {
+	data := encodePNG(t, 4, 4)
+
+	saved, path, err := upload(t, data, "photo.jpg", int64(len(data)))
+
+	require.NoError(t, err)
+	assert.Equal(t, ".png", filepath.Ext(path))
+	assert.True(t, strings.HasPrefix(path, "/public/assets/uploads/test/"))
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(saved))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, 4, cfg.Width)
+}
+
+func TestUploadFile_RejectsDisguisedFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		filename string
+		want     error
+	}{
+		{"html renamed to jpg", []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), "cat.jpg", ErrFileTypeNotAllowed},
+		{"svg renamed to png", []byte(`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`), "cat.png", ErrFileTypeNotAllowed},
+		{"truncated png", encodePNG(t, 4, 4)[:40], "cat.png", ErrImageInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := upload(t, tt.data, tt.filename, int64(len(tt.data)))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestUploadFile_StripsTrailingPolyglotPayload(t *testing.T) {
+	data := append(encodePNG(t, 4, 4), []byte("<script>alert(1)</script>")...)
+
+	saved, _, err := upload(t, data, "cat.png", int64(len(data)))
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "<script>")
+}
+
+func TestUploadFile_EnforcesSizeOnStream(t *testing.T) {
+	data := append(encodePNG(t, 4, 4), make([]byte, MaxFileSize)...)
+
+	// The header claims a tiny file; the stream is what counts
+	_, _, err := upload(t, data, "cat.png", 10)
+
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+}
+
+func TestUploadFile_RejectsDecompressionBomb(t *testing.T) {
+	data := encodePNG(t, MaxImageDimension+1, 1)
+
+	_, _, err := upload(t, data, "bomb.png", int64(len(data)))
+
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+}
+
+// gifBomb builds a GIF of frames frames of w×h pixels, each carrying only an LZW
+// clear code, so the file stays tiny whatever the frames would decode to
+func gifBomb(w, h, frames int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("GIF89a")
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{uint16(w), uint16(h)})
+	buf.Write([]byte{0x80, 0, 0, 0, 0, 0, 0xFF, 0xFF, 0xFF}) // two-color global table
+	for i := 0; i < frames; i++ {
+		buf.WriteByte(0x2C)
+		binary.Write(&buf, binary.LittleEndian, [4]uint16{0, 0, uint16(w), uint16(h)})
+		buf.Write([]byte{0, 2, 1, 0x04, 0})
+	}
+	buf.WriteByte(0x3B)
+	return buf.Bytes()
+}
+
+func TestUploadFile_RejectsGIFBombBeforeDecoding(t *testing.T) {
+	// Each frame is within the single-image limits; together they are not
+	data := gifBomb(4000, 4000, 200)
+	require.Less(t, len(data), MaxFileSize)
+
+	_, _, err := upload(t, data, "bomb.gif", int64(len(data)))
+
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+
+	data = gifBomb(1, 1, MaxGIFFrames+1)
+	_, _, err = upload(t, data, "frames.gif", int64(len(data)))
+
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+}
+
+func TestUploadFile_KeepsAnimatedGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	anim := &gif.GIF{
+		Image: []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 8, 8), palette), image.NewPaletted(image.Rect(0, 0, 8, 8), palette)},
+		Delay: []int{10, 10},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, anim))
+
+	saved, _, err := upload(t, buf.Bytes(), "anim.gif", int64(buf.Len()))
+
+	require.NoError(t, err)
+	g, err := gif.DecodeAll(bytes.NewReader(saved))
+	require.NoError(t, err)
+	assert.Len(t, g.Image, 2)
+}
+
+func TestUploadFile_StripsExifAndAppliesOrientation(t *testing.T) {
+	data := jpegWithOrientation(t, 8, 4, 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	saved, _, err := upload(t, data, "portrait.jpeg", int64(len(data)))
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "Exif")
+	assert.NotContains(t, string(saved), "GPSLatitude")
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(saved))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, cfg.Width)
+	assert.Equal(t, 8, cfg.Height)
+}
+
+func TestUploadFile_AcceptsWebP(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, nativewebp.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 3, 2)), nil))
+
+	saved, path, err := upload(t, buf.Bytes(), "photo.webp", int64(buf.Len()))
+
+	require.NoError(t, err)
+	assert.Equal(t, ".webp", filepath.Ext(path))
+	assert.Equal(t, "image/webp", http.DetectContentType(saved))
+}
+
+func TestDeleteUploads_OnlyTouchesUploads(t *testing.T) {
+	dir := t.TempDir()
+	store := storage.NewLocal(dir, t.TempDir(), storage.DefaultLocalURL)
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "uploads/test/a.png", bytes.NewReader([]byte("a")), "image/png"))
+	require.NoError(t, store.Put(ctx, "profile.jpg", bytes.NewReader([]byte("sample")), "image/jpeg"))
+	require.NoError(t, store.Put(ctx, "uploads/media/b.png", bytes.NewReader([]byte("b")), "image/png"))
+
+	err := DeleteUploads(ctx, store, "/public/assets/uploads/test/a.png", "/public/assets/profile.jpg", "https://example.com/uploads/x.png", "",
+		"/public/assets/uploads/media/b.png")
+
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "uploads", "test", "a.png"))
+	assert.FileExists(t, filepath.Join(dir, "profile.jpg"))
+	assert.FileExists(t, filepath.Join(dir, "uploads", "media", "b.png"), "media library files are owned by their library entry")
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"session-19/model"
+	"session-19/storage"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+)
+
+// ResponsiveWidths are the widths, in pixels, of the resized copies made for each upload
+var ResponsiveWidths = []int{320, 640, 1280}
+
+// GenerateImageVariants stores resized copies of an uploaded image next to it and
+// returns them together with the original, ready to be recorded alongside the image URL.
+//
+// Each width narrower than the original gets a copy in the original format and, when
+// smaller, a WebP copy. The WebP encoder is lossless, so for photos the JPEG is often
+// the lighter file and no WebP is kept. Animated GIFs are left alone.
+func GenerateImageVariants(ctx context.Context, store storage.Storage, imageURL string) (model.ImageVariants, error) {
+	key, ok := uploadKey(store, imageURL)
+	if !ok {
+		return nil, fmt.Errorf("image %s is not an upload", imageURL)
+	}
+	rc, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, MaxFileSize+1))
+	rc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %v", err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType == "image/gif" {
+		return nil, nil
+	}
+	if _, ok := AllowedImageTypes[contentType]; !ok {
+		return nil, ErrFileTypeNotAllowed
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageInvalid
+	}
+	width := img.Bounds().Dx()
+
+	base := strings.TrimSuffix(key, path.Ext(key))
+	variants := model.ImageVariants{{URL: imageURL, Width: width, Type: contentType}}
+	var written []string
+
+	save := func(variantKey, variantType string, encoded []byte, w int) error {
+		if err := store.Put(ctx, variantKey, bytes.NewReader(encoded), variantType); err != nil {
+			return fmt.Errorf("failed to save image variant: %v", err)
+		}
+		written = append(written, variantKey)
+		variants = append(variants, model.ImageVariant{URL: store.URL(variantKey), Width: w, Type: variantType})
+		return nil
+	}
+
+	// addWebP keeps a WebP copy only when it beats the fallback it would replace
+	addWebP := func(src image.Image, w int, variantKey string, fallbackSize int) error {
+		if contentType == "image/webp" {
+			return nil
+		}
+		encoded, err := encodeImage(src, "image/webp")
+		if err != nil || len(encoded) >= fallbackSize {
+			return err
+		}
+		return save(variantKey, "image/webp", encoded, w)
+	}
+
+	err = func() error {
+		if err := addWebP(img, width, base+".webp", len(data)); err != nil {
+			return err
+		}
+		for _, w := range ResponsiveWidths {
+			if w >= width {
+				continue
+			}
+			resized := resizeToWidth(img, w)
+			encoded, err := encodeImage(resized, contentType)
+			if err != nil {
+				return err
+			}
+			if err := save(fmt.Sprintf("%s_%dw%s", base, w, path.Ext(key)), contentType, encoded, w); err != nil {
+				return err
+			}
+
+			if err := addWebP(resized, w, fmt.Sprintf("%s_%dw.webp", base, w), len(encoded)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		for _, variantKey := range written {
+			store.Delete(ctx, variantKey)
+		}
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// resizeToWidth scales img to the given width, keeping its aspect ratio
+func resizeToWidth(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	height := max(1, b.Dy()*width/b.Dx())
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// encodeImage encodes img in the format of contentType
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	case "image/webp":
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		return nil, ErrFileTypeNotAllowed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %v", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math/rand"
+	"session-19/storage"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeImage stores data as an upload in local storage under a temporary directory
+func writeImage(t *testing.T, data []byte, name string) (storage.Storage, string) {
+	t.Helper()
+	store := storage.NewLocal(t.TempDir(), t.TempDir(), storage.DefaultLocalURL)
+	key := "uploads/test/" + name
+	require.NoError(t, store.Put(context.Background(), key, bytes.NewReader(data), ""))
+	return store, store.URL(key)
+}
+
+func readSize(t *testing.T, store storage.Storage, url string) (int, image.Config) {
+	t.Helper()
+	key, ok := store.Key(url)
+	require.True(t, ok)
+	rc, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return len(data), cfg
+}
+
+func TestGenerateImageVariants_ResizesToResponsiveWidths(t *testing.T) {
+	// Noise keeps the JPEG honest; a flat image would compress to almost nothing
+	img := image.NewRGBA(image.Rect(0, 0, 1600, 800))
+	rnd := rand.New(rand.NewSource(1))
+	for i := range img.Pix {
+		img.Pix[i] = byte(rnd.Intn(256))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	store, url := writeImage(t, buf.Bytes(), "photo.jpg")
+
+	variants, err := GenerateImageVariants(context.Background(), store, url)
+
+	require.NoError(t, err)
+	assert.Equal(t, url, variants[0].URL)
+	assert.Equal(t, 1600, variants[0].Width)
+	assert.Equal(t,
+		"/public/assets/uploads/test/photo_320w.jpg 320w, /public/assets/uploads/test/photo_640w.jpg 640w, "+
+			"/public/assets/uploads/test/photo_1280w.jpg 1280w, /public/assets/uploads/test/photo.jpg 1600w",
+		variants.OriginalSrcset())
+
+	for _, v := range variants {
+		_, cfg := readSize(t, store, v.URL)
+		assert.Equal(t, v.Width, cfg.Width, v.URL)
+		assert.Equal(t, v.Width/2, cfg.Height, v.URL)
+	}
+}
+
+func TestGenerateImageVariants_KeepsWebPOnlyWhenSmaller(t *testing.T) {
+	// Flat colour areas compress well losslessly
+	img := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x / 100 * 30), G: 120, B: 200, A: 255})
+		}
+	}
+	store, url := writeImage(t, encodeNRGBA(t, img), "chart.png")
+
+	variants, err := GenerateImageVariants(context.Background(), store, url)
+
+	require.NoError(t, err)
+	fallback := map[int]int{}
+	for _, v := range variants {
+		if v.Type == "image/png" {
+			fallback[v.Width], _ = readSize(t, store, v.URL)
+		}
+	}
+	assert.Len(t, fallback, 3) // 320, 640 and the original
+	for _, v := range variants {
+		if v.Type != "image/webp" {
+			continue
+		}
+		size, cfg := readSize(t, store, v.URL)
+		assert.Equal(t, v.Width, cfg.Width)
+		assert.Less(t, size, fallback[v.Width], v.URL)
+	}
+	// The WebP source still offers every width, filling gaps with the PNG copies
+	assert.Equal(t, len(strings.Split(variants.OriginalSrcset(), ", ")), len(strings.Split(variants.Srcset("image/webp"), ", ")))
+}
+
+func TestGenerateImageVariants_SmallImageHasOnlyTheOriginal(t *testing.T) {
+	store, url := writeImage(t, encodePNG(t, 200, 100), "icon.png")
+
+	variants, err := GenerateImageVariants(context.Background(), store, url)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, variants)
+	for _, v := range variants {
+		assert.Equal(t, 200, v.Width)
+	}
+}
+
+func TestGenerateImageVariants_SkipsGIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 800, 400), color.Palette{color.Black}), nil))
+	store, url := writeImage(t, buf.Bytes(), "anim.gif")
+
+	variants, err := GenerateImageVariants(context.Background(), store, url)
+
+	assert.NoError(t, err)
+	assert.Nil(t, variants)
+}
+
+func TestGenerateImageVariants_MissingFile(t *testing.T) {
+	store := storage.NewLocal(t.TempDir(), t.TempDir(), storage.DefaultLocalURL)
+
+	_, err := GenerateImageVariants(context.Background(), store, "/public/assets/uploads/test/missing.png")
+
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestGenerateImageVariants_IgnoresExternalImages(t *testing.T) {
+	store := storage.NewLocal(t.TempDir(), t.TempDir(), storage.DefaultLocalURL)
+
+	_, err := GenerateImageVariants(context.Background(), store, "https://example.com/cover.png")
+
+	assert.Error(t, err)
+}
+
+func encodeNRGBA(t *testing.T, img *image.NRGBA) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}